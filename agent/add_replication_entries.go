@@ -5,14 +5,11 @@ package agent
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 	"sync"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
@@ -38,25 +35,7 @@ func AddReplicationEntriesToPgHbaConf(confs []*idl.AddReplicationEntriesRequest_
 		go func(conf *idl.AddReplicationEntriesRequest_Entry) {
 			defer wg.Done()
 
-			var lines strings.Builder
-			lines.WriteString(fmt.Sprintf("host replication %s samehost trust\n", conf.GetUser()))
-			for _, mirrorHostAddr := range conf.GetHostAddrs() {
-				lines.WriteString(fmt.Sprintf("host all %s %s trust\n", conf.GetUser(), mirrorHostAddr))
-				lines.WriteString(fmt.Sprintf("host replication %s %s trust\n", conf.GetUser(), mirrorHostAddr))
-			}
-
-			file, err := os.OpenFile(filepath.Join(conf.GetDataDir(), "pg_hba.conf"), os.O_APPEND|os.O_WRONLY, 0600)
-			if err != nil {
-				errs <- err
-				return
-			}
-			defer func() {
-				if cErr := file.Close(); cErr != nil {
-					errs <- errorlist.Append(err, cErr)
-				}
-			}()
-
-			_, err = file.WriteString(lines.String())
+			err := hub.AppendReplicationEntries(conf.GetDataDir(), conf.GetUser(), conf.GetHostAddrs())
 			if err != nil {
 				errs <- err
 			}
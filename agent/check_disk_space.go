@@ -15,7 +15,13 @@ import (
 func (s *Server) CheckDiskSpace(ctx context.Context, in *idl.CheckSegmentDiskSpaceRequest) (*idl.CheckDiskSpaceReply, error) {
 	gplog.Info("agent received request to %s", idl.Substep_CHECK_DISK_SPACE)
 
-	usage, err := disk.CheckUsage(step.DevNullStream, disk.Local, in.GetDiskFreeRatio(), in.GetDirs()...)
+	var usage disk.FileSystemDiskUsage
+	var err error
+	if in.GetDiskFreeRatio() > 0 {
+		usage, err = disk.CheckUsage(step.DevNullStream, disk.Local, in.GetDiskFreeRatio(), in.GetDirs()...)
+	} else {
+		usage, err = disk.EstimateUsage(step.DevNullStream, disk.Local, in.GetRequirements()...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,30 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func (s *Server) CheckExtensions(ctx context.Context, req *idl.CheckExtensionsRequest) (*idl.CheckExtensionsReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_CHECK_EXTENSIONS)
+
+	reply, err := greenplum.CheckExtensionFiles(req.GetGphome(), req.GetExtensions())
+	if err != nil {
+		return &idl.CheckExtensionsReply{}, err
+	}
+
+	reply.Hostname, err = os.Hostname()
+	if err != nil {
+		return &idl.CheckExtensionsReply{}, err
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestCheckExtensions(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	reply, err := server.CheckExtensions(context.Background(), &idl.CheckExtensionsRequest{
+		Gphome:     gphome,
+		Extensions: []string{"postgis"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &idl.CheckExtensionsReply{
+		Hostname:   hostname,
+		Extensions: []*idl.CheckExtensionsReply_Extension{{Name: "postgis"}},
+	}
+	if !reflect.DeepEqual(reply, expected) {
+		t.Errorf("got %v want %v", reply, expected)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func (s *Server) CheckFilesystems(ctx context.Context, req *idl.CheckFilesystemsRequest) (*idl.CheckFilesystemsReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_CHECK_LINK_MODE_FILESYSTEMS)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return &idl.CheckFilesystemsReply{}, err
+	}
+
+	devices, err := disk.CheckFilesystems(disk.Local, req.GetPairs()...)
+	if err != nil {
+		return &idl.CheckFilesystemsReply{}, err
+	}
+
+	return &idl.CheckFilesystemsReply{
+		Hostname: hostname,
+		Devices:  devices,
+	}, nil
+}
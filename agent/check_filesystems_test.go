@@ -0,0 +1,68 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestCheckFilesystems(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	source := filepath.Join(dir, "demoDataDir0")
+	testutils.MustCreateDir(t, source)
+
+	t.Run("returns the same device for a target that does not exist yet", func(t *testing.T) {
+		reply, err := server.CheckFilesystems(context.Background(), &idl.CheckFilesystemsRequest{
+			Pairs: []*idl.CheckFilesystemsRequest_DirPair{
+				{Dbid: 2, Source: source, Target: filepath.Join(dir, "demoDataDir.AAAAAAAAAAA.0")},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		hostname, err := os.Hostname()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reply.GetHostname() != hostname {
+			t.Errorf("got hostname %q want %q", reply.GetHostname(), hostname)
+		}
+
+		if len(reply.GetDevices()) != 1 {
+			t.Fatalf("got %d devices want 1", len(reply.GetDevices()))
+		}
+
+		devices := reply.GetDevices()[0]
+		if devices.GetSourceDevice() != devices.GetTargetDevice() {
+			t.Errorf("got source device %d and target device %d, want them to be equal", devices.GetSourceDevice(), devices.GetTargetDevice())
+		}
+	})
+
+	t.Run("errors when the source directory does not exist", func(t *testing.T) {
+		_, err := server.CheckFilesystems(context.Background(), &idl.CheckFilesystemsRequest{
+			Pairs: []*idl.CheckFilesystemsRequest_DirPair{
+				{Dbid: 2, Source: filepath.Join(dir, "missing"), Target: filepath.Join(dir, "target")},
+			},
+		})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want not exist", err)
+		}
+	})
+}
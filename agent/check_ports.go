@@ -0,0 +1,28 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func (s *Server) CheckPorts(ctx context.Context, req *idl.CheckPortsRequest) (*idl.CheckPortsReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_CHECK_TARGET_PORTS)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return &idl.CheckPortsReply{}, err
+	}
+
+	return &idl.CheckPortsReply{
+		Hostname:         hostname,
+		UnavailablePorts: utils.UnavailablePorts(req.GetPorts()),
+	}, nil
+}
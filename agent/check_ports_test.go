@@ -0,0 +1,37 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestCheckPorts(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	inUse := uint32(listener.Addr().(*net.TCPAddr).Port)
+
+	reply, err := server.CheckPorts(context.Background(), &idl.CheckPortsRequest{Ports: []uint32{inUse}})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := []uint32{inUse}
+	if !reflect.DeepEqual(reply.GetUnavailablePorts(), expected) {
+		t.Errorf("got unavailable ports %v want %v", reply.GetUnavailablePorts(), expected)
+	}
+}
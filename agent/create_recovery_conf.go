@@ -10,16 +10,27 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/conf"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
+const replicationSlotName = "internal_wal_replication_slot"
+
 func (s *Server) CreateRecoveryConf(ctx context.Context, req *idl.CreateRecoveryConfRequest) (*idl.CreateRecoveryConfReply, error) {
-	gplog.Info("agent received request to create recovery.conf")
+	gplog.Info("agent received request to create recovery configuration")
+
+	targetVersion, err := semver.Parse(req.GetTargetVersion())
+	if err != nil {
+		return &idl.CreateRecoveryConfReply{}, xerrors.Errorf("parsing target version: %w", err)
+	}
 
-	err := createRecoveryConf(req.GetConnections())
+	err = createRecoveryConf(req.GetConnections(), targetVersion)
 	if err != nil {
 		return &idl.CreateRecoveryConfReply{}, err
 	}
@@ -27,7 +38,7 @@ func (s *Server) CreateRecoveryConf(ctx context.Context, req *idl.CreateRecovery
 	return &idl.CreateRecoveryConfReply{}, nil
 }
 
-func createRecoveryConf(connReqs []*idl.CreateRecoveryConfRequest_Connection) error {
+func createRecoveryConf(connReqs []*idl.CreateRecoveryConfRequest_Connection, targetVersion semver.Version) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(connReqs))
 
@@ -37,12 +48,15 @@ func createRecoveryConf(connReqs []*idl.CreateRecoveryConfRequest_Connection) er
 		go func(connReq *idl.CreateRecoveryConfRequest_Connection) {
 			defer wg.Done()
 
-			config := fmt.Sprintf(`standby_mode = 'on'
-primary_conninfo = 'user=%s host=%s port=%d sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver'
-primary_slot_name = 'internal_wal_replication_slot'`, connReq.GetUser(), connReq.GetPrimaryHost(), connReq.GetPrimaryPort())
+			primaryConnInfo := fmt.Sprintf("user=%s host=%s port=%d sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver",
+				connReq.GetUser(), connReq.GetPrimaryHost(), connReq.GetPrimaryPort())
 
-			err := os.WriteFile(filepath.Join(connReq.GetMirrorDataDir(), "recovery.conf"), []byte(config), 0644)
-			if err != nil {
+			write := writeRecoveryConf
+			if targetVersion.Major >= 7 {
+				write = writeStandbySignal
+			}
+
+			if err := write(connReq.GetMirrorDataDir(), primaryConnInfo); err != nil {
 				errs <- err
 			}
 		}(connReq)
@@ -58,3 +72,34 @@ primary_slot_name = 'internal_wal_replication_slot'`, connReq.GetUser(), connReq
 
 	return err
 }
+
+// writeRecoveryConf configures the mirror to stream from its primary using
+// recovery.conf, which was replaced in GPDB 7.
+func writeRecoveryConf(dataDir string, primaryConnInfo string) error {
+	config := fmt.Sprintf(`standby_mode = 'on'
+primary_conninfo = '%s'
+primary_slot_name = '%s'`, primaryConnInfo, replicationSlotName)
+
+	return os.WriteFile(filepath.Join(dataDir, "recovery.conf"), []byte(config), 0644)
+}
+
+// writeStandbySignal configures the mirror to stream from its primary as done
+// starting with GPDB 7. The connection settings are written to
+// postgresql.auto.conf, and standby.signal starts the server as a standby.
+func writeStandbySignal(dataDir string, primaryConnInfo string) error {
+	path := filepath.Join(dataDir, "postgresql.auto.conf")
+	contents, err := utils.System.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	file := conf.Parse(path, string(contents))
+	file.Set("primary_conninfo", primaryConnInfo)
+	file.Set("primary_slot_name", replicationSlotName)
+
+	if err := utils.AtomicallyWrite(path, []byte(file.String())); err != nil {
+		return xerrors.Errorf("writing %q: %w", path, err)
+	}
+
+	return os.WriteFile(filepath.Join(dataDir, "standby.signal"), nil, 0600)
+}
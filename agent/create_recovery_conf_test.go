@@ -32,7 +32,7 @@ func TestCreateRecoveryConf(t *testing.T) {
 			PrimaryPort:   int32(123),
 		}}
 
-		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{Connections: connReqs})
+		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{Connections: connReqs, TargetVersion: "6.20.0"})
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
@@ -47,6 +47,45 @@ primary_slot_name = 'internal_wal_replication_slot'`
 		}
 	})
 
+	t.Run("creates standby.signal and sets the connection in postgresql.auto.conf for GPDB 7", func(t *testing.T) {
+		mirrorDataDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, mirrorDataDir)
+
+		autoConf := filepath.Join(mirrorDataDir, "postgresql.auto.conf")
+		testutils.MustWriteToFile(t, autoConf, "# Do not edit this file manually!\nprimary_slot_name = 'old_slot'\n")
+
+		connReqs := []*idl.CreateRecoveryConfRequest_Connection{{
+			MirrorDataDir: mirrorDataDir,
+			User:          "gpadmin",
+			PrimaryHost:   "sdw1",
+			PrimaryPort:   int32(123),
+		}}
+
+		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{Connections: connReqs, TargetVersion: "7.0.0"})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		contents := testutils.MustReadFile(t, autoConf)
+		expected := `# Do not edit this file manually!
+primary_slot_name = 'internal_wal_replication_slot'
+primary_conninfo = 'user=gpadmin host=sdw1 port=123 sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver'
+`
+		if contents != expected {
+			t.Errorf("got %q, want %q", contents, expected)
+		}
+
+		testutils.PathMustExist(t, filepath.Join(mirrorDataDir, "standby.signal"))
+		testutils.PathMustNotExist(t, filepath.Join(mirrorDataDir, "recovery.conf"))
+	})
+
+	t.Run("errors when the target version is invalid", func(t *testing.T) {
+		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{TargetVersion: "7"})
+		if err == nil {
+			t.Error("expected error, returned nil")
+		}
+	})
+
 	t.Run("returns multiple errors when failing to write recovery.conf", func(t *testing.T) {
 		connReqs := []*idl.CreateRecoveryConfRequest_Connection{
 			{
@@ -62,7 +101,7 @@ primary_slot_name = 'internal_wal_replication_slot'`
 				PrimaryPort:   int32(456),
 			}}
 
-		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{Connections: connReqs})
+		_, err := server.CreateRecoveryConf(context.Background(), &idl.CreateRecoveryConfRequest{Connections: connReqs, TargetVersion: "6.20.0"})
 		if err == nil {
 			t.Error("expected error, returned nil")
 		}
@@ -0,0 +1,35 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func (s *Server) GetControlData(ctx context.Context, req *idl.GetControlDataRequest) (*idl.GetControlDataReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_VERIFY_SOURCE_SHUTDOWN)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return &idl.GetControlDataReply{}, err
+	}
+
+	var controlData []*idl.ControlData
+	for _, dataDir := range req.GetDataDirs() {
+		data, err := greenplum.GetControlData(req.GetGphome(), dataDir)
+		if err != nil {
+			return &idl.GetControlDataReply{}, err
+		}
+
+		controlData = append(controlData, data)
+	}
+
+	return &idl.GetControlDataReply{Hostname: hostname, ControlData: controlData}, nil
+}
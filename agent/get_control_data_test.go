@@ -0,0 +1,87 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func PgControlDataShutDown() {
+	fmt.Println("Database cluster state:               shut down")
+}
+
+func PgControlDataFailed() {
+	os.Exit(1)
+}
+
+func init() {
+	exectest.RegisterMains(
+		PgControlDataShutDown,
+		PgControlDataFailed,
+	)
+}
+
+func TestGetControlData(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("returns the control data of each data directory", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(PgControlDataShutDown))
+		defer greenplum.ResetGreenplumCommand()
+
+		reply, err := server.GetControlData(context.Background(), &idl.GetControlDataRequest{
+			Gphome:   "/usr/local/gpdb5",
+			DataDirs: []string{"/data/dbfast1/seg1", "/data/dbfast2/seg2"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		hostname, err := os.Hostname()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reply.GetHostname() != hostname {
+			t.Errorf("got hostname %q want %q", reply.GetHostname(), hostname)
+		}
+
+		if len(reply.GetControlData()) != 2 {
+			t.Fatalf("got %d control data want 2", len(reply.GetControlData()))
+		}
+
+		for i, dataDir := range []string{"/data/dbfast1/seg1", "/data/dbfast2/seg2"} {
+			data := reply.GetControlData()[i]
+			if data.GetDataDir() != dataDir {
+				t.Errorf("got data directory %q want %q", data.GetDataDir(), dataDir)
+			}
+
+			if data.GetClusterState() != greenplum.ClusterStateShutDown {
+				t.Errorf("got cluster state %q want %q", data.GetClusterState(), greenplum.ClusterStateShutDown)
+			}
+		}
+	})
+
+	t.Run("errors when pg_controldata fails", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(PgControlDataFailed))
+		defer greenplum.ResetGreenplumCommand()
+
+		_, err := server.GetControlData(context.Background(), &idl.GetControlDataRequest{
+			Gphome:   "/usr/local/gpdb5",
+			DataDirs: []string{"/data/dbfast1/seg1"},
+		})
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func (s *Server) GetHostInfo(ctx context.Context, req *idl.GetHostInfoRequest) (*idl.GetHostInfoReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_CHECK_HOSTS)
+
+	info, err := hub.GetHostInfo(req.GetSourceGPHome(), req.GetTargetGPHome())
+	if err != nil {
+		return &idl.GetHostInfoReply{}, err
+	}
+
+	return &idl.GetHostInfoReply{Info: info}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestGetHostInfo(t *testing.T) {
+	testlog.SetupLogger()
+	utils.System = utils.InitializeSystemFunctions()
+	server := agent.NewServer(agent.Config{})
+
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	reply, err := server.GetHostInfo(context.Background(), &idl.GetHostInfoRequest{
+		SourceGPHome: gphome,
+		TargetGPHome: gphome,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.GetInfo().GetHostname() != hostname {
+		t.Errorf("got hostname %q want %q", reply.GetInfo().GetHostname(), hostname)
+	}
+
+	if reply.GetInfo().GetTargetVersion() != "" {
+		t.Errorf("got target version %q for a missing installation want empty", reply.GetInfo().GetTargetVersion())
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func (s *Server) MigrateAuthFiles(ctx context.Context, req *idl.MigrateAuthFilesRequest) (*idl.MigrateAuthFilesReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_MIGRATE_AUTH_FILES)
+
+	targetVersion, err := semver.Parse(req.GetTargetVersion())
+	if err != nil {
+		return &idl.MigrateAuthFilesReply{}, err
+	}
+
+	var changes []string
+	for _, pair := range req.GetDataDirPairs() {
+		diff, err := hub.MergeAuthFiles(pair.GetSourceDataDir(), pair.GetTargetDataDir(), targetVersion)
+		if err != nil {
+			return &idl.MigrateAuthFilesReply{}, err
+		}
+
+		changes = append(changes, diff...)
+	}
+
+	return &idl.MigrateAuthFilesReply{Changes: changes}, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestMigrateAuthFiles(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	t.Run("merges the files of each data directory pair", func(t *testing.T) {
+		sourceDir := testutils.GetTempDir(t, "source")
+		defer testutils.MustRemoveAll(t, sourceDir)
+
+		targetDir := testutils.GetTempDir(t, "target")
+		defer testutils.MustRemoveAll(t, targetDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, hub.PgHbaConf), "host all all 10.0.0.0/8 md5\n")
+		testutils.MustWriteToFile(t, filepath.Join(targetDir, hub.PgHbaConf), "local all gpadmin ident\n")
+
+		reply, err := server.MigrateAuthFiles(context.Background(), &idl.MigrateAuthFilesRequest{
+			DataDirPairs:  []*idl.DataDirPair{{SourceDataDir: sourceDir, TargetDataDir: targetDir}},
+			TargetVersion: "6.20.0",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{filepath.Join(targetDir, hub.PgHbaConf) + ": + host all all 10.0.0.0/8 md5"}
+		if !reflect.DeepEqual(reply.GetChanges(), expected) {
+			t.Errorf("got %q want %q", reply.GetChanges(), expected)
+		}
+	})
+
+	t.Run("errors on an invalid target version", func(t *testing.T) {
+		_, err := server.MigrateAuthFiles(context.Background(), &idl.MigrateAuthFilesRequest{TargetVersion: "six"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func (s *Server) ResolveLibraries(ctx context.Context, req *idl.ResolveLibrariesRequest) (*idl.ResolveLibrariesReply, error) {
+	gplog.Info("agent received request to %s", idl.Substep_CHECK_LIBRARIES)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return &idl.ResolveLibrariesReply{}, err
+	}
+
+	return &idl.ResolveLibrariesReply{
+		Hostname:  hostname,
+		Libraries: greenplum.ResolveLibraries(req.GetGphome(), req.GetDynamicLibraryPath(), req.GetLibraries()),
+	}, nil
+}
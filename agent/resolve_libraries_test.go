@@ -0,0 +1,55 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestResolveLibraries(t *testing.T) {
+	testlog.SetupLogger()
+	server := agent.NewServer(agent.Config{})
+
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	extraDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, extraDir)
+	testutils.MustWriteToFile(t, filepath.Join(extraDir, "mylib.so"), "")
+
+	reply, err := server.ResolveLibraries(context.Background(), &idl.ResolveLibrariesRequest{
+		Gphome:             gphome,
+		DynamicLibraryPath: "$libdir:" + extraDir,
+		Libraries:          []string{"mylib", "$libdir/missing"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &idl.ResolveLibrariesReply{
+		Hostname: hostname,
+		Libraries: []*idl.ResolveLibrariesReply_Library{
+			{Name: "mylib", Path: filepath.Join(extraDir, "mylib.so"), Found: true},
+			{Name: "$libdir/missing", Path: filepath.Join(greenplum.PkgLibDir(gphome), "missing"), Found: false},
+		},
+	}
+	if !reflect.DeepEqual(reply, expected) {
+		t.Errorf("got %v want %v", reply, expected)
+	}
+}
@@ -102,6 +102,11 @@ func RestoreTablespaces(request *idl.UpgradePrimariesRequest, segment Segment) e
 		return nil
 	}
 
+	targetVersion, err := semver.Parse(request.TargetVersion)
+	if err != nil {
+		return xerrors.Errorf("parsing target version: %w", err)
+	}
+
 	for oid, tablespace := range segment.Tablespaces {
 		if !tablespace.GetUserDefined() {
 			continue
@@ -110,10 +115,14 @@ func RestoreTablespaces(request *idl.UpgradePrimariesRequest, segment Segment) e
 		targetDir := greenplum.GetTablespaceLocationForDbId(tablespace, int(segment.DBID))
 		sourceDir := greenplum.GetMasterTablespaceLocation(utils.GetTablespaceDir(), int(oid)) + string(os.PathSeparator)
 
+		// Only copy the target version directory of the master. When
+		// upgrading from 6X the segment directory also contains the source
+		// GPDB_6 directory which must not be replaced or deleted.
 		options := []rsync.Option{
 			rsync.WithSources(sourceDir),
 			rsync.WithDestination(targetDir),
-			rsync.WithOptions("--archive", "--delete"),
+			rsync.WithOptions("--archive", "--delete", "--include", fmt.Sprintf("/GPDB_%d_*", targetVersion.Major)),
+			rsync.WithExcludedFiles("/GPDB_*"),
 		}
 
 		if err := rsync.Rsync(options...); err != nil {
@@ -52,7 +52,7 @@ func TestRestoreTablespaces(t *testing.T) {
 	defer os.RemoveAll(utils.GetTablespaceDir())
 
 	t.Run("successfully performs restore of tablespaces", func(t *testing.T) {
-		request := &idl.UpgradePrimariesRequest{}
+		request := &idl.UpgradePrimariesRequest{TargetVersion: "6.20.0"}
 
 		tablespaces := map[int32]*idl.TablespaceInfo{
 			1663: {
@@ -76,9 +76,10 @@ func TestRestoreTablespaces(t *testing.T) {
 
 		// all the args for multiple invocations of rsync
 		expectedRsyncArgs := []string{
-			"--archive", "--delete",
+			"--archive", "--delete", "--include", "/GPDB_6_*",
 			filepath.Join(utils.GetTablespaceDir(), "1663", string(os.PathSeparator), "1") + string(os.PathSeparator),
 			"/tmp/default/1663/2",
+			"--exclude", "/GPDB_*",
 		}
 
 		var actualArgs []string
@@ -149,7 +150,7 @@ func TestRestoreTablespaces(t *testing.T) {
 	})
 
 	t.Run("restore tablespace fails during rsync", func(t *testing.T) {
-		request := &idl.UpgradePrimariesRequest{}
+		request := &idl.UpgradePrimariesRequest{TargetVersion: "6.20.0"}
 
 		segment := getSampleSegment()
 
@@ -169,7 +170,7 @@ func TestRestoreTablespaces(t *testing.T) {
 	})
 
 	t.Run("fails during recreation of symlink", func(t *testing.T) {
-		request := &idl.UpgradePrimariesRequest{}
+		request := &idl.UpgradePrimariesRequest{TargetVersion: "6.20.0"}
 
 		segment := getSampleSegment()
 
@@ -193,6 +194,15 @@ func TestRestoreTablespaces(t *testing.T) {
 			t.Errorf("got %+v, want %+v", err, expectedErrorStr)
 		}
 	})
+
+	t.Run("errors when the target version is invalid", func(t *testing.T) {
+		request := &idl.UpgradePrimariesRequest{TargetVersion: "six"}
+
+		err := agent.RestoreTablespaces(request, getSampleSegment())
+		if err == nil || !strings.Contains(err.Error(), "parsing target version") {
+			t.Errorf("got %+v, want parsing target version error", err)
+		}
+	})
 }
 
 func TestReCreateSymLink(t *testing.T) {
@@ -353,6 +353,34 @@ __gpupgrade_handle_word()
     __gpupgrade_handle_word
 }
 
+_gpupgrade_compare-dumps()
+{
+    last_command="gpupgrade_compare-dumps"
+
+    command_aliases=()
+
+    commands=()
+
+    flags=()
+    two_word_flags=()
+    local_nonpersistent_flags=()
+    flags_with_completion=()
+    flags_completion=()
+
+    flags+=("--source-dump=")
+    two_word_flags+=("--source-dump")
+    local_nonpersistent_flags+=("--source-dump")
+    local_nonpersistent_flags+=("--source-dump=")
+    flags+=("--target-dump=")
+    two_word_flags+=("--target-dump")
+    local_nonpersistent_flags+=("--target-dump")
+    local_nonpersistent_flags+=("--target-dump=")
+
+    must_have_one_flag=()
+    must_have_one_noun=()
+    noun_aliases=()
+}
+
 _gpupgrade_config_show()
 {
     last_command="gpupgrade_config_show"
@@ -443,6 +471,16 @@ _gpupgrade_execute()
     flags+=("-?")
     local_nonpersistent_flags+=("--?")
     local_nonpersistent_flags+=("-?")
+    flags+=("--quiesce=")
+    two_word_flags+=("--quiesce")
+    local_nonpersistent_flags+=("--quiesce")
+    local_nonpersistent_flags+=("--quiesce=")
+    flags+=("--quiesce-timeout=")
+    two_word_flags+=("--quiesce-timeout")
+    local_nonpersistent_flags+=("--quiesce-timeout")
+    local_nonpersistent_flags+=("--quiesce-timeout=")
+    flags+=("--skip-disk-space-check")
+    local_nonpersistent_flags+=("--skip-disk-space-check")
     flags+=("--verbose")
     flags+=("-v")
     local_nonpersistent_flags+=("--verbose")
@@ -569,10 +607,20 @@ _gpupgrade_initialize()
     flags+=("-a")
     local_nonpersistent_flags+=("--automatic")
     local_nonpersistent_flags+=("-a")
+    flags+=("--cluster-ready-timeout=")
+    two_word_flags+=("--cluster-ready-timeout")
+    local_nonpersistent_flags+=("--cluster-ready-timeout")
+    local_nonpersistent_flags+=("--cluster-ready-timeout=")
+    flags+=("--data-validation=")
+    two_word_flags+=("--data-validation")
+    local_nonpersistent_flags+=("--data-validation")
+    local_nonpersistent_flags+=("--data-validation=")
     flags+=("--disk-free-ratio=")
     two_word_flags+=("--disk-free-ratio")
     local_nonpersistent_flags+=("--disk-free-ratio")
     local_nonpersistent_flags+=("--disk-free-ratio=")
+    flags+=("--dump-schemas")
+    local_nonpersistent_flags+=("--dump-schemas")
     flags+=("--dynamic-library-path=")
     two_word_flags+=("--dynamic-library-path")
     local_nonpersistent_flags+=("--dynamic-library-path")
@@ -583,6 +631,10 @@ _gpupgrade_initialize()
     local_nonpersistent_flags+=("--file")
     local_nonpersistent_flags+=("--file=")
     local_nonpersistent_flags+=("-f")
+    flags+=("--gpinitsystem-overrides=")
+    two_word_flags+=("--gpinitsystem-overrides")
+    local_nonpersistent_flags+=("--gpinitsystem-overrides")
+    local_nonpersistent_flags+=("--gpinitsystem-overrides=")
     flags+=("--hub-port=")
     two_word_flags+=("--hub-port")
     local_nonpersistent_flags+=("--hub-port")
@@ -591,6 +643,14 @@ _gpupgrade_initialize()
     two_word_flags+=("--mode")
     local_nonpersistent_flags+=("--mode")
     local_nonpersistent_flags+=("--mode=")
+    flags+=("--rebalance")
+    local_nonpersistent_flags+=("--rebalance")
+    flags+=("--rsync-mirrors")
+    local_nonpersistent_flags+=("--rsync-mirrors")
+    flags+=("--smoke-test-dir=")
+    two_word_flags+=("--smoke-test-dir")
+    local_nonpersistent_flags+=("--smoke-test-dir")
+    local_nonpersistent_flags+=("--smoke-test-dir=")
     flags+=("--source-gphome=")
     two_word_flags+=("--source-gphome")
     local_nonpersistent_flags+=("--source-gphome")
@@ -607,6 +667,8 @@ _gpupgrade_initialize()
     two_word_flags+=("--temp-port-range")
     local_nonpersistent_flags+=("--temp-port-range")
     local_nonpersistent_flags+=("--temp-port-range=")
+    flags+=("--upgrade-extensions")
+    local_nonpersistent_flags+=("--upgrade-extensions")
     flags+=("--use-hba-hostnames")
     local_nonpersistent_flags+=("--use-hba-hostnames")
     flags+=("--verbose")
@@ -726,6 +788,8 @@ _gpupgrade_version()
     two_word_flags+=("--format")
     local_nonpersistent_flags+=("--format")
     local_nonpersistent_flags+=("--format=")
+    flags+=("--matrix")
+    local_nonpersistent_flags+=("--matrix")
 
     must_have_one_flag=()
     must_have_one_noun=()
@@ -739,6 +803,7 @@ _gpupgrade_root_command()
     command_aliases=()
 
     commands=()
+    commands+=("compare-dumps")
     commands+=("config")
     commands+=("execute")
     commands+=("finalize")
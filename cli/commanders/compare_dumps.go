@@ -0,0 +1,82 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils/dumpfilter"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// introduce this variable to allow exec.Command to be mocked out in tests
+var execCommandDiff = exec.Command
+
+var ErrDumpsDiffer = xerrors.New("the source and target schema dumps differ")
+
+// CompareDumps normalizes the source and target schema dumps using the rules
+// for their respective versions, and writes a unified diff of the result to
+// out. ErrDumpsDiffer is returned when differences are found.
+func CompareDumps(out io.Writer, sourceVersion int, sourceDump string, targetVersion int, targetDump string) error {
+	filteredSource := sourceDump + ".filtered"
+	if err := filterDump(sourceVersion, sourceDump, filteredSource); err != nil {
+		return err
+	}
+
+	filteredTarget := targetDump + ".filtered"
+	if err := filterDump(targetVersion, targetDump, filteredTarget); err != nil {
+		return err
+	}
+
+	cmd := execCommandDiff("diff", "-U3", "--speed-large-files", "--ignore-space-change", "--ignore-blank-lines",
+		filteredSource, filteredTarget)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if xerrors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return ErrDumpsDiffer
+	}
+
+	if err != nil {
+		return xerrors.Errorf("comparing %q and %q: %w", filteredSource, filteredTarget, err)
+	}
+
+	fmt.Fprintln(out, "No differences found between the source and target schema dumps.")
+	return nil
+}
+
+func filterDump(version int, path string, filteredPath string) (err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return xerrors.Errorf("open schema dump: %w", err)
+	}
+	defer func() {
+		if cErr := in.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	out, err := os.Create(filteredPath)
+	if err != nil {
+		return xerrors.Errorf("create filtered schema dump: %w", err)
+	}
+	defer func() {
+		if cErr := out.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if err := dumpfilter.Filter(version, in, out); err != nil {
+		return xerrors.Errorf("filter schema dump %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+)
+
+func Diff_Identical() {}
+
+func Diff_Differ() {
+	fmt.Print("-CREATE TABLE foo (a integer);")
+	os.Exit(1)
+}
+
+func Diff_Error() {
+	fmt.Fprint(os.Stderr, "diff: no such file")
+	os.Exit(2)
+}
+
+func init() {
+	exectest.RegisterMains(
+		Diff_Identical,
+		Diff_Differ,
+		Diff_Error,
+	)
+}
+
+func TestCompareDumps(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	sourceDump := filepath.Join(dir, "source_schema.sql")
+	testutils.MustWriteToFile(t, sourceDump, "CREATE TABLE foo (a integer);\n")
+
+	targetDump := filepath.Join(dir, "target_schema.sql")
+	testutils.MustWriteToFile(t, targetDump, "CREATE TABLE foo (a integer);\n")
+
+	defer func() {
+		execCommandDiff = exec.Command
+	}()
+
+	t.Run("diffs the filtered dumps", func(t *testing.T) {
+		execCommandDiff = exectest.NewCommandWithVerifier(Diff_Identical, func(utility string, args ...string) {
+			if utility != "diff" {
+				t.Errorf("got utility %q want diff", utility)
+			}
+
+			expected := []string{"-U3", "--speed-large-files", "--ignore-space-change", "--ignore-blank-lines",
+				sourceDump + ".filtered", targetDump + ".filtered"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		})
+
+		var out bytes.Buffer
+		err := CompareDumps(&out, 5, sourceDump, 6, targetDump)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		if !strings.Contains(out.String(), "No differences found") {
+			t.Errorf("got output %q", out.String())
+		}
+
+		for _, path := range []string{sourceDump + ".filtered", targetDump + ".filtered"} {
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading filtered dump: %v", err)
+			}
+
+			if !strings.Contains(string(contents), "CREATE TABLE foo") {
+				t.Errorf("filtered dump %q has contents %q", path, contents)
+			}
+		}
+	})
+
+	t.Run("returns ErrDumpsDiffer and prints the diff when the dumps differ", func(t *testing.T) {
+		execCommandDiff = exectest.NewCommand(Diff_Differ)
+
+		var out bytes.Buffer
+		err := CompareDumps(&out, 5, sourceDump, 6, targetDump)
+		if !errors.Is(err, ErrDumpsDiffer) {
+			t.Errorf("got error %#v want %#v", err, ErrDumpsDiffer)
+		}
+
+		expected := "-CREATE TABLE foo (a integer);"
+		if out.String() != expected {
+			t.Errorf("got output %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("returns an error when diff fails", func(t *testing.T) {
+		execCommandDiff = exectest.NewCommand(Diff_Error)
+
+		err := CompareDumps(&bytes.Buffer{}, 5, sourceDump, 6, targetDump)
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("got error %#v want %T", err, exitErr)
+		}
+
+		if exitErr.ExitCode() != 2 {
+			t.Errorf("got exit code %d want 2", exitErr.ExitCode())
+		}
+	})
+
+	t.Run("returns an error when a dump does not exist", func(t *testing.T) {
+		execCommandDiff = exectest.NewCommand(Diff_Identical)
+
+		err := CompareDumps(&bytes.Buffer{}, 5, filepath.Join(dir, "missing.sql"), 6, targetDump)
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+}
@@ -47,4 +47,21 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_REMOVE_SOURCE_MIRRORS:                                         substepText{"Removing source cluster data directories and tablespaces to save space...", "Remove source cluster data directories and tablespaces to save space..."},
 	idl.Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_ADDING_MIRRORS_AND_STANDBY: substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
 	idl.Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG:           substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
+	idl.Substep_SNAPSHOT_SOURCE_DATA:                                          substepText{"Snapshotting source cluster data for validation...", "Snapshot source cluster data for validation"},
+	idl.Substep_VALIDATE_TARGET_DATA:                                          substepText{"Validating target cluster data...", "Validate target cluster data"},
+	idl.Substep_DUMP_SOURCE_SCHEMA:                                            substepText{"Dumping source cluster schema...", "Dump source cluster schema"},
+	idl.Substep_DUMP_TARGET_SCHEMA:                                            substepText{"Dumping target cluster schema...", "Dump target cluster schema"},
+	idl.Substep_RUN_SMOKE_TESTS:                                               substepText{"Running smoke tests against the target cluster...", "Run smoke tests against the target cluster"},
+	idl.Substep_CHECK_EXTENSIONS:                                              substepText{"Checking extensions and languages in the target installation...", "Check extensions and languages in the target installation"},
+	idl.Substep_CHECK_LIBRARIES:                                               substepText{"Checking shared libraries in the target installation...", "Check shared libraries in the target installation"},
+	idl.Substep_UPGRADE_EXTENSIONS:                                            substepText{"Upgrading extensions in the target cluster...", "Upgrade extensions in the target cluster"},
+	idl.Substep_CHECK_TARGET_PORTS:                                            substepText{"Checking target cluster ports are available...", "Check target cluster ports are available"},
+	idl.Substep_CHECK_HOSTS:                                                   substepText{"Checking target installation and rsync on all hosts...", "Check target installation and rsync on all hosts"},
+	idl.Substep_CHECK_SOURCE_CLUSTER_HEALTH:                                   substepText{"Checking source cluster segments are up, in their preferred roles, and synchronized...", "Check source cluster segments are up, in their preferred roles, and synchronized"},
+	idl.Substep_QUIESCE_SOURCE_CLUSTER:                                        substepText{"Checking the source cluster is idle...", "Check the source cluster is idle"},
+	idl.Substep_VERIFY_SOURCE_SHUTDOWN:                                        substepText{"Verifying the source cluster shut down cleanly...", "Verify the source cluster shut down cleanly"},
+	idl.Substep_CHECK_CLUSTER_TOPOLOGY:                                        substepText{"Checking the cluster configuration has not changed...", "Check the cluster configuration has not changed"},
+	idl.Substep_MIGRATE_SOURCE_SETTINGS:                                       substepText{"Copying source cluster settings to the target cluster...", "Copy source cluster settings to the target cluster"},
+	idl.Substep_MIGRATE_AUTH_FILES:                                            substepText{"Copying source cluster pg_hba.conf and pg_ident.conf rules to the target cluster...", "Copy source cluster pg_hba.conf and pg_ident.conf rules to the target cluster"},
+	idl.Substep_CHECK_LINK_MODE_FILESYSTEMS:                                   substepText{"Checking link mode target directories are on the source filesystems...", "Check link mode target directories are on the source filesystems"},
 }
@@ -37,6 +37,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -46,6 +47,7 @@ import (
 	grpcStatus "google.golang.org/grpc/status"
 
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -87,6 +89,7 @@ func BuildRootCommand() *cobra.Command {
 	root.AddCommand(execute())
 	root.AddCommand(finalize())
 	root.AddCommand(revert())
+	root.AddCommand(compareDumps())
 	root.AddCommand(restartServices)
 	root.AddCommand(killServices)
 	root.AddCommand(Agent())
@@ -164,17 +167,62 @@ func createConfigShowSubcommand() *cobra.Command {
 
 func version() *cobra.Command {
 	var format string
+	var matrix bool
+	var versionMatrix string
 
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Version of gpupgrade",
 		Long:  `Version of gpupgrade`,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if matrix {
+				return printVersionMatrix(format, versionMatrix)
+			}
+
 			printVersion(format)
+			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&format, "format", "", `specify the output format as either "multiline", "oneline", or "json". Default is multiline.`)
+	cmd.Flags().BoolVar(&matrix, "matrix", false, "print the supported source and target versions")
+	cmd.Flags().StringVar(&versionMatrix, "version-matrix", "", "with --matrix, print the matrix in this file rather than the built-in one")
+
+	return cmd
+}
+
+func compareDumps() *cobra.Command {
+	var sourceDump, targetDump string
+
+	cmd := &cobra.Command{
+		Use:   "compare-dumps",
+		Short: "compare the schema dumps of the source and target clusters",
+		Long: "Normalizes the schema-only dumps of the source and target clusters taken\n" +
+			"when initialize is run with --dump-schemas, and prints any differences.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf := &hub.Config{}
+			err := hub.LoadConfig(conf, upgrade.GetConfigFile())
+			if err != nil {
+				return xerrors.Errorf("loading hub configuration (did you run 'gpupgrade initialize'?): %w", err)
+			}
+
+			if !conf.DumpSchemas && !cmd.Flag("source-dump").Changed {
+				return xerrors.New(`schema dumps were not taken. Run "gpupgrade initialize" with --dump-schemas to enable them.`)
+			}
+
+			if conf.Source == nil || conf.Intermediate == nil {
+				return xerrors.New(`the target cluster has not been created. Run "gpupgrade initialize" to completion first.`)
+			}
+
+			cmd.SilenceUsage = true
+			return commanders.CompareDumps(os.Stdout,
+				int(conf.Source.Version.Major), sourceDump,
+				int(conf.Intermediate.Version.Major), targetDump)
+		},
+	}
+
+	cmd.Flags().StringVar(&sourceDump, "source-dump", utils.GetSourceSchemaDump(), "path to the schema-only dump of the source cluster")
+	cmd.Flags().StringVar(&targetDump, "target-dump", utils.GetTargetSchemaDump(), "path to the schema-only dump of the target cluster")
 
 	return cmd
 }
@@ -335,3 +383,15 @@ func getHubPort(tryDefault bool) int {
 
 	return conf.Port
 }
+
+// masterDataDirectoryEnv returns the name of the master data directory
+// environment variable for the version of cluster. Hubs that do not report the
+// version are assumed to manage a cluster using MASTER_DATA_DIRECTORY.
+func masterDataDirectoryEnv(cluster *idl.Cluster) string {
+	version, err := semver.Parse(cluster.GetVersion())
+	if err != nil {
+		return greenplum.MasterDataDirectoryEnv(semver.Version{})
+	}
+
+	return greenplum.MasterDataDirectoryEnv(version)
+}
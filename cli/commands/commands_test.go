@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -55,3 +56,23 @@ func TestGetHubPort(t *testing.T) {
 	})
 
 }
+
+func TestMasterDataDirectoryEnv(t *testing.T) {
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "6.20.0", expected: "MASTER_DATA_DIRECTORY"},
+		{version: "7.0.0", expected: "COORDINATOR_DATA_DIRECTORY"},
+		{version: "", expected: "MASTER_DATA_DIRECTORY"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			env := masterDataDirectoryEnv(&idl.Cluster{Version: c.version})
+			if env != c.expected {
+				t.Errorf("got %q want %q", env, c.expected)
+			}
+		})
+	}
+}
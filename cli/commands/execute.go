@@ -6,10 +6,13 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -18,12 +21,25 @@ import (
 func execute() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var skipDiskSpaceCheck bool
+	var quiesce string
+	var quiesceTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "execute",
 		Short: "executes the upgrade",
 		Long:  ExecuteHelp,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			quiesce = strings.ToLower(strings.TrimSpace(quiesce))
+			if !hub.IsValidQuiesceAction(quiesce) {
+				return fmt.Errorf("Invalid input %q for quiesce. Please specify either %s, %s, or %s.",
+					quiesce, hub.QuiesceAbort, hub.QuiesceWait, hub.QuiesceTerminate)
+			}
+
+			if quiesceTimeout < time.Second {
+				return fmt.Errorf("Invalid input %q for quiesce-timeout. Please specify a duration of at least one second such as \"5m\".", quiesceTimeout)
+			}
+
 			cmd.SilenceUsage = true
 			var response idl.ExecuteResponse
 
@@ -55,7 +71,12 @@ func execute() *cobra.Command {
 					return err
 				}
 
-				response, err = commanders.Execute(client, verbose)
+				request := &idl.ExecuteRequest{
+					SkipDiskSpaceCheck: skipDiskSpaceCheck,
+					Quiesce:            quiesce,
+					QuiesceTimeout:     uint32(quiesceTimeout.Seconds()),
+				}
+				response, err = commanders.Execute(client, request, verbose)
 				if err != nil {
 					return err
 				}
@@ -69,7 +90,7 @@ Execute completed successfully.
 The target cluster is now running. You may now run queries against the target 
 database and perform any other validation desired prior to finalizing your upgrade.
 PGPORT=%d
-MASTER_DATA_DIRECTORY=%s
+%s=%s
 
 WARNING: If any queries modify the target database prior to gpupgrade finalize, 
 it will be inconsistent with the source database. 
@@ -80,13 +101,16 @@ If you are satisfied with the state of the cluster, run "gpupgrade finalize"
 to proceed with the upgrade.
 
 To return the cluster to its original state, run "gpupgrade revert".`,
-				response.GetTarget().GetPort(), response.GetTarget().GetMasterDataDirectory()))
+				response.GetTarget().GetPort(), masterDataDirectoryEnv(response.GetTarget()), response.GetTarget().GetMasterDataDirectory()))
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().BoolVar(&skipDiskSpaceCheck, "skip-disk-space-check", false, "do not re-check disk space before upgrading. Only use when the shortfall is known to be safe.")
+	cmd.Flags().StringVar(&quiesce, "quiesce", hub.QuiesceAbort, "what to do when sessions, gpexpand, or backup utilities are active on the source cluster before it is stopped. Either abort, wait, or terminate. wait and terminate give up after --quiesce-timeout.")
+	cmd.Flags().DurationVar(&quiesceTimeout, "quiesce-timeout", hub.DefaultQuiesceTimeout, "how long to wait for activity on the source cluster to finish")
 
 	return addHelpToCommand(cmd, ExecuteHelp)
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +18,16 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
+const FinalizeExtensionWarningMessage = `
+WARNING
+_______
+The following extensions could not be updated and require manual steps:
+%s
+
+Refer to the documentation of each extension for how to update it, and
+then run "ALTER EXTENSION <name> UPDATE" in each listed database.
+`
+
 func finalize() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
@@ -76,13 +87,16 @@ func finalize() *cobra.Command {
 				return upgrade.DeleteDirectories([]string{utils.GetStateDir()}, upgrade.StateDirectoryFiles, streams)
 			})
 
+			warningMessage := FinalizeExtensionWarningMessageIfAny(response)
+
 			return st.Complete(fmt.Sprintf(`
 Finalize completed successfully.
+%s
 
 The target cluster has been upgraded to Greenplum %s:
 %s
 PGPORT=%d
-MASTER_DATA_DIRECTORY=%s
+%s=%s
 
 The source cluster is not running. If copy mode was used you may start 
 the source cluster, but not at the same time as the target cluster. 
@@ -105,9 +119,11 @@ To use the upgraded cluster:
    Execute the “post-finalize” data migration scripts, and recreate any 
    additional tables, indexes, and roles that were dropped or altered 
    to resolve migration issues.`,
+				warningMessage,
 				response.GetTargetVersion(),
 				filepath.Join(response.GetTargetCluster().GetGPHome(), "greenplum_path.sh"),
 				response.GetTargetCluster().GetPort(),
+				masterDataDirectoryEnv(response.GetTargetCluster()),
 				response.GetTargetCluster().GetMasterDataDirectory(),
 				fmt.Sprintf("%s.<contentID>%s", response.GetUpgradeID(), upgrade.OldSuffix),
 				response.GetArchivedSourceMasterDataDirectory(),
@@ -125,3 +141,12 @@ To use the upgraded cluster:
 	cmd.Flags().MarkHidden("non-interactive") //nolint
 	return addHelpToCommand(cmd, FinalizeHelp)
 }
+
+func FinalizeExtensionWarningMessageIfAny(response idl.FinalizeResponse) string {
+	failures := response.GetExtensionUpgradeFailures()
+	if len(failures) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(FinalizeExtensionWarningMessage, " - "+strings.Join(failures, "\n - "))
+}
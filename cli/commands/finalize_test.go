@@ -0,0 +1,41 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestFinalizeExtensionWarningMessageIfAny(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    idl.FinalizeResponse
+		expected string
+	}{
+		{
+			name:     "no extensions failed to update",
+			input:    idl.FinalizeResponse{},
+			expected: "",
+		},
+		{
+			name: "extensions failed to update",
+			input: idl.FinalizeResponse{
+				ExtensionUpgradeFailures: []string{"failure one", "failure two"},
+			},
+			expected: fmt.Sprintf(FinalizeExtensionWarningMessage, " - failure one\n - failure two"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resultMessage := FinalizeExtensionWarningMessageIfAny(c.input)
+			if resultMessage != c.expected {
+				t.Errorf("got %q, want %q", resultMessage, c.expected)
+			}
+		})
+	}
+}
@@ -26,23 +26,41 @@ func init() {
 	InitializeHelp = GenerateHelpString(initializeHelp, []idl.Substep{
 		idl.Substep_START_HUB,
 		idl.Substep_SAVING_SOURCE_CLUSTER_CONFIG,
+		idl.Substep_CHECK_SOURCE_CLUSTER_HEALTH,
 		idl.Substep_START_AGENTS,
+		idl.Substep_CHECK_HOSTS,
 		idl.Substep_CHECK_DISK_SPACE,
+		idl.Substep_CHECK_LINK_MODE_FILESYSTEMS,
+		idl.Substep_CHECK_TARGET_PORTS,
+		idl.Substep_DUMP_SOURCE_SCHEMA,
+		idl.Substep_CHECK_EXTENSIONS,
+		idl.Substep_CHECK_LIBRARIES,
 		idl.Substep_GENERATE_TARGET_CONFIG,
 		idl.Substep_INIT_TARGET_CLUSTER,
 		idl.Substep_SETTING_DYNAMIC_LIBRARY_PATH_ON_TARGET_CLSUTER,
+		idl.Substep_MIGRATE_SOURCE_SETTINGS,
 		idl.Substep_SHUTDOWN_TARGET_CLUSTER,
 		idl.Substep_BACKUP_TARGET_MASTER,
 		idl.Substep_CHECK_UPGRADE,
 	})
 	ExecuteHelp = GenerateHelpString(executeHelp, []idl.Substep{
+		idl.Substep_CHECK_CLUSTER_TOPOLOGY,
+		idl.Substep_CHECK_DISK_SPACE,
+		idl.Substep_QUIESCE_SOURCE_CLUSTER,
+		idl.Substep_SNAPSHOT_SOURCE_DATA,
 		idl.Substep_SHUTDOWN_SOURCE_CLUSTER,
+		idl.Substep_VERIFY_SOURCE_SHUTDOWN,
 		idl.Substep_UPGRADE_MASTER,
 		idl.Substep_COPY_MASTER,
 		idl.Substep_UPGRADE_PRIMARIES,
 		idl.Substep_START_TARGET_CLUSTER,
+		idl.Substep_VALIDATE_TARGET_DATA,
+		idl.Substep_DUMP_TARGET_SCHEMA,
+		idl.Substep_RUN_SMOKE_TESTS,
 	})
 	FinalizeHelp = GenerateHelpString(finalizeHelp, []idl.Substep{
+		idl.Substep_CHECK_CLUSTER_TOPOLOGY,
+		idl.Substep_MIGRATE_AUTH_FILES,
 		idl.Substep_REMOVE_SOURCE_MIRRORS,
 		idl.Substep_UPGRADE_MIRRORS,
 		idl.Substep_UPGRADE_STANDBY,
@@ -53,6 +71,7 @@ func init() {
 		idl.Substep_UPDATE_TARGET_CONF_FILES,
 		idl.Substep_START_TARGET_CLUSTER,
 		idl.Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG,
+		idl.Substep_UPGRADE_EXTENSIONS,
 		idl.Substep_ARCHIVE_LOG_DIRECTORIES,
 		idl.Substep_DELETE_SEGMENT_STATEDIRS,
 		idl.Substep_STOP_HUB_AND_AGENTS,
@@ -117,8 +136,15 @@ Usage: gpupgrade execute
 
 Optional Flags:
 
-  -h, --help      displays help output for execute
-  -v, --verbose   outputs detailed logs for execute
+  -h, --help                   displays help output for execute
+  -v, --verbose                outputs detailed logs for execute
+      --skip-disk-space-check  skips re-checking disk space before the
+                               source cluster is shut down
+      --quiesce                what to do when sessions, gpexpand, or backup
+                               utilities are active on the source cluster:
+                               abort (default), wait, or terminate sessions
+      --quiesce-timeout        how long to wait or terminate before giving up.
+                               Defaults to 5m.
 
 gpupgrade log files can be found on all hosts in %s
 `
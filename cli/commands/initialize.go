@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -46,10 +48,19 @@ func initialize() *cobra.Command {
 	var stopBeforeClusterCreation bool
 	var verbose bool
 	var skipVersionCheck bool
+	var versionMatrix string
 	var ports string
 	var mode string
 	var useHbaHostnames bool
 	var dynamicLibraryPath string
+	var dataValidation string
+	var dumpSchemas bool
+	var smokeTestDir string
+	var upgradeExtensions bool
+	var clusterReadyTimeout time.Duration
+	var rebalance bool
+	var gpinitsystemOverrides string
+	var rsyncMirrors bool
 
 	subInit := &cobra.Command{
 		Use:   "initialize",
@@ -124,13 +135,11 @@ func initialize() *cobra.Command {
 				return err
 			}
 
-			// if diskFreeRatio is not explicitly set, use defaults
-			if !cmd.Flag("disk-free-ratio").Changed {
-				if linkMode {
-					diskFreeRatio = 0.2
-				} else {
-					diskFreeRatio = 0.6
-				}
+			// if diskFreeRatio is not explicitly set, estimate the required
+			// disk space from the size of the cluster
+			estimateDiskSpace := !cmd.Flag("disk-free-ratio").Changed
+			if estimateDiskSpace {
+				diskFreeRatio = 0
 			}
 
 			if diskFreeRatio < 0.0 || diskFreeRatio > 1.0 {
@@ -141,6 +150,44 @@ func initialize() *cobra.Command {
 				)
 			}
 
+			if clusterReadyTimeout < time.Second {
+				return fmt.Errorf("Invalid input %q for cluster_ready_timeout. Please specify a duration of at least one second such as \"5m\".", clusterReadyTimeout)
+			}
+
+			dataValidation = strings.ToLower(strings.TrimSpace(dataValidation))
+			if !hub.IsValidDataValidationMode(dataValidation) {
+				return fmt.Errorf("Invalid input %q for data_validation. Please specify either %s, %s, or %s.",
+					dataValidation, hub.DataValidationNone, hub.DataValidationExact, hub.DataValidationSampled)
+			}
+
+			if smokeTestDir != "" {
+				smokeTestDir, err = filepath.Abs(smokeTestDir)
+				if err != nil {
+					return err
+				}
+
+				info, err := os.Stat(smokeTestDir)
+				if err != nil {
+					return xerrors.Errorf("smoke_test_dir: %w", err)
+				}
+
+				if !info.IsDir() {
+					return fmt.Errorf("smoke_test_dir %q is not a directory", smokeTestDir)
+				}
+			}
+
+			if gpinitsystemOverrides != "" {
+				gpinitsystemOverrides, err = filepath.Abs(gpinitsystemOverrides)
+				if err != nil {
+					return err
+				}
+
+				// validate the overrides before starting the upgrade
+				if _, err := hub.ReadInitsystemOverrides(gpinitsystemOverrides); err != nil {
+					return err
+				}
+			}
+
 			parsedPorts, err := parsePorts(ports)
 			if err != nil {
 				return err
@@ -168,8 +215,13 @@ func initialize() *cobra.Command {
 				return err
 			}
 
+			diskFreeRatioText := fmt.Sprintf("%.1f", diskFreeRatio)
+			if estimateDiskSpace {
+				diskFreeRatioText = "estimated from cluster size"
+			}
+
 			confirmationText := fmt.Sprintf(initializeConfirmationText, logdir, configPath,
-				sourcePort, sourceGPHome, targetGPHome, mode, diskFreeRatio, useHbaHostnames, dynamicLibraryPath, ports, hubPort, agentPort)
+				sourcePort, sourceGPHome, targetGPHome, mode, diskFreeRatioText, useHbaHostnames, dynamicLibraryPath, dataValidation, dumpSchemas, smokeTestDir, upgradeExtensions, clusterReadyTimeout, rebalance, gpinitsystemOverrides, rsyncMirrors, ports, hubPort, agentPort)
 
 			st, err := commanders.NewStep(idl.Step_INITIALIZE,
 				&step.BufferedStreams{},
@@ -191,7 +243,7 @@ func initialize() *cobra.Command {
 					return nil
 				}
 
-				err := greenplum.VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome)
+				err := greenplum.VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome, versionMatrix)
 				if err != nil {
 					return err
 				}
@@ -215,14 +267,24 @@ func initialize() *cobra.Command {
 				}
 
 				request := &idl.InitializeRequest{
-					AgentPort:       int32(agentPort),
-					SourceGPHome:    filepath.Clean(sourceGPHome),
-					TargetGPHome:    filepath.Clean(targetGPHome),
-					SourcePort:      int32(sourcePort),
-					UseLinkMode:     linkMode,
-					UseHbaHostnames: useHbaHostnames,
-					Ports:           parsedPorts,
-					DiskFreeRatio:   diskFreeRatio,
+					AgentPort:             int32(agentPort),
+					SourceGPHome:          filepath.Clean(sourceGPHome),
+					TargetGPHome:          filepath.Clean(targetGPHome),
+					SourcePort:            int32(sourcePort),
+					UseLinkMode:           linkMode,
+					UseHbaHostnames:       useHbaHostnames,
+					Ports:                 parsedPorts,
+					DiskFreeRatio:         diskFreeRatio,
+					EstimateDiskSpace:     estimateDiskSpace,
+					AutoAssignPorts:       !cmd.Flag("temp-port-range").Changed,
+					DataValidation:        dataValidation,
+					DumpSchemas:           dumpSchemas,
+					SmokeTestDir:          smokeTestDir,
+					UpgradeExtensions:     upgradeExtensions,
+					ClusterReadyTimeout:   uint32(clusterReadyTimeout.Seconds()),
+					Rebalance:             rebalance,
+					GpinitsystemOverrides: gpinitsystemOverrides,
+					RsyncMirrors:          rsyncMirrors,
 				}
 				err = commanders.Initialize(client, request, verbose)
 				if err != nil {
@@ -273,15 +335,24 @@ To return the cluster to its original state, run "gpupgrade revert".`,
 	subInit.Flags().StringVar(&sourceGPHome, "source-gphome", "", "path for the source Greenplum installation")
 	subInit.Flags().StringVar(&targetGPHome, "target-gphome", "", "path for the target Greenplum installation")
 	subInit.Flags().StringVar(&mode, "mode", "copy", "performs upgrade in either copy or link mode. Default is copy.")
-	subInit.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0.60, "percentage of disk space that must be available (from 0.0 - 1.0)")
+	subInit.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0, "percentage of disk space that must be available (from 0.0 - 1.0). Only applies when given; otherwise the required space is estimated from the size of the cluster. Set to 0.0 to skip the disk space check.")
 	subInit.Flags().BoolVar(&useHbaHostnames, "use-hba-hostnames", false, "use hostnames in pg_hba.conf")
 	subInit.Flags().StringVar(&dynamicLibraryPath, "dynamic-library-path", upgrade.DefaultDynamicLibraryPath, "sets the dynamic_library_path GUC to correctly find extensions installed outside their default location. Defaults to '$dynamic_library_path'.")
+	subInit.Flags().StringVar(&dataValidation, "data-validation", hub.DataValidationNone, "compare object counts, row counts, and relation sizes of the source and target clusters. Either none, exact, or sampled.")
+	subInit.Flags().BoolVar(&dumpSchemas, "dump-schemas", false, "take schema-only dumps of the source and target clusters for use with compare-dumps")
+	subInit.Flags().StringVar(&smokeTestDir, "smoke-test-dir", "", "directory of SQL smoke tests to run against the target cluster before finalize")
+	subInit.Flags().BoolVar(&upgradeExtensions, "upgrade-extensions", false, "update outdated extensions in the target cluster during finalize")
+	subInit.Flags().DurationVar(&clusterReadyTimeout, "cluster-ready-timeout", greenplum.DefaultReadyTimeout, "how long to wait for the segments to be up, in their preferred roles, and synchronized")
+	subInit.Flags().BoolVar(&rebalance, "rebalance", false, "run gprecoverseg -r when source segments are not in their preferred roles")
+	subInit.Flags().StringVar(&gpinitsystemOverrides, "gpinitsystem-overrides", "", "file of gpinitsystem_config parameters to use when creating the target cluster")
+	subInit.Flags().BoolVar(&rsyncMirrors, "rsync-mirrors", false, "in copy mode upgrade the mirrors using rsync seeded from the source mirrors rather than gpaddmirrors")
 	subInit.Flags().StringVar(&ports, "temp-port-range", "50432-65535", "set of ports to use when initializing the target cluster")
 	subInit.Flags().IntVar(&hubPort, "hub-port", upgrade.DefaultHubPort, "the port gpupgrade hub uses to listen for commands on")
 	subInit.Flags().IntVar(&agentPort, "agent-port", upgrade.DefaultAgentPort, "the port gpupgrade agent uses to listen for commands on")
 	subInit.Flags().BoolVar(&stopBeforeClusterCreation, "stop-before-cluster-creation", false, "only run up to pre-init")
 	subInit.Flags().MarkHidden("stop-before-cluster-creation") //nolint
 	subInit.Flags().BoolVar(&skipVersionCheck, "skip-version-check", false, "disable source and target version check")
+	subInit.Flags().StringVar(&versionMatrix, "version-matrix", "", "file replacing the built-in matrix of supported source and target versions. See gpupgrade version --matrix.")
 	subInit.Flags().MarkHidden("skip-version-check") //nolint
 	return addHelpToCommand(subInit, InitializeHelp)
 }
@@ -80,7 +80,7 @@ Revert completed successfully.
 
 The source cluster is now running version %s.
 PGPORT=%d
-MASTER_DATA_DIRECTORY=%s
+%s=%s
 
 The gpupgrade logs can be found on the master and segment hosts in
 %s
@@ -92,7 +92,7 @@ recreate any additional tables, indexes, and roles that were dropped or
 altered to resolve migration issues.
 
 To restart the upgrade, run "gpupgrade initialize" again.`,
-				response.GetSourceVersion(), response.GetSource().GetPort(), response.GetSource().GetMasterDataDirectory(), response.GetLogArchiveDirectory()))
+				response.GetSourceVersion(), response.GetSource().GetPort(), masterDataDirectoryEnv(response.GetSource()), response.GetSource().GetMasterDataDirectory(), response.GetLogArchiveDirectory()))
 		},
 	}
 
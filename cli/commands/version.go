@@ -4,7 +4,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
 )
 
 // These variables are set during build time as specified in the Makefile.
@@ -38,3 +41,40 @@ Release: %s`
 func printVersion(format string) {
 	fmt.Println(VersionString(format))
 }
+
+// VersionMatrixString returns the version compatibility matrix in the file at
+// path, or the built-in matrix when path is empty, as a table or as JSON when
+// format is "json".
+func VersionMatrixString(format string, path string) (string, error) {
+	matrix, err := greenplum.LoadVersionMatrix(path)
+	if err != nil {
+		return "", err
+	}
+
+	if format != "json" {
+		return matrix.String() + versionMatrixOverrideHint, nil
+	}
+
+	contents, err := json.MarshalIndent(matrix, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents) + "\n", nil
+}
+
+const versionMatrixOverrideHint = `
+To use a different matrix set version_matrix in the gpupgrade_config file, or
+pass --version-matrix to initialize, naming a JSON file in the same format as
+"gpupgrade version --matrix --format json".
+`
+
+func printVersionMatrix(format string, path string) error {
+	matrix, err := VersionMatrixString(format, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(matrix)
+	return nil
+}
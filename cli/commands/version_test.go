@@ -4,9 +4,15 @@
 package commands_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/cli/commands"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/testutils"
 )
 
 func TestVersion(t *testing.T) {
@@ -84,3 +90,74 @@ Release: Enterprise`,
 		}
 	}
 }
+
+func TestVersionMatrixString(t *testing.T) {
+	t.Run("prints the built-in matrix and how to override it", func(t *testing.T) {
+		actual, err := commands.VersionMatrixString("", "")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `Version compatibility matrix (built-in)
+
+Source   Target   Min Source     Min Target
+5.x      6.x      5.29.1         6.18.0
+6.x      6.x      6.18.0         6.18.0
+6.x      7.x      6.18.0         7.0.0
+
+To use a different matrix set version_matrix in the gpupgrade_config file, or
+pass --version-matrix to initialize, naming a JSON file in the same format as
+"gpupgrade version --matrix --format json".
+`
+		if actual != expected {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+
+	t.Run("prints the matrix as json", func(t *testing.T) {
+		actual, err := commands.VersionMatrixString("json", "")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		var matrix greenplum.VersionMatrix
+		if err := json.Unmarshal([]byte(actual), &matrix); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(matrix.Upgrades) != 3 {
+			t.Errorf("got upgrades %+v want 3", matrix.Upgrades)
+		}
+	})
+
+	t.Run("prints the override file", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		path := filepath.Join(dir, "matrix.json")
+		testutils.MustWriteToFile(t, path, `{"upgrades": [{"sourceMajor": 6, "targetMajor": 7, "minSource": "6.20.0", "minTarget": "7.0.0"}]}`)
+
+		actual, err := commands.VersionMatrixString("", path)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := fmt.Sprintf("Version compatibility matrix (%s)", path)
+		if !strings.HasPrefix(actual, expected) || !strings.Contains(actual, "6.x      7.x      6.20.0         7.0.0") {
+			t.Errorf("got %q want the override matrix", actual)
+		}
+	})
+
+	t.Run("errors when the override file is invalid", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		path := filepath.Join(dir, "matrix.json")
+		testutils.MustWriteToFile(t, path, `{"upgrades": []}`)
+
+		_, err := commands.VersionMatrixString("", path)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
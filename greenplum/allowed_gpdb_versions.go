@@ -4,124 +4,270 @@
 package greenplum
 
 import (
+	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-// Note that we represent the source and target versions separately.  Another
-// option is a matrix explicitly listing supported source/target combinations.
-// However, pg_upgrade supports upgrade from any version to any version.
-// We are not sure yet if we are doing that for gpupgrade.
+// defaultVersionMatrix controls which upgrades are allowed by the utility.
+// Modify version_matrix.json to change it.
+//
+//go:embed version_matrix.json
+var defaultVersionMatrix []byte
 
-var (
-	// sourceVersionAllowed returns whether or not the given semver.Version is a
-	// valid source GPDB cluster version.
-	sourceVersionAllowed semver.Range
+// VersionMatrix lists the supported upgrades between major versions and the
+// versions that are denied regardless of the upgrade.
+type VersionMatrix struct {
+	Upgrades []UpgradePath   `json:"upgrades"`
+	Denied   []DeniedVersion `json:"denied"`
 
-	// targetVersionAllowed returns whether or not the given semver.Version is a
-	// valid target GPDB cluster version.
-	targetVersionAllowed semver.Range
-)
+	// Origin is the path of the matrix, or "built-in" when it is embedded
+	// in gpupgrade.
+	Origin string `json:"-"`
+}
 
-// Source and Target Versions: modify these lists to control what will be allowed
-// by the utility.  Map entries are of the form: GPDB_VERSION : MIN_ALLOWED_SEMVER
+// UpgradePath allows upgrading from the source to the target major version
+// when both clusters are at least the given minimum versions.
+type UpgradePath struct {
+	SourceMajor uint64 `json:"sourceMajor"`
+	TargetMajor uint64 `json:"targetMajor"`
+	MinSource   string `json:"minSource"`
+	MinTarget   string `json:"minTarget"`
+}
 
-var minSourceVersions = map[int]string{
-	5: "5.29.1",
-	6: "6.18.0",
+// DeniedVersion rejects the versions in the semver range, such as "6.19.0" or
+// ">=6.19.0 <6.19.2", of the source, target, or when Cluster is empty either
+// cluster.
+type DeniedVersion struct {
+	Cluster  string `json:"cluster,omitempty"`
+	Versions string `json:"versions"`
+	Reason   string `json:"reason"`
 }
 
-var minTargetVersions = map[int]string{
-	6: "6.18.0",
+func (d DeniedVersion) appliesTo(destination idl.ClusterDestination) bool {
+	return d.Cluster == "" || d.Cluster == strings.ToLower(destination.String())
 }
 
-// The below boilerplate turns the source/targetRanges variables into
-// source/targetVersionAllowed. You shouldn't need to touch it.
+// LoadVersionMatrix returns the matrix in the file at path, or the built-in
+// matrix when path is empty. An override file allows a newly released version,
+// or denies one with a known pg_upgrade bug, without rebuilding gpupgrade.
+func LoadVersionMatrix(path string) (*VersionMatrix, error) {
+	if path == "" {
+		return ParseVersionMatrix(defaultVersionMatrix, "built-in")
+	}
 
-func init() {
-	accumulateRanges(&sourceVersionAllowed, minSourceVersions)
-	accumulateRanges(&targetVersionAllowed, minTargetVersions)
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("reading version matrix: %w", err)
+	}
+
+	return ParseVersionMatrix(contents, path)
 }
 
-func accumulateRanges(a *semver.Range, minVersions map[int]string) {
-	for v, min := range minVersions {
-		// for example, 5: "5.28.0" becomes the Range string ">=5.28.0 <6.0.0"
-		str := fmt.Sprintf(">=%s <%d.0.0", min, v+1)
-		r := semver.MustParseRange(str)
+// ParseVersionMatrix parses and validates a JSON version matrix.
+func ParseVersionMatrix(contents []byte, origin string) (*VersionMatrix, error) {
+	matrix := &VersionMatrix{Origin: origin}
+	if err := json.Unmarshal(contents, matrix); err != nil {
+		return nil, xerrors.Errorf("parsing version matrix %q: %w", origin, err)
+	}
 
-		if *a == nil {
-			*a = r
-		} else {
-			*a = a.OR(r)
-		}
+	var errs error
+	if len(matrix.Upgrades) == 0 {
+		errs = errorlist.Append(errs, errors.New("no upgrades are listed"))
 	}
-}
 
-// returns the version string that is the lowest of the major version of "version"
-// or the lowest version supported in minVersions otherwise
-func getMinVersion(version semver.Version, minVersions map[int]string) string {
+	for _, path := range matrix.Upgrades {
+		for _, min := range []struct {
+			version string
+			major   uint64
+		}{{path.MinSource, path.SourceMajor}, {path.MinTarget, path.TargetMajor}} {
+			version, err := semver.Parse(min.version)
+			if err != nil {
+				errs = errorlist.Append(errs, xerrors.Errorf("upgrade from %d to %d: minimum version %q: %w", path.SourceMajor, path.TargetMajor, min.version, err))
+				continue
+			}
 
-	major := version.Major
-	min, ok := minVersions[int(major)]
-	if ok {
-		return min
+			if version.Major != min.major {
+				errs = errorlist.Append(errs, fmt.Errorf("upgrade from %d to %d: minimum version %s is not a %d.x version", path.SourceMajor, path.TargetMajor, version, min.major))
+			}
+		}
 	}
 
-	var lowest int
-	for major := range minVersions {
-		if lowest == 0 {
-			lowest = major
+	for _, denied := range matrix.Denied {
+		if _, err := semver.ParseRange(denied.Versions); err != nil {
+			errs = errorlist.Append(errs, xerrors.Errorf("denied versions %q: %w", denied.Versions, err))
 		}
-		if major < lowest {
-			lowest = major
+
+		if denied.Cluster != "" && denied.Cluster != "source" && denied.Cluster != "target" {
+			errs = errorlist.Append(errs, fmt.Errorf("denied versions %q: cluster %q is not one of source or target", denied.Versions, denied.Cluster))
 		}
 	}
-	return semver.MustParse(minVersions[lowest]).String()
+
+	if errs != nil {
+		return nil, xerrors.Errorf("in version matrix %q: %w", origin, errs)
+	}
+
+	return matrix, nil
 }
 
-func VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome string) error {
-	var errs error
+// VerifyCompatibleGPDBVersions verifies the versions of the source and target
+// installations against the matrix loaded from versionMatrix.
+func VerifyCompatibleGPDBVersions(sourceGPHome, targetGPHome string, versionMatrix string) error {
+	matrix, err := LoadVersionMatrix(versionMatrix)
+	if err != nil {
+		return err
+	}
 
 	sourceVersion, err := Version(sourceGPHome)
 	if err != nil {
 		return err
 	}
 
-	err = validateVersion(sourceVersion, idl.ClusterDestination_SOURCE)
-	errs = errorlist.Append(errs, err)
-
 	targetVersion, err := Version(targetGPHome)
 	if err != nil {
 		return err
 	}
 
-	err = validateVersion(targetVersion, idl.ClusterDestination_TARGET)
-	errs = errorlist.Append(errs, err)
+	return matrix.Verify(semver.MustParse(sourceVersion), semver.MustParse(targetVersion))
+}
+
+// Verify returns an error when upgrading from the source to the target version
+// is not allowed by the matrix.
+func (m *VersionMatrix) Verify(source semver.Version, target semver.Version) error {
+	var errs error
+	errs = errorlist.Append(errs, m.verifyVersion(source, idl.ClusterDestination_SOURCE))
+	errs = errorlist.Append(errs, m.verifyVersion(target, idl.ClusterDestination_TARGET))
+	if errs != nil {
+		return errs
+	}
+
+	path, ok := m.findUpgrade(source.Major, target.Major)
+	if !ok {
+		return fmt.Errorf("upgrading from source cluster version %s to target cluster version %s is not supported.  "+
+			"Supported upgrades are %s.", source, target, m.supportedUpgrades())
+	}
 
+	errs = errorlist.Append(errs, verifyMinVersion(source, path.MinSource, idl.ClusterDestination_SOURCE))
+	errs = errorlist.Append(errs, verifyMinVersion(target, path.MinTarget, idl.ClusterDestination_TARGET))
 	return errs
 }
 
-func validateVersion(versionStr string, destination idl.ClusterDestination) error {
-	versionsAllowed := sourceVersionAllowed
-	minVersions := minSourceVersions
-	if destination == idl.ClusterDestination_TARGET {
-		versionsAllowed = targetVersionAllowed
-		minVersions = minTargetVersions
+// verifyVersion ensures the version is not denied and that its major version
+// is supported for the cluster.
+func (m *VersionMatrix) verifyVersion(version semver.Version, destination idl.ClusterDestination) error {
+	for _, denied := range m.Denied {
+		if !denied.appliesTo(destination) {
+			continue
+		}
+
+		if semver.MustParseRange(denied.Versions)(version) {
+			return fmt.Errorf("%s cluster version %s is not supported: %s",
+				strings.ToLower(destination.String()), version, denied.Reason)
+		}
+	}
+
+	var lowest semver.Version
+	for _, path := range m.Upgrades {
+		major, min := path.SourceMajor, path.MinSource
+		if destination == idl.ClusterDestination_TARGET {
+			major, min = path.TargetMajor, path.MinTarget
+		}
+
+		if major == version.Major {
+			return nil
+		}
+
+		minVersion := semver.MustParse(min)
+		if lowest.Equals(semver.Version{}) || minVersion.LT(lowest) {
+			lowest = minVersion
+		}
 	}
 
-	version := semver.MustParse(versionStr)
-	if !versionsAllowed(version) {
-		min := getMinVersion(version, minVersions)
-		return fmt.Errorf("%s cluster version %s is not supported.  "+
-			"The minimum required version is %s. "+
-			"We recommend the latest version.",
-			strings.ToLower(destination.String()), version, min)
+	return minVersionError(version, lowest, destination)
+}
+
+func verifyMinVersion(version semver.Version, min string, destination idl.ClusterDestination) error {
+	minVersion := semver.MustParse(min)
+	if version.LT(minVersion) {
+		return minVersionError(version, minVersion, destination)
 	}
 
 	return nil
 }
+
+func minVersionError(version semver.Version, min semver.Version, destination idl.ClusterDestination) error {
+	return fmt.Errorf("%s cluster version %s is not supported.  "+
+		"The minimum required version is %s. "+
+		"We recommend the latest version.",
+		strings.ToLower(destination.String()), version, min)
+}
+
+func (m *VersionMatrix) findUpgrade(sourceMajor uint64, targetMajor uint64) (UpgradePath, bool) {
+	for _, path := range m.Upgrades {
+		if path.SourceMajor == sourceMajor && path.TargetMajor == targetMajor {
+			return path, true
+		}
+	}
+
+	return UpgradePath{}, false
+}
+
+func (m *VersionMatrix) supportedUpgrades() string {
+	var upgrades []string
+	for _, path := range m.sortedUpgrades() {
+		upgrades = append(upgrades, fmt.Sprintf("%d.x to %d.x", path.SourceMajor, path.TargetMajor))
+	}
+
+	return strings.Join(upgrades, ", ")
+}
+
+func (m *VersionMatrix) sortedUpgrades() []UpgradePath {
+	upgrades := append([]UpgradePath{}, m.Upgrades...)
+	sort.Slice(upgrades, func(i, j int) bool {
+		if upgrades[i].SourceMajor != upgrades[j].SourceMajor {
+			return upgrades[i].SourceMajor < upgrades[j].SourceMajor
+		}
+
+		return upgrades[i].TargetMajor < upgrades[j].TargetMajor
+	})
+
+	return upgrades
+}
+
+// String returns the matrix as a table for display.
+func (m *VersionMatrix) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Version compatibility matrix (%s)\n\n", m.Origin)
+	fmt.Fprintf(&b, "%-8s %-8s %-14s %s\n", "Source", "Target", "Min Source", "Min Target")
+	for _, path := range m.sortedUpgrades() {
+		fmt.Fprintf(&b, "%-8s %-8s %-14s %s\n",
+			fmt.Sprintf("%d.x", path.SourceMajor), fmt.Sprintf("%d.x", path.TargetMajor), path.MinSource, path.MinTarget)
+	}
+
+	if len(m.Denied) == 0 {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\nDenied versions\n")
+	for _, denied := range m.Denied {
+		cluster := denied.Cluster
+		if cluster == "" {
+			cluster = "any"
+		}
+
+		fmt.Fprintf(&b, "%-8s %-22s %s\n", cluster, denied.Versions, denied.Reason)
+	}
+
+	return b.String()
+}
@@ -4,130 +4,255 @@
 package greenplum
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/blang/semver/v4"
 
-	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func TestAllowedVersions(t *testing.T) {
+const testMatrix = `{
+  "upgrades": [
+    {"sourceMajor": 5, "targetMajor": 6, "minSource": "5.29.1", "minTarget": "6.18.0"},
+    {"sourceMajor": 6, "targetMajor": 6, "minSource": "6.18.0", "minTarget": "6.18.0"},
+    {"sourceMajor": 6, "targetMajor": 7, "minSource": "6.20.0", "minTarget": "7.0.0"}
+  ],
+  "denied": [
+    {"cluster": "target", "versions": ">=6.19.0 <6.19.2", "reason": "pg_upgrade fails on partitioned tables"},
+    {"versions": "6.25.0", "reason": "known bug"}
+  ]
+}`
+
+func TestDefaultVersionMatrix(t *testing.T) {
+	matrix, err := ParseVersionMatrix(defaultVersionMatrix, "built-in")
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
 	cases := []struct {
-		name          string
-		versions      []string
-		validator     semver.Range
-		validatorName string
-		expected      bool
+		source  string
+		target  string
+		allowed bool
 	}{
-		{
-			"allowed source versions",
-			[]string{
-				"5.29.1",
-				"5.29.13",
-				"5.50.0",
-				"6.18.0",
-				"6.18.1",
-				"6.50.1",
-			},
-			sourceVersionAllowed,
-			"sourceVersionAllowed",
-			true,
-		}, {
-			"disallowed source versions",
-			[]string{
-				"4.3.0",
-				"5.0.0",
-				"5.28.11",
-				"5.29.0",
-				"6.0.0",
-				"6.17.9",
-				"7.0.0",
-			},
-			sourceVersionAllowed,
-			"sourceVersionAllowed",
-			false,
-		}, {
-			"allowed target versions",
-			[]string{
-				"6.18.0",
-				"6.18.1",
-				"6.50.1",
-			},
-			targetVersionAllowed,
-			"targetVersionAllowed",
-			true,
-		}, {
-			"disallowed target versions",
-			[]string{
-				"4.3.0",
-				"5.0.0",
-				"5.27.0",
-				"5.28.0",
-				"5.50.0",
-				"6.0.0",
-				"6.17.0",
-				"7.0.0",
-			},
-			targetVersionAllowed,
-			"targetVersionAllowed",
-			false,
-		},
+		{"5.29.1", "6.18.0", true},
+		{"5.50.0", "6.50.1", true},
+		{"6.18.0", "6.18.1", true},
+		{"6.18.0", "7.0.0", true},
+		{"6.50.1", "7.1.0", true},
+		{"4.3.0", "6.18.0", false},
+		{"5.28.11", "6.18.0", false},
+		{"6.17.9", "6.18.0", false},
+		{"5.29.1", "6.17.0", false},
+		{"5.29.1", "5.29.2", false},
+		{"5.29.1", "7.0.0", false},
+		{"7.0.0", "7.1.0", false},
+		{"6.18.0", "8.0.0", false},
 	}
 
 	for _, c := range cases {
-		t.Run(c.name, func(t *testing.T) {
-			for _, v := range c.versions {
-				ver := semver.MustParse(v)
-				actual := c.validator(ver)
+		t.Run(c.source+" to "+c.target, func(t *testing.T) {
+			err := matrix.Verify(semver.MustParse(c.source), semver.MustParse(c.target))
+			if c.allowed && err != nil {
+				t.Errorf("unexpected error %+v", err)
+			}
 
-				if actual != c.expected {
-					t.Errorf("%s(%q) = %t, want %t", c.validatorName, v, actual, c.expected)
-				}
+			if !c.allowed && err == nil {
+				t.Errorf("expected error")
 			}
 		})
 	}
 }
 
-func TestValidateVersionsErrorCases(t *testing.T) {
+func TestVersionMatrixVerify(t *testing.T) {
+	matrix, err := ParseVersionMatrix([]byte(testMatrix), "test")
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
 	cases := []struct {
-		name             string
-		localVersion     string
-		testLocalVersion func(string) (semver.Version, error)
-		expected         error
+		name     string
+		source   string
+		target   string
+		expected []string
 	}{
 		{
-			name:         "fails when GPDB version has unsupported minor versions",
-			localVersion: semver.MustParse("6.8.0").String(),
-			expected:     errors.New("source cluster version 6.8.0 is not supported.  The minimum required version is 6.18.0. We recommend the latest version."),
+			name:     "fails when GPDB version has unsupported minor versions",
+			source:   "6.8.0",
+			target:   "6.18.0",
+			expected: []string{"source cluster version 6.8.0 is not supported.  The minimum required version is 6.18.0. We recommend the latest version."},
+		},
+		{
+			name:   "fails when GPDB versions have unsupported major versions",
+			source: "0.0.0",
+			target: "0.0.0",
+			expected: []string{
+				"source cluster version 0.0.0 is not supported.  The minimum required version is 5.29.1. We recommend the latest version.",
+				"target cluster version 0.0.0 is not supported.  The minimum required version is 6.18.0. We recommend the latest version.",
+			},
 		},
 		{
-			name:         "fails when GPDB version has unsupported major versions",
-			localVersion: semver.MustParse("0.0.0").String(),
-			expected:     errors.New("source cluster version 0.0.0 is not supported.  The minimum required version is 5.29.1. We recommend the latest version."),
+			name:     "uses the minimum versions of the upgrade",
+			source:   "6.18.0",
+			target:   "7.0.0",
+			expected: []string{"source cluster version 6.18.0 is not supported.  The minimum required version is 6.20.0. We recommend the latest version."},
+		},
+		{
+			name:     "fails when the upgrade is not listed",
+			source:   "5.29.1",
+			target:   "7.0.0",
+			expected: []string{"upgrading from source cluster version 5.29.1 to target cluster version 7.0.0 is not supported.  Supported upgrades are 5.x to 6.x, 6.x to 6.x, 6.x to 7.x."},
+		},
+		{
+			name:     "fails when the target version is denied",
+			source:   "5.29.1",
+			target:   "6.19.1",
+			expected: []string{"target cluster version 6.19.1 is not supported: pg_upgrade fails on partitioned tables"},
+		},
+		{
+			name:     "fails when the source or target version is denied",
+			source:   "6.25.0",
+			target:   "7.0.0",
+			expected: []string{"source cluster version 6.25.0 is not supported: known bug"},
 		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			err := validateVersion(c.localVersion, idl.ClusterDestination_SOURCE)
-			if err.Error() != c.expected.Error() {
-				t.Errorf("got %s want %s", err, c.expected)
+			err := matrix.Verify(semver.MustParse(c.source), semver.MustParse(c.target))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			var actual []string
+			var errs errorlist.Errors
+			if errors.As(err, &errs) {
+				for _, e := range errs {
+					actual = append(actual, e.Error())
+				}
+			} else {
+				actual = []string{err.Error()}
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("got %q want %q", actual, c.expected)
 			}
 		})
 	}
+
+	t.Run("allows a source version that is only denied as a target", func(t *testing.T) {
+		err := matrix.Verify(semver.MustParse("6.19.0"), semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+}
+
+func TestParseVersionMatrix(t *testing.T) {
+	t.Run("reports every invalid entry", func(t *testing.T) {
+		_, err := ParseVersionMatrix([]byte(`{
+  "upgrades": [{"sourceMajor": 6, "targetMajor": 7, "minSource": "6.x", "minTarget": "6.18.0"}],
+  "denied": [{"cluster": "both", "versions": "6.19.0", "reason": "bug"}, {"versions": "~>", "reason": "bug"}]
+}`), "matrix.json")
+
+		for _, expected := range []string{
+			`upgrade from 6 to 7: minimum version "6.x"`,
+			"upgrade from 6 to 7: minimum version 6.18.0 is not a 7.x version",
+			`denied versions "6.19.0": cluster "both" is not one of source or target`,
+			`denied versions "~>"`,
+		} {
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected error %v to contain %q", err, expected)
+			}
+		}
+	})
+
+	t.Run("requires an upgrade", func(t *testing.T) {
+		_, err := ParseVersionMatrix([]byte(`{"upgrades": []}`), "matrix.json")
+		if err == nil || !strings.Contains(err.Error(), "no upgrades are listed") {
+			t.Errorf("got %v want no upgrades error", err)
+		}
+	})
+
+	t.Run("errors on invalid JSON", func(t *testing.T) {
+		_, err := ParseVersionMatrix([]byte(`{`), "matrix.json")
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("got error %#v want %T", err, syntaxErr)
+		}
+	})
+}
+
+func TestLoadVersionMatrix(t *testing.T) {
+	t.Run("loads the built-in matrix by default", func(t *testing.T) {
+		matrix, err := LoadVersionMatrix("")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if matrix.Origin != "built-in" || len(matrix.Upgrades) == 0 {
+			t.Errorf("unexpected matrix %+v", matrix)
+		}
+	})
+
+	t.Run("loads the override file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "matrix.json")
+		if err := os.WriteFile(path, []byte(testMatrix), 0600); err != nil {
+			t.Fatal(err)
+		}
+		matrix, err := LoadVersionMatrix(path)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if matrix.Origin != path || len(matrix.Denied) != 2 {
+			t.Errorf("unexpected matrix %+v", matrix)
+		}
+	})
+
+	t.Run("errors when the override file cannot be read", func(t *testing.T) {
+		_, err := LoadVersionMatrix(filepath.Join(t.TempDir(), "missing.json"))
+		var pathErr *os.PathError
+		if !errors.As(err, &pathErr) {
+			t.Errorf("got error %#v want %T", err, pathErr)
+		}
+	})
+}
+
+func TestVersionMatrixString(t *testing.T) {
+	matrix, err := ParseVersionMatrix([]byte(testMatrix), "/home/gpadmin/matrix.json")
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := `Version compatibility matrix (/home/gpadmin/matrix.json)
+
+Source   Target   Min Source     Min Target
+5.x      6.x      5.29.1         6.18.0
+6.x      6.x      6.18.0         6.18.0
+6.x      7.x      6.20.0         7.0.0
+
+Denied versions
+target   >=6.19.0 <6.19.2       pg_upgrade fails on partitioned tables
+any      6.25.0                 known bug
+`
+	if matrix.String() != expected {
+		t.Errorf("got %q want %q", matrix.String(), expected)
+	}
 }
 
 func TestVerifyCompatibleGPDBVersions(t *testing.T) {
 	testlog.SetupLogger()
 
 	t.Run("returns error when gphome is incorrect", func(t *testing.T) {
-		err := VerifyCompatibleGPDBVersions("/usr/local/greenplum-db-source-typo", "")
+		err := VerifyCompatibleGPDBVersions("/usr/local/greenplum-db-source-typo", "", "")
 		var pathError *os.PathError
 		if !errors.As(err, &pathError) {
 			t.Errorf("got type %T want %T", err, pathError)
@@ -138,7 +263,7 @@ func TestVerifyCompatibleGPDBVersions(t *testing.T) {
 		SetVersionCommand(exectest.NewCommand(PostgresGPVersion_0_0_0))
 		defer ResetVersionCommand()
 
-		err := VerifyCompatibleGPDBVersions("", "")
+		err := VerifyCompatibleGPDBVersions("", "", "")
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("got error %#v, want type %T", err, errs)
@@ -366,7 +366,7 @@ func (c *Cluster) runGreenplumCommand(streams step.OutStreams, utility string, a
 	args = append([]string{path}, args...)
 
 	cmd := greenplumCommand("bash", "-c", fmt.Sprintf("source %s/greenplum_path.sh && %s", c.GPHome, shellquote.Join(args...)))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", "MASTER_DATA_DIRECTORY", c.MasterDataDir()))
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", MasterDataDirectoryEnv(c.Version), c.MasterDataDir()))
 	cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", "PGPORT", c.MasterPort()))
 	cmd.Env = append(cmd.Env, envs...)
 
@@ -379,7 +379,7 @@ func (c *Cluster) runGreenplumCommand(streams step.OutStreams, utility string, a
 
 // WaitForClusterToBeReady waits until the timeout for all segments to be up,
 // in their preferred role, and synchronized.
-func (c *Cluster) WaitForClusterToBeReady(conn *Conn) error {
+func (c *Cluster) WaitForClusterToBeReady(conn *Conn, timeout time.Duration) error {
 	destination := ToTarget()
 	if c.Destination == idl.ClusterDestination_SOURCE {
 		destination = ToSource()
@@ -400,9 +400,12 @@ func (c *Cluster) WaitForClusterToBeReady(conn *Conn) error {
 		}
 	}()
 
-	return WaitForSegments(db, 5*time.Minute, c)
+	return WaitForSegments(db, timeout, c)
 }
 
+// WaitForSegments polls until all segments are up, in their preferred role,
+// and synchronized. When the timeout is exceeded a ClusterNotReadyError lists
+// the segments that are still unhealthy.
 func WaitForSegments(db *sql.DB, timeout time.Duration, cluster *Cluster) error {
 	startTime := time.Now()
 	for {
@@ -427,7 +430,12 @@ func WaitForSegments(db *sql.DB, timeout time.Duration, cluster *Cluster) error
 		}
 
 		if time.Since(startTime) > timeout {
-			return xerrors.Errorf("%s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized.", timeout)
+			segments, err := UnhealthySegmentsFromDB(db, cluster)
+			if err != nil {
+				return err
+			}
+
+			return ClusterNotReadyError{Timeout: timeout, Segments: segments}
 		}
 
 		time.Sleep(time.Second)
@@ -459,24 +467,5 @@ WHERE content > -1 AND status = 'u' AND (role = preferred_role) ` + whereClause)
 		return false, nil
 	}
 
-	// check gp_stat_replication for the standby. Note, gp_stat_replication does not exist in GPDB 5.
-	if cluster.Version.Major == 5 || !cluster.HasStandby() {
-		return true, nil
-	}
-
-	row = db.QueryRow("SELECT COUNT(*) FROM gp_stat_replication WHERE gp_segment_id = -1 AND state = 'streaming' AND sent_location = flush_location;")
-	if err := row.Scan(&segments); err != nil {
-		if err == sql.ErrNoRows {
-			gplog.Debug("no rows found when querying gp_stat_replication")
-			return false, nil
-		}
-
-		return false, xerrors.Errorf("querying gp_stat_replication: %w", err)
-	}
-
-	if segments != 1 {
-		return false, nil
-	}
-
-	return true, nil
+	return isStandbyStreaming(db, cluster)
 }
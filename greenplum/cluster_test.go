@@ -424,6 +424,26 @@ func TestRunGreenplumCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("sets the coordinator data directory for GPDB 7", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(EnvironmentMain))
+		defer greenplum.ResetGreenplumCommand()
+
+		coordinator := *cluster
+		coordinator.Version = semver.MustParse("7.0.0")
+
+		streams := &step.BufferedStreams{}
+		err := coordinator.RunGreenplumCmd(streams, "gpstop", "-a")
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		actual := streams.StdoutBuf.String()
+		expected := "COORDINATOR_DATA_DIRECTORY=/data/qddir/seg-1\nPGPORT=15432\n"
+		if actual != expected {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	})
+
 	t.Run("returns errors", func(t *testing.T) {
 		greenplum.SetGreenplumCommand(exectest.NewCommand(FailedMain))
 		defer greenplum.ResetGreenplumCommand()
@@ -467,6 +487,21 @@ func TestWaitForSegments(t *testing.T) {
 		}
 	})
 
+	t.Run("checks the standby using the GPDB 7 gp_stat_replication columns", func(t *testing.T) {
+		target.Version = semver.MustParse("7.0.0")
+
+		expectFtsProbe(mock)
+		expectGpSegmentConfigurationToReturn(mock, 4)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM gp_stat_replication 
+WHERE gp_segment_id = -1 AND state = 'streaming' AND sent_lsn = flush_lsn;`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		err = greenplum.WaitForSegments(db, timeout, target)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
 	t.Run("skips fts and gp_stat_replication if GPDB version is 5", func(t *testing.T) {
 		target.Version = semver.MustParse("5.0.0")
 
@@ -556,11 +591,27 @@ func TestWaitForSegments(t *testing.T) {
 
 		expectFtsProbe(mock)
 		expectGpSegmentConfigurationToReturn(mock, 0)
+		mock.ExpectQuery(`SELECT dbid, content, hostname, role, preferred_role, mode, status`).
+			WillReturnRows(sqlmock.NewRows([]string{"dbid", "content", "hostname", "role", "preferred_role", "mode", "status"}).
+				AddRow(4, 0, "sdw2", "m", "m", "n", "d"))
+		expectGpStatReplicationToReturn(mock, 1)
 
 		err = greenplum.WaitForSegments(db, -1*time.Second, target)
-		expected := "-1s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized."
-		if err.Error() != expected {
-			t.Errorf("got: %#v want %s", err, expected)
+		var notReady greenplum.ClusterNotReadyError
+		if !errors.As(err, &notReady) {
+			t.Fatalf("got error %#v want %T", err, notReady)
+		}
+
+		expected := greenplum.UnhealthySegments{
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", Role: "m", PreferredRole: "m", Mode: "n", Status: "d"},
+		}
+		if !reflect.DeepEqual(notReady.Segments, expected) {
+			t.Errorf("got segments %+v want %+v", notReady.Segments, expected)
+		}
+
+		prefix := "-1s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized."
+		if !strings.HasPrefix(err.Error(), prefix) {
+			t.Errorf("got: %q want prefix %q", err.Error(), prefix)
 		}
 	})
 }
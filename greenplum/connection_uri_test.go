@@ -42,6 +42,15 @@ func TestConnURI(t *testing.T) {
 			},
 			"postgresql://localhost:12345/template1?search_path=",
 		},
+		{
+			"set database",
+			v5X,
+			v6X,
+			[]greenplum.Option{
+				greenplum.Database("my db"),
+			},
+			"postgresql://localhost:0/my%20db?search_path=",
+		},
 		{
 			"connect to source version less than 7X",
 			v5X,
@@ -0,0 +1,93 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// ClusterStateShutDown is the pg_controldata cluster state of a data
+// directory that was cleanly shut down.
+const ClusterStateShutDown = "shut down"
+
+// GetControlData runs pg_controldata from gphome against dataDir. The C locale
+// is used since the field names are translated.
+func GetControlData(gphome string, dataDir string) (*idl.ControlData, error) {
+	path := filepath.Join(gphome, "bin", "pg_controldata")
+	cmd := greenplumCommand("bash", "-c", fmt.Sprintf("source %s/greenplum_path.sh && %s", gphome, shellquote.Join(path, dataDir)))
+	cmd.Env = []string{"LC_ALL=C"}
+
+	gplog.Debug(cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, xerrors.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+	}
+
+	controlData, err := ParseControlData(string(output))
+	if err != nil {
+		return nil, xerrors.Errorf("data directory %q: %w", dataDir, err)
+	}
+
+	controlData.DataDir = dataDir
+	return controlData, nil
+}
+
+// ParseControlData parses the output of pg_controldata. Every field is
+// available by name in Fields, and commonly used fields are also parsed into
+// their own members. The data checksum version is zero for GPDB 5 which does
+// not report it.
+func ParseControlData(output string) (*idl.ControlData, error) {
+	fields := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		fields[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("scanning pg_controldata: %w", err)
+	}
+
+	if len(fields) == 0 {
+		return nil, xerrors.Errorf("pg_controldata output %q has no fields", output)
+	}
+
+	controlData := &idl.ControlData{
+		ClusterState:       fields["Database cluster state"],
+		PgControlVersion:   fields["pg_control version number"],
+		CatalogVersion:     fields["Catalog version number"],
+		SystemIdentifier:   fields["Database system identifier"],
+		CheckpointLocation: fields["Latest checkpoint location"],
+		RedoLocation:       fields["Latest checkpoint's REDO location"],
+		Timeline:           fields["Latest checkpoint's TimeLineID"],
+		NextXid:            fields["Latest checkpoint's NextXID"],
+		Fields:             fields,
+	}
+
+	if version, ok := fields["Data page checksum version"]; ok {
+		checksumVersion, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			return nil, xerrors.Errorf("parsing data page checksum version: %w", err)
+		}
+
+		controlData.DataChecksumVersion = uint32(checksumVersion)
+	}
+
+	return controlData, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+const controlData6X = `pg_control version number:            9420600
+Catalog version number:               301908232
+Database system identifier:           6849079892457217099
+Database cluster state:               shut down
+pg_control last modified:             Mon Jul 13 14:36:28 2020
+Latest checkpoint location:           0/180001D0
+Prior checkpoint location:            0/18000150
+Latest checkpoint's REDO location:    0/180001D0
+Latest checkpoint's TimeLineID:       1
+Latest checkpoint's NextXID:          0/1543
+Data page checksum version:           1
+`
+
+func PgControlData6X() {
+	fmt.Print(controlData6X)
+}
+
+func PgControlDataFails() {
+	fmt.Print("pg_controldata: could not open file")
+	os.Exit(1)
+}
+
+func init() {
+	exectest.RegisterMains(
+		PgControlData6X,
+		PgControlDataFails,
+	)
+}
+
+func TestParseControlData(t *testing.T) {
+	t.Run("parses the fields", func(t *testing.T) {
+		controlData, err := greenplum.ParseControlData(controlData6X)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if controlData.GetClusterState() != greenplum.ClusterStateShutDown {
+			t.Errorf("got cluster state %q want %q", controlData.GetClusterState(), greenplum.ClusterStateShutDown)
+		}
+
+		if controlData.GetCatalogVersion() != "301908232" {
+			t.Errorf("got catalog version %q want %q", controlData.GetCatalogVersion(), "301908232")
+		}
+
+		if controlData.GetCheckpointLocation() != "0/180001D0" {
+			t.Errorf("got checkpoint location %q want %q", controlData.GetCheckpointLocation(), "0/180001D0")
+		}
+
+		if controlData.GetNextXid() != "0/1543" {
+			t.Errorf("got next xid %q want %q", controlData.GetNextXid(), "0/1543")
+		}
+
+		if controlData.GetDataChecksumVersion() != 1 {
+			t.Errorf("got data checksum version %d want 1", controlData.GetDataChecksumVersion())
+		}
+
+		if controlData.GetFields()["pg_control last modified"] != "Mon Jul 13 14:36:28 2020" {
+			t.Errorf("got fields %v want pg_control last modified", controlData.GetFields())
+		}
+	})
+
+	t.Run("defaults the checksum version when it is not reported", func(t *testing.T) {
+		controlData, err := greenplum.ParseControlData("Database cluster state:               in production\n")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if controlData.GetDataChecksumVersion() != 0 {
+			t.Errorf("got data checksum version %d want 0", controlData.GetDataChecksumVersion())
+		}
+	})
+
+	t.Run("errors when there are no fields", func(t *testing.T) {
+		_, err := greenplum.ParseControlData("\n")
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestGetControlData(t *testing.T) {
+	testlog.SetupLogger()
+
+	t.Run("runs pg_controldata on the data directory", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommandWithVerifier(PgControlData6X, func(utility string, args ...string) {
+			expected := "source /usr/local/gpdb6/greenplum_path.sh && /usr/local/gpdb6/bin/pg_controldata /data/qddir/seg-1"
+			if len(args) != 2 || args[1] != expected {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer greenplum.ResetGreenplumCommand()
+
+		controlData, err := greenplum.GetControlData("/usr/local/gpdb6", "/data/qddir/seg-1")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if controlData.GetDataDir() != "/data/qddir/seg-1" {
+			t.Errorf("got data directory %q want %q", controlData.GetDataDir(), "/data/qddir/seg-1")
+		}
+	})
+
+	t.Run("returns pg_controldata failures", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(PgControlDataFails))
+		defer greenplum.ResetGreenplumCommand()
+
+		_, err := greenplum.GetControlData("/usr/local/gpdb6", "/data/qddir/seg-1")
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Errorf("got error %#v want %T", err, exitErr)
+		}
+	})
+}
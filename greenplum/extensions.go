@@ -0,0 +1,142 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+const libdirMacro = "$libdir"
+
+// PkgLibDir returns the directory containing the shared libraries of the
+// Greenplum installation, referred to as $libdir by the server.
+func PkgLibDir(gphome string) string {
+	return filepath.Join(gphome, "lib", "postgresql")
+}
+
+// ExtensionDir returns the directory containing the extension control and
+// script files of the Greenplum installation.
+func ExtensionDir(gphome string) string {
+	return filepath.Join(gphome, "share", "postgresql", "extension")
+}
+
+// ResolveLibrary mirrors how the server locates a shared library from a
+// pg_proc.probin value. Names starting with $libdir are expanded to the
+// pkglibdir of gphome, bare names are searched for in dynamicLibraryPath, and
+// absolute paths are used as is. The platform suffix is tried when the exact
+// name is not found. The resolved path is returned along with whether it
+// exists.
+func ResolveLibrary(gphome string, dynamicLibraryPath string, name string) (string, bool) {
+	var candidates []string
+
+	switch {
+	case strings.HasPrefix(name, libdirMacro):
+		candidates = append(candidates, PkgLibDir(gphome)+strings.TrimPrefix(name, libdirMacro))
+	case strings.Contains(name, "/"):
+		candidates = append(candidates, name)
+	default:
+		for _, dir := range libraryPathDirs(gphome, dynamicLibraryPath) {
+			candidates = append(candidates, filepath.Join(dir, name))
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, path := range []string{candidate, candidate + ".so"} {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+
+	return candidates[0], false
+}
+
+// libraryPathDirs expands the $libdir macro in a colon separated
+// dynamic_library_path. $libdir is always searched since it is the default
+// value of dynamic_library_path.
+func libraryPathDirs(gphome string, dynamicLibraryPath string) []string {
+	dirs := []string{PkgLibDir(gphome)}
+	for _, dir := range strings.Split(dynamicLibraryPath, ":") {
+		dir = strings.TrimSpace(dir)
+		if dir == "" || dir == libdirMacro {
+			continue
+		}
+
+		dirs = append(dirs, strings.Replace(dir, libdirMacro, PkgLibDir(gphome), 1))
+	}
+
+	return dirs
+}
+
+// ResolveLibraries resolves each library name using ResolveLibrary.
+func ResolveLibraries(gphome string, dynamicLibraryPath string, names []string) []*idl.ResolveLibrariesReply_Library {
+	var libraries []*idl.ResolveLibrariesReply_Library
+	for _, name := range names {
+		path, found := ResolveLibrary(gphome, dynamicLibraryPath, name)
+		libraries = append(libraries, &idl.ResolveLibrariesReply_Library{
+			Name:  name,
+			Path:  path,
+			Found: found,
+		})
+	}
+
+	return libraries
+}
+
+// ExtensionDefaultVersion reads the default_version from the extension's
+// control file under gphome. A missing control file is reported with an
+// error satisfying os.IsNotExist.
+func ExtensionDefaultVersion(gphome string, extension string) (string, error) {
+	path := filepath.Join(ExtensionDir(gphome), extension+".control")
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) != "default_version" {
+			continue
+		}
+
+		return strings.Trim(strings.TrimSpace(parts[1]), `'"`), nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", xerrors.Errorf("read %q: %w", path, err)
+	}
+
+	return "", nil
+}
+
+// CheckExtensionFiles reports which of the given extensions have control
+// files in the gphome installation.
+func CheckExtensionFiles(gphome string, extensions []string) (*idl.CheckExtensionsReply, error) {
+	reply := &idl.CheckExtensionsReply{}
+
+	for _, name := range extensions {
+		version, err := ExtensionDefaultVersion(gphome, name)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		reply.Extensions = append(reply.Extensions, &idl.CheckExtensionsReply_Extension{
+			Name:             name,
+			ControlFileFound: err == nil,
+			DefaultVersion:   version,
+		})
+	}
+
+	return reply, nil
+}
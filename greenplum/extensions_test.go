@@ -0,0 +1,115 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestResolveLibrary(t *testing.T) {
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	extraDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, extraDir)
+
+	testutils.MustCreateDir(t, greenplum.PkgLibDir(gphome))
+	testutils.MustWriteToFile(t, filepath.Join(greenplum.PkgLibDir(gphome), "plpgsql.so"), "")
+	testutils.MustWriteToFile(t, filepath.Join(extraDir, "postgis-2.1.so"), "")
+
+	cases := []struct {
+		name               string
+		dynamicLibraryPath string
+		expected           string
+		found              bool
+	}{
+		{"$libdir/plpgsql", "", filepath.Join(greenplum.PkgLibDir(gphome), "plpgsql.so"), true},
+		{"plpgsql", "$libdir", filepath.Join(greenplum.PkgLibDir(gphome), "plpgsql.so"), true},
+		{"postgis-2.1", "$libdir:" + extraDir, filepath.Join(extraDir, "postgis-2.1.so"), true},
+		{filepath.Join(extraDir, "postgis-2.1.so"), "", filepath.Join(extraDir, "postgis-2.1.so"), true},
+		{"postgis-2.1", "$libdir", filepath.Join(greenplum.PkgLibDir(gphome), "postgis-2.1"), false},
+		{"$libdir/missing", "", filepath.Join(greenplum.PkgLibDir(gphome), "missing"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path, found := greenplum.ResolveLibrary(gphome, c.dynamicLibraryPath, c.name)
+			if path != c.expected || found != c.found {
+				t.Errorf("got (%q, %t) want (%q, %t)", path, found, c.expected, c.found)
+			}
+		})
+	}
+}
+
+func TestExtensionDefaultVersion(t *testing.T) {
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	testutils.MustCreateDir(t, filepath.Join(gphome, "share"))
+	testutils.MustCreateDir(t, filepath.Join(gphome, "share", "postgresql"))
+	testutils.MustCreateDir(t, greenplum.ExtensionDir(gphome))
+	testutils.MustWriteToFile(t, filepath.Join(greenplum.ExtensionDir(gphome), "hstore.control"),
+		"# hstore extension\ncomment = 'data type for storing sets of (key, value) pairs'\ndefault_version = '1.3'\nmodule_pathname = '$libdir/hstore'\n")
+
+	t.Run("returns the default version from the control file", func(t *testing.T) {
+		version, err := greenplum.ExtensionDefaultVersion(gphome, "hstore")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if version != "1.3" {
+			t.Errorf("got version %q want %q", version, "1.3")
+		}
+	})
+
+	t.Run("returns a not exist error when the control file is missing", func(t *testing.T) {
+		_, err := greenplum.ExtensionDefaultVersion(gphome, "postgis")
+		if !os.IsNotExist(err) {
+			t.Errorf("got error %#v want not exist", err)
+		}
+	})
+
+	t.Run("checks extension control files", func(t *testing.T) {
+		reply, err := greenplum.CheckExtensionFiles(gphome, []string{"hstore", "postgis"})
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := &idl.CheckExtensionsReply{
+			Extensions: []*idl.CheckExtensionsReply_Extension{
+				{Name: "hstore", ControlFileFound: true, DefaultVersion: "1.3"},
+				{Name: "postgis", ControlFileFound: false},
+			},
+		}
+		if !reflect.DeepEqual(reply, expected) {
+			t.Errorf("got %v want %v", reply, expected)
+		}
+	})
+}
+
+func TestResolveLibraries(t *testing.T) {
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	testutils.MustCreateDir(t, filepath.Join(gphome, "lib"))
+	testutils.MustCreateDir(t, greenplum.PkgLibDir(gphome))
+	testutils.MustWriteToFile(t, filepath.Join(greenplum.PkgLibDir(gphome), "gp_array_agg.so"), "")
+
+	libraries := greenplum.ResolveLibraries(gphome, "$libdir", []string{"$libdir/gp_array_agg", "mylib"})
+
+	expected := []*idl.ResolveLibrariesReply_Library{
+		{Name: "$libdir/gp_array_agg", Path: filepath.Join(greenplum.PkgLibDir(gphome), "gp_array_agg.so"), Found: true},
+		{Name: "mylib", Path: filepath.Join(greenplum.PkgLibDir(gphome), "mylib"), Found: false},
+	}
+	if !reflect.DeepEqual(libraries, expected) {
+		t.Errorf("got %v want %v", libraries, expected)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// DefaultReadyTimeout is how long to wait for the segments of a cluster to be
+// up, in their preferred roles, and synchronized.
+const DefaultReadyTimeout = 5 * time.Minute
+
+// SegmentHealth is the state of a segment from gp_segment_configuration.
+type SegmentHealth struct {
+	DbID          int
+	ContentID     int
+	Hostname      string
+	Role          string
+	PreferredRole string
+	Mode          string
+	Status        string
+}
+
+// UnhealthySegments are segments that are down, not synchronized, or not in
+// their preferred role.
+type UnhealthySegments []SegmentHealth
+
+// String formats the segments as a table.
+func (u UnhealthySegments) String() string {
+	var b strings.Builder
+
+	var t tabwriter.Writer
+	t.Init(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(&t, "Host\tContent\tDbID\tRole\tPreferred Role\tMode\tStatus\t")
+	for _, seg := range u {
+		fmt.Fprintf(&t, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", seg.Hostname, strconv.Itoa(seg.ContentID), strconv.Itoa(seg.DbID),
+			describeRole(seg.Role), describeRole(seg.PreferredRole), describeMode(seg.Mode), describeStatus(seg.Status))
+	}
+
+	t.Flush()
+	return b.String()
+}
+
+// NotInPreferredRole returns true when any segment has switched roles, which
+// can be resolved by rebalancing the cluster.
+func (u UnhealthySegments) NotInPreferredRole() bool {
+	for _, seg := range u {
+		if seg.Role != seg.PreferredRole {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describeRole(role string) string {
+	switch role {
+	case PrimaryRole:
+		return "primary"
+	case MirrorRole:
+		return "mirror"
+	}
+
+	return role
+}
+
+func describeMode(mode string) string {
+	switch mode {
+	case "s":
+		return "synchronized"
+	case "n":
+		return "not synchronized"
+	}
+
+	return mode
+}
+
+func describeStatus(status string) string {
+	switch status {
+	case "u":
+		return "up"
+	case "d":
+		return "down"
+	}
+
+	return status
+}
+
+// UnhealthySegmentsFromDB returns the segments that are down, not in their
+// preferred role, or not synchronized with their mirror. The standby is
+// included when it is not streaming from the master.
+func UnhealthySegmentsFromDB(db *sql.DB, cluster *Cluster) (UnhealthySegments, error) {
+	rows, err := db.Query(`SELECT dbid, content, hostname, role, preferred_role, mode, status
+FROM gp_segment_configuration
+ORDER BY content, dbid;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying gp_segment_configuration: %w", err)
+	}
+	defer rows.Close()
+
+	var segments []SegmentHealth
+	for rows.Next() {
+		var seg SegmentHealth
+		if err := rows.Scan(&seg.DbID, &seg.ContentID, &seg.Hostname, &seg.Role, &seg.PreferredRole, &seg.Mode, &seg.Status); err != nil {
+			return nil, xerrors.Errorf("scanning gp_segment_configuration: %w", err)
+		}
+
+		segments = append(segments, seg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating gp_segment_configuration: %w", err)
+	}
+
+	standbyStreaming, err := isStandbyStreaming(db, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var unhealthy UnhealthySegments
+	for _, seg := range segments {
+		if seg.ContentID == -1 {
+			if seg.Role == MirrorRole && !standbyStreaming {
+				seg.Mode = "n"
+				unhealthy = append(unhealthy, seg)
+			}
+
+			continue
+		}
+
+		if seg.Status != "u" || seg.Role != seg.PreferredRole || (cluster.HasMirrors() && seg.Mode != "s") {
+			unhealthy = append(unhealthy, seg)
+		}
+	}
+
+	return unhealthy, nil
+}
+
+// isStandbyStreaming returns true when the standby is streaming and has
+// flushed all WAL sent to it. Clusters without a standby and GPDB 5 which lacks
+// gp_stat_replication are treated as streaming. GPDB 7 renamed the *_location
+// columns of gp_stat_replication to *_lsn.
+func isStandbyStreaming(db *sql.DB, cluster *Cluster) (bool, error) {
+	if cluster.Version.Major == 5 || !cluster.HasStandby() {
+		return true, nil
+	}
+
+	sent, flush := "sent_location", "flush_location"
+	if cluster.Version.Major >= 7 {
+		sent, flush = "sent_lsn", "flush_lsn"
+	}
+
+	var count int
+	row := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM gp_stat_replication WHERE gp_segment_id = -1 AND state = 'streaming' AND %s = %s;", sent, flush))
+	if err := row.Scan(&count); err != nil {
+		return false, xerrors.Errorf("querying gp_stat_replication: %w", err)
+	}
+
+	return count == 1, nil
+}
+
+// ClusterNotReadyError is returned when the segments are not ready before the
+// timeout, and lists the segments that were still unhealthy.
+type ClusterNotReadyError struct {
+	Timeout  time.Duration
+	Segments UnhealthySegments
+}
+
+func (e ClusterNotReadyError) Error() string {
+	msg := fmt.Sprintf("%s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized.", e.Timeout)
+	if len(e.Segments) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf("%s\n\n%s", msg, e.Segments)
+}
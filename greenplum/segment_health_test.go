@@ -0,0 +1,98 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestUnhealthySegmentsFromDB(t *testing.T) {
+	cluster := MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Port: 16432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Port: 25435, Role: greenplum.PrimaryRole},
+		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
+	})
+	cluster.Version = semver.MustParse("6.0.0")
+
+	columns := []string{"dbid", "content", "hostname", "role", "preferred_role", "mode", "status"}
+
+	t.Run("returns nothing for a healthy cluster", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(`SELECT dbid, content, hostname, role, preferred_role, mode, status`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, -1, "master", "p", "p", "s", "u").
+				AddRow(2, -1, "standby", "m", "m", "s", "u").
+				AddRow(3, 0, "sdw1", "p", "p", "s", "u").
+				AddRow(4, 0, "sdw2", "m", "m", "s", "u"))
+		expectGpStatReplicationToReturn(mock, 1)
+
+		segments, err := greenplum.UnhealthySegmentsFromDB(db, cluster)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(segments) != 0 {
+			t.Errorf("got unhealthy segments %+v want none", segments)
+		}
+	})
+
+	t.Run("returns down, switched, unsynchronized segments and an unsynchronized standby", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(`SELECT dbid, content, hostname, role, preferred_role, mode, status`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, -1, "master", "p", "p", "s", "u").
+				AddRow(2, -1, "standby", "m", "m", "s", "u").
+				AddRow(3, 0, "sdw1", "m", "p", "n", "d").
+				AddRow(4, 0, "sdw2", "p", "m", "n", "u").
+				AddRow(5, 1, "sdw2", "p", "p", "s", "u").
+				AddRow(6, 1, "sdw1", "m", "m", "s", "u"))
+		expectGpStatReplicationToReturn(mock, 0)
+
+		segments, err := greenplum.UnhealthySegmentsFromDB(db, cluster)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := greenplum.UnhealthySegments{
+			{DbID: 2, ContentID: -1, Hostname: "standby", Role: "m", PreferredRole: "m", Mode: "n", Status: "u"},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", Role: "m", PreferredRole: "p", Mode: "n", Status: "d"},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", Role: "p", PreferredRole: "m", Mode: "n", Status: "u"},
+		}
+		if !reflect.DeepEqual(segments, expected) {
+			t.Errorf("got %+v want %+v", segments, expected)
+		}
+
+		if !segments.NotInPreferredRole() {
+			t.Errorf("expected segments to not be in their preferred role")
+		}
+
+		table := segments.String()
+		for _, row := range []string{"sdw1     0        3     mirror   primary         not synchronized  down", "Preferred Role"} {
+			if !strings.Contains(table, row) {
+				t.Errorf("expected table %q to contain %q", table, row)
+			}
+		}
+	})
+}
@@ -10,13 +10,17 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/blang/semver/v4"
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
-const tablespacesQuery = `
+// tablespacesQuery5X lists the tablespaces of each segment from the
+// filespaces of GPDB 5. User defined tablespace locations include the
+// tablespace oid.
+const tablespacesQuery5X = `
 	SELECT
 		fsedbid as dbid,
 		upgrade_tablespace.oid as oid,
@@ -34,6 +38,25 @@ const tablespacesQuery = `
 			ON fsefsoid = spcfsoid
 		) upgrade_tablespace`
 
+// tablespacesQuery6X lists the tablespaces of each segment for GPDB 6 which
+// removed filespaces. The system tablespaces are located in the data
+// directory, and user defined tablespaces at the location given to CREATE
+// TABLESPACE which may differ per content. Mirrors share the location of their
+// primary. gp_tablespace_location() dispatches to the segments, so the query
+// cannot run in utility mode.
+const tablespacesQuery6X = `
+	SELECT c.dbid, t.oid, t.spcname, c.datadir, 0
+	FROM pg_tablespace t
+	CROSS JOIN gp_segment_configuration c
+	WHERE t.spcname IN ('pg_default', 'pg_global')
+	UNION ALL
+	SELECT c.dbid, t.oid, t.spcname, l.tblspc_loc, 1
+	FROM pg_tablespace t
+	CROSS JOIN LATERAL gp_tablespace_location(t.oid) l
+	JOIN gp_segment_configuration c ON c.content = l.gp_segment_id
+	WHERE t.spcname NOT IN ('pg_default', 'pg_global')
+	ORDER BY 1, 2`
+
 // map<tablespaceOid, tablespaceInfo>
 type SegmentTablespaces map[int]TablespaceInfo
 
@@ -84,8 +107,13 @@ func GetMasterTablespaceLocation(basePath string, oid int) string {
 	return filepath.Join(basePath, strconv.Itoa(oid), strconv.Itoa(MasterDbid))
 }
 
-func GetTablespaceTuples(db *sql.DB) (TablespaceTuples, error) {
-	rows, err := db.Query(tablespacesQuery)
+func GetTablespaceTuples(db *sql.DB, version semver.Version) (TablespaceTuples, error) {
+	query := tablespacesQuery5X
+	if version.Major >= 6 {
+		query = tablespacesQuery6X
+	}
+
+	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -150,8 +178,8 @@ func (t TablespaceTuples) Write(w io.Writer) error {
 // 1. query the database to get tablespace information
 // 2. write the tablespace information to a file
 // 3. converts the tablespace information to an internal structure
-func TablespacesFromDB(db *sql.DB, tablespacesFile string) (Tablespaces, error) {
-	tablespaceTuples, err := GetTablespaceTuples(db)
+func TablespacesFromDB(db *sql.DB, tablespacesFile string, version semver.Version) (Tablespaces, error) {
+	tablespaceTuples, err := GetTablespaceTuples(db, version)
 	if err != nil {
 		return nil, xerrors.Errorf("retrieve tablespace information: %w", err)
 	}
@@ -32,9 +32,9 @@ func TestGetTablespaces(t *testing.T) {
 		rows.AddRow(1, 1234, "pg_default", "/tmp/pg_default_tablespace", 0)
 		rows.AddRow(2, 1235, "my_tablespace", "/tmp/my_tablespace", 1)
 
-		mock.ExpectQuery("SELECT").WillReturnRows(rows)
+		mock.ExpectQuery("SELECT .* pg_filespace_entry").WillReturnRows(rows)
 
-		actual, err := greenplum.GetTablespaceTuples(db)
+		actual, err := greenplum.GetTablespaceTuples(db, semver.MustParse("5.28.0"))
 		if err != nil {
 			t.Errorf("returned error %+v", err)
 		}
@@ -59,6 +59,37 @@ func TestGetTablespaces(t *testing.T) {
 		}
 	})
 
+	t.Run("retrieves 6X tablespaces from gp_tablespace_location", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"dbid", "oid", "spcname", "location", "userdefined"})
+		rows.AddRow(1, 1663, "pg_default", "/data/qddir/demoDataDir-1", 0)
+		rows.AddRow(1, 16385, "my_tablespace", "/tmp/my_tablespace", 1)
+		rows.AddRow(2, 16385, "my_tablespace", "/tmp/my_tablespace", 1)
+
+		mock.ExpectQuery("SELECT .* gp_tablespace_location").WillReturnRows(rows)
+
+		actual, err := greenplum.GetTablespaceTuples(db, semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+
+		expected := greenplum.TablespaceTuples{
+			{DbId: 1, Oid: 1663, Name: "pg_default", Info: greenplum.TablespaceInfo{Location: "/data/qddir/demoDataDir-1", UserDefined: 0}},
+			{DbId: 1, Oid: 16385, Name: "my_tablespace", Info: greenplum.TablespaceInfo{Location: "/tmp/my_tablespace", UserDefined: 1}},
+			{DbId: 2, Oid: 16385, Name: "my_tablespace", Info: greenplum.TablespaceInfo{Location: "/tmp/my_tablespace", UserDefined: 1}},
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got configuration %+v, want %+v", actual, expected)
+		}
+	})
+
 	// error cases
 	expectedErr := errors.New("tablespace query")
 
@@ -90,7 +121,7 @@ func TestGetTablespaces(t *testing.T) {
 
 			mock.ExpectQuery("SELECT").WillReturnError(c.error)
 
-			tuples, err := greenplum.GetTablespaceTuples(db)
+			tuples, err := greenplum.GetTablespaceTuples(db, c.version)
 			if !errors.Is(err, c.error) {
 				t.Errorf("returned %#v want %#v", err, c.error)
 			}
@@ -239,7 +270,7 @@ func TestTablespacesFromDB(t *testing.T) {
 		expected := errors.New("connection failed")
 		mock.ExpectQuery("SELECT").WillReturnError(expected)
 
-		tablespaces, err := greenplum.TablespacesFromDB(db, "")
+		tablespaces, err := greenplum.TablespacesFromDB(db, "", semver.MustParse("5.28.0"))
 		if !errors.Is(err, expected) {
 			t.Errorf("got %#v want %#v", err, expected)
 		}
@@ -259,7 +290,7 @@ func TestTablespacesFromDB(t *testing.T) {
 		expected := errors.New("failed to get tablespace information")
 		mock.ExpectQuery("SELECT .* upgrade_tablespace").WillReturnError(expected)
 
-		tablespaces, err := greenplum.TablespacesFromDB(db, "")
+		tablespaces, err := greenplum.TablespacesFromDB(db, "", semver.MustParse("5.28.0"))
 
 		if err == nil {
 			t.Errorf("Expected an error, but got nil")
@@ -292,7 +323,7 @@ func TestTablespacesFromDB(t *testing.T) {
 		defer write.Close()
 
 		expectedFileName := "/tmp/mappingFile.txt"
-		tablespaces, err := greenplum.TablespacesFromDB(db, expectedFileName)
+		tablespaces, err := greenplum.TablespacesFromDB(db, expectedFileName, semver.MustParse("5.28.0"))
 
 		if err != nil {
 			t.Errorf("got unexpected error: %+v", err)
@@ -345,7 +376,7 @@ func TestTablespacesFromDB(t *testing.T) {
 			createCalled = true
 			return nil, expectedError
 		}
-		_, err = greenplum.TablespacesFromDB(db, expectedFileName)
+		_, err = greenplum.TablespacesFromDB(db, expectedFileName, semver.MustParse("5.28.0"))
 
 		if err == nil {
 			t.Errorf("expected error: %+v", expectedError)
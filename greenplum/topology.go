@@ -0,0 +1,131 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TopologyDifferences describes how the current segments differ from saved.
+// Segments are matched by dbid so that failovers, segments recovered to other
+// hosts or directories, and added or removed segments are all reported.
+func TopologyDifferences(saved SegConfigs, current SegConfigs) []string {
+	savedSegs := segmentsByDbID(saved)
+	currentSegs := segmentsByDbID(current)
+
+	var differences []string
+	for _, dbid := range sortedDbIDs(savedSegs, currentSegs) {
+		s, inSaved := savedSegs[dbid]
+		c, inCurrent := currentSegs[dbid]
+
+		switch {
+		case !inCurrent:
+			differences = append(differences, fmt.Sprintf("dbid %d: removed %s", dbid, describeSegment(s)))
+		case !inSaved:
+			differences = append(differences, fmt.Sprintf("dbid %d: added %s", dbid, describeSegment(c)))
+		case s != c:
+			differences = append(differences, fmt.Sprintf("dbid %d: changed from %s to %s", dbid, describeSegment(s), describeSegment(c)))
+		}
+	}
+
+	return differences
+}
+
+// TablespaceDifferences describes how the tablespaces of current differ from
+// saved.
+func TablespaceDifferences(saved Tablespaces, current Tablespaces) []string {
+	dbids := make(map[int]bool)
+	for dbid := range saved {
+		dbids[dbid] = true
+	}
+	for dbid := range current {
+		dbids[dbid] = true
+	}
+
+	var sorted []int
+	for dbid := range dbids {
+		sorted = append(sorted, dbid)
+	}
+	sort.Ints(sorted)
+
+	var differences []string
+	for _, dbid := range sorted {
+		oids := make(map[int]bool)
+		for oid := range saved[dbid] {
+			oids[oid] = true
+		}
+		for oid := range current[dbid] {
+			oids[oid] = true
+		}
+
+		var sortedOids []int
+		for oid := range oids {
+			sortedOids = append(sortedOids, oid)
+		}
+		sort.Ints(sortedOids)
+
+		for _, oid := range sortedOids {
+			s, inSaved := saved[dbid][oid]
+			c, inCurrent := current[dbid][oid]
+
+			switch {
+			case !inCurrent:
+				differences = append(differences, fmt.Sprintf("dbid %d: removed tablespace %d at %q", dbid, oid, s.Location))
+			case !inSaved:
+				differences = append(differences, fmt.Sprintf("dbid %d: added tablespace %d at %q", dbid, oid, c.Location))
+			case s != c:
+				differences = append(differences, fmt.Sprintf("dbid %d: tablespace %d moved from %q to %q", dbid, oid, s.Location, c.Location))
+			}
+		}
+	}
+
+	return differences
+}
+
+func segmentsByDbID(segConfigs SegConfigs) map[int]SegConfig {
+	segments := make(map[int]SegConfig)
+	for _, seg := range segConfigs {
+		segments[seg.DbID] = seg
+	}
+
+	return segments
+}
+
+func sortedDbIDs(segmentMaps ...map[int]SegConfig) []int {
+	dbids := make(map[int]bool)
+	for _, segments := range segmentMaps {
+		for dbid := range segments {
+			dbids[dbid] = true
+		}
+	}
+
+	var sorted []int
+	for dbid := range dbids {
+		sorted = append(sorted, dbid)
+	}
+	sort.Ints(sorted)
+
+	return sorted
+}
+
+func describeSegment(seg SegConfig) string {
+	return fmt.Sprintf("content %d %s on %s:%d at %q", seg.ContentID, describeRole(seg.Role), seg.Hostname, seg.Port, seg.DataDir)
+}
+
+// TopologyChangedError is returned when the cluster no longer matches the
+// configuration saved during initialize.
+type TopologyChangedError struct {
+	Differences []string
+}
+
+func (e TopologyChangedError) Error() string {
+	return fmt.Sprintf(`The cluster configuration has changed since initialize:
+%s
+
+gpupgrade cannot continue with a stale configuration. Restore the original
+configuration, for example by running "gprecoverseg -r" to rebalance segments
+to their preferred roles, or revert and rerun initialize.`, strings.Join(e.Differences, "\n"))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+func TestTopologyDifferences(t *testing.T) {
+	saved := greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25432, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25433, Role: greenplum.MirrorRole},
+	}
+
+	t.Run("returns nothing when the topology is unchanged", func(t *testing.T) {
+		current := append(greenplum.SegConfigs{}, saved...)
+
+		differences := greenplum.TopologyDifferences(saved, current)
+		if len(differences) != 0 {
+			t.Errorf("got differences %q want none", differences)
+		}
+	})
+
+	t.Run("reports failovers, moved, added, and removed segments", func(t *testing.T) {
+		current := greenplum.SegConfigs{
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25432, Role: greenplum.MirrorRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw3", DataDir: "/data/dbfast_mirror1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 1, Hostname: "sdw3", DataDir: "/data/dbfast1/seg2", Port: 25434, Role: greenplum.PrimaryRole},
+		}
+
+		differences := greenplum.TopologyDifferences(saved, current)
+
+		expected := []string{
+			`dbid 1: removed content -1 primary on mdw:15432 at "/data/qddir/seg-1"`,
+			`dbid 2: changed from content 0 primary on sdw1:25432 at "/data/dbfast1/seg1" to content 0 mirror on sdw1:25432 at "/data/dbfast1/seg1"`,
+			`dbid 3: changed from content 0 mirror on sdw2:25433 at "/data/dbfast_mirror1/seg1" to content 0 primary on sdw3:25433 at "/data/dbfast_mirror1/seg1"`,
+			`dbid 4: added content 1 primary on sdw3:25434 at "/data/dbfast1/seg2"`,
+		}
+		if !reflect.DeepEqual(differences, expected) {
+			t.Errorf("got differences %q want %q", differences, expected)
+		}
+	})
+}
+
+func TestTablespaceDifferences(t *testing.T) {
+	saved := greenplum.Tablespaces{
+		1: {16384: {Location: "/tmp/tblspc1/16384", UserDefined: 1}},
+		2: {16384: {Location: "/tmp/tblspc2/16384", UserDefined: 1}},
+	}
+
+	t.Run("returns nothing when the tablespaces are unchanged", func(t *testing.T) {
+		current := greenplum.Tablespaces{
+			1: {16384: {Location: "/tmp/tblspc1/16384", UserDefined: 1}},
+			2: {16384: {Location: "/tmp/tblspc2/16384", UserDefined: 1}},
+		}
+
+		differences := greenplum.TablespaceDifferences(saved, current)
+		if len(differences) != 0 {
+			t.Errorf("got differences %q want none", differences)
+		}
+	})
+
+	t.Run("reports moved, added, and removed tablespaces", func(t *testing.T) {
+		current := greenplum.Tablespaces{
+			1: {
+				16384: {Location: "/tmp/tblspc1/16384", UserDefined: 1},
+				16385: {Location: "/tmp/tblspc3/16385", UserDefined: 1},
+			},
+			3: {16384: {Location: "/tmp/tblspc4/16384", UserDefined: 1}},
+		}
+
+		differences := greenplum.TablespaceDifferences(saved, current)
+
+		expected := []string{
+			`dbid 1: added tablespace 16385 at "/tmp/tblspc3/16385"`,
+			`dbid 2: removed tablespace 16384 at "/tmp/tblspc2/16384"`,
+			`dbid 3: added tablespace 16384 at "/tmp/tblspc4/16384"`,
+		}
+		if !reflect.DeepEqual(differences, expected) {
+			t.Errorf("got differences %q want %q", differences, expected)
+		}
+	})
+}
+
+func TestTopologyChangedError(t *testing.T) {
+	err := greenplum.TopologyChangedError{Differences: []string{"dbid 1: removed", "dbid 2: added"}}
+	if !strings.Contains(err.Error(), "dbid 1: removed\ndbid 2: added") {
+		t.Errorf("got error %q want it to list the differences", err)
+	}
+}
@@ -31,16 +31,11 @@ func ResetVersionCommand() {
 }
 
 func Version(gphome string) (string, error) {
-	cmd := versionCommand(filepath.Join(gphome, "bin", "postgres"), "--gp-version")
-	cmd.Env = []string{}
-
-	gplog.Debug(cmd.String())
-	output, err := cmd.CombinedOutput()
+	rawVersion, err := versionOutput(gphome)
 	if err != nil {
-		return "", fmt.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+		return "", err
 	}
 
-	rawVersion := string(output)
 	parts := strings.SplitN(strings.TrimSpace(rawVersion), "postgres (Greenplum Database) ", 2)
 	if len(parts) != 2 {
 		return "", xerrors.Errorf(`Greenplum version %q is not of the form "postgres (Greenplum Database) #.#.#"`, rawVersion)
@@ -59,3 +54,47 @@ func Version(gphome string) (string, error) {
 
 	return version.String(), nil
 }
+
+// FullVersion returns the complete "postgres (Greenplum Database) ..." line
+// including the build information, which identifies an installation more
+// precisely than the semantic version returned by Version.
+func FullVersion(gphome string) (string, error) {
+	rawVersion, err := versionOutput(gphome)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(rawVersion), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "postgres (Greenplum Database) ") {
+			return line, nil
+		}
+	}
+
+	return "", xerrors.Errorf(`Greenplum version %q is not of the form "postgres (Greenplum Database) #.#.#"`, rawVersion)
+}
+
+func versionOutput(gphome string) (string, error) {
+	cmd := versionCommand(filepath.Join(gphome, "bin", "postgres"), "--gp-version")
+	cmd.Env = []string{}
+
+	gplog.Debug(cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+	}
+
+	return string(output), nil
+}
+
+// MasterDataDirectoryEnv returns the environment variable the management
+// utilities read the master data directory from. Starting with GPDB 7 the
+// master is called the coordinator.
+func MasterDataDirectoryEnv(version semver.Version) string {
+	if version.Major >= 7 {
+		return "COORDINATOR_DATA_DIRECTORY"
+	}
+
+	return "MASTER_DATA_DIRECTORY"
+}
@@ -10,6 +10,8 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/blang/semver/v4"
+
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 )
@@ -133,3 +135,63 @@ func TestVersion_Parsing(t *testing.T) {
 		}
 	})
 }
+
+func TestFullVersion(t *testing.T) {
+	testlog.SetupLogger()
+
+	cases := []struct {
+		name           string
+		versionCommand exectest.Main
+		expected       string
+	}{
+		{name: "returns the build information", versionCommand: PostgresGPVersion_6_7_1, expected: "postgres (Greenplum Database) 6.7.1 build commit:a21de286045072d8d1df64fa48752b7dfac8c1b7"},
+		{name: "ignores warnings before the version", versionCommand: PostgresGPVersion_MultiLine, expected: "postgres (Greenplum Database) 6.18.2 build commit:1242aadf0137d3b26ee42c80e579e78bd7a805c7"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			SetVersionCommand(exectest.NewCommand(c.versionCommand))
+			defer ResetVersionCommand()
+
+			version, err := FullVersion("")
+			if err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+
+			if version != c.expected {
+				t.Errorf("got version %q, want %q", version, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when the output has no version", func(t *testing.T) {
+		SetVersionCommand(exectest.NewCommand(EmptyString))
+		defer ResetVersionCommand()
+
+		_, err := FullVersion("")
+		expected := `Greenplum version "\n" is not of the form "postgres (Greenplum Database) #.#.#"`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got %v want %q", err, expected)
+		}
+	})
+}
+
+func TestMasterDataDirectoryEnv(t *testing.T) {
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "5.29.1", expected: "MASTER_DATA_DIRECTORY"},
+		{version: "6.20.0", expected: "MASTER_DATA_DIRECTORY"},
+		{version: "7.0.0", expected: "COORDINATOR_DATA_DIRECTORY"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			env := MasterDataDirectoryEnv(semver.MustParse(c.version))
+			if env != c.expected {
+				t.Errorf("got %q want %q", env, c.expected)
+			}
+		})
+	}
+}
@@ -14,6 +14,16 @@ import (
 )
 
 func addMirrorsToCatalog(conn *greenplum.Conn, intermediate *greenplum.Cluster) error {
+	return modifyCatalog(conn, intermediate, AddMirrorsToGpSegmentConfiguration)
+}
+
+func addStandbyToCatalog(conn *greenplum.Conn, intermediate *greenplum.Cluster) error {
+	return modifyCatalog(conn, intermediate, AddStandbyToGpSegmentConfiguration)
+}
+
+// modifyCatalog runs update against the master of the intermediate cluster,
+// which must be started in utility mode.
+func modifyCatalog(conn *greenplum.Conn, intermediate *greenplum.Cluster, update func(*sql.DB, *greenplum.Cluster) error) (err error) {
 	options := []greenplum.Option{
 		greenplum.ToTarget(),
 		greenplum.Port(intermediate.MasterPort()),
@@ -31,7 +41,7 @@ func addMirrorsToCatalog(conn *greenplum.Conn, intermediate *greenplum.Cluster)
 		}
 	}()
 
-	return AddMirrorsToGpSegmentConfiguration(db, intermediate)
+	return update(db, intermediate)
 }
 
 func AddMirrorsToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Cluster) (err error) {
@@ -44,7 +54,7 @@ func AddMirrorsToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Clus
 	}()
 
 	for _, seg := range intermediate.Mirrors.ExcludingStandby() {
-		if err := addSegment(tx, seg); err != nil {
+		if err := addSegment(tx, seg, "n"); err != nil {
 			return err
 		}
 	}
@@ -52,10 +62,24 @@ func AddMirrorsToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Clus
 	return nil
 }
 
-func addSegment(tx *sql.Tx, seg greenplum.SegConfig) error {
+// AddStandbyToGpSegmentConfiguration adds the standby as in sync, as
+// gpinitstandby does, since FTS does not probe the standby to update its mode.
+func AddStandbyToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Cluster) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return xerrors.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		err = commitOrRollback(tx, err)
+	}()
+
+	return addSegment(tx, intermediate.Standby(), "s")
+}
+
+func addSegment(tx *sql.Tx, seg greenplum.SegConfig, mode string) error {
 	result, err := tx.Exec("INSERT INTO gp_segment_configuration "+
 		"(dbid, content, role, preferred_role, mode, status, port, hostname, address, datadir) "+
-		"VALUES($1, $2, $3, $4, 'n', 'u', $5, $6, $7, $8);", seg.DbID, seg.ContentID, seg.Role, seg.Role, seg.Port, seg.Hostname, seg.Hostname, seg.DataDir)
+		"VALUES($1, $2, $3, $4, $5, 'u', $6, $7, $8, $9);", seg.DbID, seg.ContentID, seg.Role, seg.Role, mode, seg.Port, seg.Hostname, seg.Hostname, seg.DataDir)
 	if err != nil {
 		return xerrors.Errorf("insert into gp_segment_configuration: %w", err)
 	}
@@ -161,9 +161,59 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 	}
 }
 
+func TestAddStandbyToCatalog(t *testing.T) {
+	target := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+	})
+
+	t.Run("adds the standby in sync", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		expectAddSegmentWithMode(mock, target.Standby(), "s").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = hub.AddStandbyToGpSegmentConfiguration(db, target)
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+	})
+
+	t.Run("rolls back when the insert fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("permission denied")
+		mock.ExpectBegin()
+		expectAddSegmentWithMode(mock, target.Standby(), "s").WillReturnError(expected)
+		mock.ExpectRollback()
+
+		err = hub.AddStandbyToGpSegmentConfiguration(db, target)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
 func expectAddSegment(mock sqlmock.Sqlmock, seg greenplum.SegConfig) *sqlmock.ExpectedExec {
+	return expectAddSegmentWithMode(mock, seg, "n")
+}
+
+func expectAddSegmentWithMode(mock sqlmock.Sqlmock, seg greenplum.SegConfig, mode string) *sqlmock.ExpectedExec {
 	return mock.ExpectExec("INSERT INTO gp_segment_configuration "+
 		"\\(dbid, content, role, preferred_role, mode, status, port, hostname, address, datadir\\) "+
-		"VALUES\\((.+), (.+), (.+), (.+), 'n', 'u', (.+), (.+), (.+), (.+)\\);").
-		WithArgs(seg.DbID, seg.ContentID, seg.Role, seg.Role, seg.Port, seg.Hostname, seg.Hostname, seg.DataDir)
+		"VALUES\\((.+), (.+), (.+), (.+), (.+), 'u', (.+), (.+), (.+), (.+)\\);").
+		WithArgs(seg.DbID, seg.ContentID, seg.Role, seg.Role, mode, seg.Port, seg.Hostname, seg.Hostname, seg.DataDir)
 }
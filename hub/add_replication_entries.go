@@ -5,11 +5,16 @@ package hub
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
 func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
@@ -54,6 +59,52 @@ func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate
 	return ExecuteRPC(agentConns, request)
 }
 
+// AddReplicationEntriesOnMaster allows the standby to replicate from the
+// master. The master is on the hub host, so its pg_hba.conf is written
+// directly.
+func AddReplicationEntriesOnMaster(intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+	user, err := utils.System.Current()
+	if err != nil {
+		return err
+	}
+
+	standbyHostAddrs := []string{intermediate.StandbyHostname()}
+	if useHbaHostnames {
+		err, standbyIps := getIpAddresses(intermediate.StandbyHostname())
+		if err != nil {
+			return err
+		}
+
+		standbyHostAddrs = standbyIps
+	}
+
+	return AppendReplicationEntries(intermediate.MasterDataDir(), user.Username, standbyHostAddrs)
+}
+
+// AppendReplicationEntries appends pg_hba.conf entries to dataDir allowing
+// user to connect and replicate from the given host addresses.
+func AppendReplicationEntries(dataDir string, user string, hostAddrs []string) (err error) {
+	var lines strings.Builder
+	lines.WriteString(fmt.Sprintf("host replication %s samehost trust\n", user))
+	for _, hostAddr := range hostAddrs {
+		lines.WriteString(fmt.Sprintf("host all %s %s trust\n", user, hostAddr))
+		lines.WriteString(fmt.Sprintf("host replication %s %s trust\n", user, hostAddr))
+	}
+
+	file, err := os.OpenFile(filepath.Join(dataDir, PgHbaConf), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := file.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	_, err = file.WriteString(lines.String())
+	return err
+}
+
 // getIpAddresses returns a list of ip addresses with CIDR notation for use in
 // pg_hba.conf.
 func getIpAddresses(host string) (error, []string) {
@@ -6,7 +6,9 @@ package hub_test
 import (
 	"errors"
 	"net"
+	"os"
 	"os/user"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -16,6 +18,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
@@ -218,3 +221,77 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 		}
 	})
 }
+
+func TestAddReplicationEntriesOnMaster(t *testing.T) {
+	masterDir := testutils.GetTempDir(t, "master")
+	defer testutils.MustRemoveAll(t, masterDir)
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: masterDir, Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+	})
+
+	utils.System.Current = func() (*user.User, error) {
+		return &user.User{Username: "gpadmin"}, nil
+	}
+	defer func() {
+		utils.System.Current = user.Current
+	}()
+
+	hbaPath := filepath.Join(masterDir, hub.PgHbaConf)
+
+	t.Run("appends entries for the standby hostname", func(t *testing.T) {
+		testutils.MustWriteToFile(t, hbaPath, "local all gpadmin ident\n")
+
+		err := hub.AddReplicationEntriesOnMaster(intermediate, false)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `local all gpadmin ident
+host replication gpadmin samehost trust
+host all gpadmin standby trust
+host replication gpadmin standby trust
+`
+		contents := testutils.MustReadFile(t, hbaPath)
+		if contents != expected {
+			t.Errorf("got %q want %q", contents, expected)
+		}
+	})
+
+	t.Run("appends entries for the standby addresses when useHbaHostnames is true", func(t *testing.T) {
+		testutils.MustWriteToFile(t, hbaPath, "")
+
+		utils.System.LookupIP = func(host string) ([]net.IP, error) {
+			return []net.IP{net.ParseIP("10.0.0.2")}, nil
+		}
+		defer func() {
+			utils.System.LookupIP = net.LookupIP
+		}()
+
+		err := hub.AddReplicationEntriesOnMaster(intermediate, true)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `host replication gpadmin samehost trust
+host all gpadmin 10.0.0.2/32 trust
+host replication gpadmin 10.0.0.2/32 trust
+`
+		contents := testutils.MustReadFile(t, hbaPath)
+		if contents != expected {
+			t.Errorf("got %q want %q", contents, expected)
+		}
+	})
+
+	t.Run("errors when pg_hba.conf does not exist", func(t *testing.T) {
+		emptyDir := testutils.GetTempDir(t, "empty")
+		defer testutils.MustRemoveAll(t, emptyDir)
+
+		err := hub.AppendReplicationEntries(emptyDir, "gpadmin", []string{"standby"})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+}
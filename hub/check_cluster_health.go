@@ -0,0 +1,68 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// ReadyTimeout returns how long to wait for a cluster to be ready. Configs
+// saved before the timeout was configurable use the default.
+func (c *Config) ReadyTimeout() time.Duration {
+	if c.ClusterReadyTimeout <= 0 {
+		return greenplum.DefaultReadyTimeout
+	}
+
+	return c.ClusterReadyTimeout
+}
+
+// CheckSourceClusterHealth immediately reports any source segments that are
+// down, not synchronized, or not in their preferred role rather than silently
+// waiting on them. When rebalance is set and segments are not in their
+// preferred role gprecoverseg -r is run. It then waits until the timeout for
+// the cluster to be ready.
+func CheckSourceClusterHealth(streams step.OutStreams, conn *greenplum.Conn, source *greenplum.Cluster, timeout time.Duration, rebalance bool) (err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToSource(), greenplum.Port(source.MasterPort())))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return CheckClusterHealth(streams, db, source, timeout, rebalance)
+}
+
+// CheckClusterHealth is CheckSourceClusterHealth for an open connection to
+// any cluster.
+func CheckClusterHealth(streams step.OutStreams, db *sql.DB, cluster *greenplum.Cluster, timeout time.Duration, rebalance bool) error {
+	unhealthy, err := greenplum.UnhealthySegmentsFromDB(db, cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(unhealthy) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(streams.Stdout(), "found %d segments that are down, not synchronized, or not in their preferred role:\n%s\n", len(unhealthy), unhealthy)
+
+	if rebalance && unhealthy.NotInPreferredRole() {
+		fmt.Fprintln(streams.Stdout(), "rebalancing segments to their preferred roles")
+		if err := cluster.RunGreenplumCmd(streams, "gprecoverseg", "-a", "-r"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(streams.Stdout(), "waiting up to %s for the segments to be ready\n", timeout)
+	return greenplum.WaitForSegments(db, timeout, cluster)
+}
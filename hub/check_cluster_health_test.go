@@ -0,0 +1,134 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestCheckClusterHealth(t *testing.T) {
+	testlog.SetupLogger()
+
+	cluster := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+	})
+	cluster.Version = semver.MustParse("5.28.0")
+	cluster.GPHome = "/usr/local/greenplum-db"
+
+	columns := []string{"dbid", "content", "hostname", "role", "preferred_role", "mode", "status"}
+	healthy := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT dbid, content, hostname, role, preferred_role, mode, status`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, -1, "master", "p", "p", "s", "u").
+				AddRow(2, 0, "sdw1", "p", "p", "s", "u").
+				AddRow(3, 0, "sdw2", "m", "m", "s", "u"))
+	}
+	switched := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT dbid, content, hostname, role, preferred_role, mode, status`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, -1, "master", "p", "p", "s", "u").
+				AddRow(2, 0, "sdw1", "m", "p", "s", "u").
+				AddRow(3, 0, "sdw2", "p", "m", "s", "u"))
+	}
+	readyCount := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM gp_segment_configuration`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	t.Run("returns immediately for a healthy cluster", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		healthy(mock)
+
+		err = hub.CheckClusterHealth(step.DevNullStream, db, cluster, time.Minute, false)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("rebalances segments not in their preferred role", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		switched(mock)
+		readyCount(mock, 2)
+
+		called := false
+		greenplum.SetGreenplumCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			called = true
+			expected := "source /usr/local/greenplum-db/greenplum_path.sh && /usr/local/greenplum-db/bin/gprecoverseg -a -r"
+			if len(args) != 2 || args[1] != expected {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer greenplum.ResetGreenplumCommand()
+
+		err = hub.CheckClusterHealth(step.DevNullStream, db, cluster, time.Minute, true)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		if !called {
+			t.Errorf("expected gprecoverseg to be called")
+		}
+	})
+
+	t.Run("reports the unhealthy segments when the timeout is exceeded", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		switched(mock)
+		readyCount(mock, 0)
+		switched(mock)
+
+		greenplum.SetGreenplumCommand(exectest.NewCommand(hub.Failure))
+		defer greenplum.ResetGreenplumCommand()
+
+		err = hub.CheckClusterHealth(step.DevNullStream, db, cluster, -1*time.Second, false)
+		var notReady greenplum.ClusterNotReadyError
+		if !errors.As(err, &notReady) {
+			t.Fatalf("got error %#v want %T", err, notReady)
+		}
+
+		if len(notReady.Segments) != 2 {
+			t.Errorf("got segments %+v want 2", notReady.Segments)
+		}
+	})
+}
+
+func TestReadyTimeout(t *testing.T) {
+	config := &hub.Config{}
+	if config.ReadyTimeout() != greenplum.DefaultReadyTimeout {
+		t.Errorf("got %s want %s", config.ReadyTimeout(), greenplum.DefaultReadyTimeout)
+	}
+
+	config.ClusterReadyTimeout = time.Minute
+	if config.ReadyTimeout() != time.Minute {
+		t.Errorf("got %s want %s", config.ReadyTimeout(), time.Minute)
+	}
+}
@@ -11,12 +11,35 @@ import (
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/disk"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
 var checkDiskUsage = disk.CheckUsage
 
+// DiskSpaceCheckEnabled returns whether disk space is checked during
+// initialize and execute.
+func (c *Config) DiskSpaceCheckEnabled() bool {
+	return c.EstimateDiskSpace || c.DiskFreeRatio > 0
+}
+
+// checkDiskSpace checks disk space using the requirement chosen during
+// initialize, either estimated from the size of the source cluster for the
+// upgrade mode or a fixed free ratio.
+func (s *Server) checkDiskSpace(streams step.OutStreams) error {
+	if s.EstimateDiskSpace {
+		logDir, err := utils.GetLogDir()
+		if err != nil {
+			return err
+		}
+
+		return EstimateDiskSpace(streams, s.agentConns, s.Source, s.Source.Tablespaces, s.UseLinkMode, s.StateDir, logDir)
+	}
+
+	return CheckDiskSpace(streams, s.agentConns, s.DiskFreeRatio, s.Source, s.Source.Tablespaces)
+}
+
 func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(agentConns)+1)
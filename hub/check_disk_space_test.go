@@ -317,3 +317,23 @@ func (r reqCheckDiskMatcher) Matches(x interface{}) bool {
 func (r reqCheckDiskMatcher) String() string {
 	return fmt.Sprintf("is equivalent to %v", r.expected)
 }
+
+func TestDiskSpaceCheckEnabled(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   hub.Config
+		expected bool
+	}{
+		{"estimating disk space", hub.Config{EstimateDiskSpace: true}, true},
+		{"using a disk free ratio", hub.Config{DiskFreeRatio: 0.6}, true},
+		{"disabled with a zero disk free ratio", hub.Config{DiskFreeRatio: 0}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.config.DiskSpaceCheckEnabled(); actual != c.expected {
+				t.Errorf("got %t want %t", actual, c.expected)
+			}
+		})
+	}
+}
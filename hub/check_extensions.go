@@ -0,0 +1,305 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// ExtensionUsage is an extension installed in a source database.
+type ExtensionUsage struct {
+	Database string
+	Name     string
+	Version  string
+}
+
+// LanguageUsage is a procedural language installed in a source database along
+// with the shared library of its call handler.
+type LanguageUsage struct {
+	Database string
+	Name     string
+	Library  string
+}
+
+// CheckExtensions verifies that the extensions and procedural languages of
+// every source database are available in the target installation on the
+// master and every segment host. Extensions need control files, and languages
+// need the library of their call handler to resolve with dynamicLibraryPath.
+// Extension version differences are reported as warnings. The shared libraries
+// of the extensions are verified by CheckLibraries.
+func CheckExtensions(streams step.OutStreams, conn *greenplum.Conn, agentConns []*idl.Connection, source *greenplum.Cluster, targetGPHome string, dynamicLibraryPath string) error {
+	databases, err := listDatabases(conn, greenplum.ToSource(), greenplum.Port(source.MasterPort()))
+	if err != nil {
+		return err
+	}
+
+	var usages []ExtensionUsage
+	var languages []LanguageUsage
+	for _, database := range databases {
+		dbUsages, dbLanguages, err := extensionUsage(conn, source, database)
+		if err != nil {
+			return xerrors.Errorf("database %q: %w", database, err)
+		}
+
+		usages = append(usages, dbUsages...)
+		languages = append(languages, dbLanguages...)
+	}
+
+	replies, err := checkExtensionFilesOnAllHosts(agentConns, source.MasterHostname(), targetGPHome, extensionNames(usages))
+	if err != nil {
+		return err
+	}
+
+	libraryReplies, err := resolveLibrariesOnAllHosts(agentConns, source.MasterHostname(), targetGPHome, dynamicLibraryPath, languageLibraries(languages))
+	if err != nil {
+		return err
+	}
+
+	missing, warnings := CompareExtensionsToTarget(usages, replies)
+	missing = append(missing, CompareLanguagesToTarget(languages, libraryReplies)...)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(streams.Stdout(), "WARNING: %s\n", warning)
+	}
+
+	for _, m := range missing {
+		fmt.Fprintf(streams.Stdout(), "MISSING: %s\n", m)
+	}
+
+	if len(missing) > 0 {
+		return xerrors.Errorf("found %d extensions or languages missing from the target installation %q:\n%s",
+			len(missing), targetGPHome, strings.Join(missing, "\n"))
+	}
+
+	return nil
+}
+
+func checkExtensionFilesOnAllHosts(agentConns []*idl.Connection, masterHost string, gphome string, extensions []string) ([]*idl.CheckExtensionsReply, error) {
+	var mu sync.Mutex
+	var replies []*idl.CheckExtensionsReply
+
+	local := func() error {
+		reply, err := greenplum.CheckExtensionFiles(gphome, extensions)
+		if err != nil {
+			return err
+		}
+
+		reply.Hostname, err = os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		replies = append(replies, reply)
+		return nil
+	}
+
+	request := func(conn *idl.Connection) error {
+		reply, err := conn.AgentClient.CheckExtensions(context.Background(), &idl.CheckExtensionsRequest{
+			Gphome:     gphome,
+			Extensions: extensions,
+		})
+		if err != nil {
+			return xerrors.Errorf("checking extensions on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		replies = append(replies, reply)
+		return nil
+	}
+
+	err := ExecuteOnMasterAndAgents(agentConns, masterHost, local, request)
+	return replies, err
+}
+
+// CompareExtensionsToTarget returns the extensions of the source databases
+// whose control files are missing on any target host, and warnings for
+// extensions whose installed version differs from the target's default
+// version.
+func CompareExtensionsToTarget(usages []ExtensionUsage, replies []*idl.CheckExtensionsReply) (missing []string, warnings []string) {
+	missingControlFiles := make(map[string][]string)
+	defaultVersions := make(map[string]string)
+
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].GetHostname() < replies[j].GetHostname()
+	})
+
+	for _, reply := range replies {
+		for _, ext := range reply.GetExtensions() {
+			if !ext.GetControlFileFound() {
+				missingControlFiles[ext.GetName()] = append(missingControlFiles[ext.GetName()], reply.GetHostname())
+				continue
+			}
+
+			defaultVersions[ext.GetName()] = ext.GetDefaultVersion()
+		}
+	}
+
+	for _, usage := range usages {
+		if hosts, ok := missingControlFiles[usage.Name]; ok {
+			missing = append(missing, fmt.Sprintf("database %q: extension %q version %s has no control file on hosts %s",
+				usage.Database, usage.Name, usage.Version, strings.Join(hosts, ", ")))
+		}
+
+		if version, ok := defaultVersions[usage.Name]; ok && version != usage.Version {
+			warnings = append(warnings, fmt.Sprintf("database %q: extension %q is version %s on the source and version %s on the target",
+				usage.Database, usage.Name, usage.Version, version))
+		}
+	}
+
+	return missing, warnings
+}
+
+// CompareLanguagesToTarget returns the procedural languages of the source
+// databases whose call handler library is missing on any target host.
+func CompareLanguagesToTarget(languages []LanguageUsage, replies []*idl.ResolveLibrariesReply) []string {
+	missingLibraries := make(map[string][]string)
+
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].GetHostname() < replies[j].GetHostname()
+	})
+
+	for _, reply := range replies {
+		for _, library := range reply.GetLibraries() {
+			if !library.GetFound() {
+				missingLibraries[library.GetName()] = append(missingLibraries[library.GetName()], reply.GetHostname())
+			}
+		}
+	}
+
+	var missing []string
+	for _, language := range languages {
+		if hosts, ok := missingLibraries[language.Library]; ok {
+			missing = append(missing, fmt.Sprintf("database %q: language %q requires handler library %q which is missing on hosts %s",
+				language.Database, language.Name, language.Library, strings.Join(hosts, ", ")))
+		}
+	}
+
+	return missing
+}
+
+func languageLibraries(languages []LanguageUsage) []string {
+	seen := make(map[string]bool)
+
+	var libraries []string
+	for _, language := range languages {
+		if !seen[language.Library] {
+			seen[language.Library] = true
+			libraries = append(libraries, language.Library)
+		}
+	}
+
+	sort.Strings(libraries)
+	return libraries
+}
+
+func extensionNames(usages []ExtensionUsage) []string {
+	seen := make(map[string]bool)
+
+	var extensions []string
+	for _, usage := range usages {
+		if !seen[usage.Name] {
+			seen[usage.Name] = true
+			extensions = append(extensions, usage.Name)
+		}
+	}
+
+	sort.Strings(extensions)
+	return extensions
+}
+
+func extensionUsage(conn *greenplum.Conn, source *greenplum.Cluster, database string) (usages []ExtensionUsage, languages []LanguageUsage, err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToSource(), greenplum.Port(source.MasterPort()), greenplum.Database(database)))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	usages, err = ExtensionUsageFromDB(db, database)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	languages, err = LanguageUsageFromDB(db, database)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return usages, languages, nil
+}
+
+// ExtensionUsageFromDB returns the extensions of a database. Both 5X and 6X
+// sources have extensions such as plcontainer, pgcrypto and PostGIS.
+func ExtensionUsageFromDB(db *sql.DB, database string) ([]ExtensionUsage, error) {
+	rows, err := db.Query(`SELECT extname, extversion FROM pg_extension ORDER BY 1;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying extensions: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []ExtensionUsage
+	for rows.Next() {
+		usage := ExtensionUsage{Database: database}
+		if err := rows.Scan(&usage.Name, &usage.Version); err != nil {
+			return nil, xerrors.Errorf("scanning extensions: %w", err)
+		}
+
+		usages = append(usages, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating extensions: %w", err)
+	}
+
+	return usages, nil
+}
+
+// LanguageUsageFromDB returns the procedural languages of a database along with
+// the shared library of their call handler. Internal languages such as c, sql
+// and internal are not procedural languages and are skipped.
+func LanguageUsageFromDB(db *sql.DB, database string) ([]LanguageUsage, error) {
+	rows, err := db.Query(`SELECT l.lanname, p.probin
+FROM pg_language l
+    JOIN pg_proc p ON p.oid = l.lanplcallfoid
+WHERE l.lanispl
+    AND p.probin IS NOT NULL
+ORDER BY 1;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying languages: %w", err)
+	}
+	defer rows.Close()
+
+	var languages []LanguageUsage
+	for rows.Next() {
+		language := LanguageUsage{Database: database}
+		if err := rows.Scan(&language.Name, &language.Library); err != nil {
+			return nil, xerrors.Errorf("scanning languages: %w", err)
+		}
+
+		languages = append(languages, language)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating languages: %w", err)
+	}
+
+	return languages, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestExtensionUsageFromDB(t *testing.T) {
+	t.Run("returns extensions with their versions", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT extname, extversion FROM pg_extension ORDER BY 1;`).
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion"}).
+				AddRow("hstore", "1.2").
+				AddRow("plpgsql", "1.0").
+				AddRow("postgis", "2.1.5"))
+
+		usages, err := hub.ExtensionUsageFromDB(db, "postgres")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []hub.ExtensionUsage{
+			{Database: "postgres", Name: "hstore", Version: "1.2"},
+			{Database: "postgres", Name: "plpgsql", Version: "1.0"},
+			{Database: "postgres", Name: "postgis", Version: "2.1.5"},
+		}
+		if !reflect.DeepEqual(usages, expected) {
+			t.Errorf("got %+v want %+v", usages, expected)
+		}
+	})
+
+	t.Run("returns the extensions of 5X databases", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT extname, extversion FROM pg_extension ORDER BY 1;`).
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion"}).
+				AddRow("pgcrypto", "1.1").
+				AddRow("plcontainer", "1.0.0"))
+
+		usages, err := hub.ExtensionUsageFromDB(db, "postgres")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []hub.ExtensionUsage{
+			{Database: "postgres", Name: "pgcrypto", Version: "1.1"},
+			{Database: "postgres", Name: "plcontainer", Version: "1.0.0"},
+		}
+		if !reflect.DeepEqual(usages, expected) {
+			t.Errorf("got %+v want %+v", usages, expected)
+		}
+	})
+}
+
+func TestCompareExtensionsToTarget(t *testing.T) {
+	usages := []hub.ExtensionUsage{
+		{Database: "postgres", Name: "hstore", Version: "1.2"},
+		{Database: "gis", Name: "postgis", Version: "2.1.5"},
+	}
+
+	t.Run("reports nothing when all extensions are present", func(t *testing.T) {
+		replies := []*idl.CheckExtensionsReply{{
+			Hostname: "mdw",
+			Extensions: []*idl.CheckExtensionsReply_Extension{
+				{Name: "hstore", ControlFileFound: true, DefaultVersion: "1.2"},
+				{Name: "postgis", ControlFileFound: true, DefaultVersion: "2.1.5"},
+			},
+		}}
+
+		missing, warnings := hub.CompareExtensionsToTarget(usages, replies)
+		if len(missing) != 0 || len(warnings) != 0 {
+			t.Errorf("got missing %q and warnings %q, want none", missing, warnings)
+		}
+	})
+
+	t.Run("reports missing control files per host and version differences", func(t *testing.T) {
+		replies := []*idl.CheckExtensionsReply{
+			{
+				Hostname: "sdw1",
+				Extensions: []*idl.CheckExtensionsReply_Extension{
+					{Name: "hstore", ControlFileFound: true, DefaultVersion: "1.3"},
+					{Name: "postgis", ControlFileFound: false},
+				},
+			},
+			{
+				Hostname: "mdw",
+				Extensions: []*idl.CheckExtensionsReply_Extension{
+					{Name: "hstore", ControlFileFound: true, DefaultVersion: "1.3"},
+					{Name: "postgis", ControlFileFound: false},
+				},
+			},
+		}
+
+		missing, warnings := hub.CompareExtensionsToTarget(usages, replies)
+
+		expectedMissing := []string{
+			`database "gis": extension "postgis" version 2.1.5 has no control file on hosts mdw, sdw1`,
+		}
+		if !reflect.DeepEqual(missing, expectedMissing) {
+			t.Errorf("got missing %q want %q", missing, expectedMissing)
+		}
+
+		expectedWarnings := []string{
+			`database "postgres": extension "hstore" is version 1.2 on the source and version 1.3 on the target`,
+		}
+		if !reflect.DeepEqual(warnings, expectedWarnings) {
+			t.Errorf("got warnings %q want %q", warnings, expectedWarnings)
+		}
+	})
+}
+
+func TestLanguageUsageFromDB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT l.lanname, p.probin FROM pg_language l JOIN pg_proc p ON p.oid = l.lanplcallfoid WHERE l.lanispl AND p.probin IS NOT NULL ORDER BY 1;`).
+		WillReturnRows(sqlmock.NewRows([]string{"lanname", "probin"}).
+			AddRow("plcontainer", "$libdir/plcontainer").
+			AddRow("plpgsql", "$libdir/plpgsql").
+			AddRow("plpythonu", "$libdir/plpython2"))
+
+	languages, err := hub.LanguageUsageFromDB(db, "postgres")
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := []hub.LanguageUsage{
+		{Database: "postgres", Name: "plcontainer", Library: "$libdir/plcontainer"},
+		{Database: "postgres", Name: "plpgsql", Library: "$libdir/plpgsql"},
+		{Database: "postgres", Name: "plpythonu", Library: "$libdir/plpython2"},
+	}
+	if !reflect.DeepEqual(languages, expected) {
+		t.Errorf("got %+v want %+v", languages, expected)
+	}
+}
+
+func TestCompareLanguagesToTarget(t *testing.T) {
+	languages := []hub.LanguageUsage{
+		{Database: "postgres", Name: "plpgsql", Library: "$libdir/plpgsql"},
+		{Database: "analytics", Name: "plpythonu", Library: "$libdir/plpython2"},
+	}
+
+	t.Run("reports nothing when all handler libraries resolve", func(t *testing.T) {
+		replies := []*idl.ResolveLibrariesReply{{
+			Hostname: "mdw",
+			Libraries: []*idl.ResolveLibrariesReply_Library{
+				{Name: "$libdir/plpgsql", Found: true},
+				{Name: "$libdir/plpython2", Found: true},
+			},
+		}}
+
+		missing := hub.CompareLanguagesToTarget(languages, replies)
+		if len(missing) != 0 {
+			t.Errorf("got missing %q, want none", missing)
+		}
+	})
+
+	t.Run("reports missing handler libraries per database and host", func(t *testing.T) {
+		replies := []*idl.ResolveLibrariesReply{
+			{
+				Hostname: "sdw1",
+				Libraries: []*idl.ResolveLibrariesReply_Library{
+					{Name: "$libdir/plpgsql", Found: true},
+					{Name: "$libdir/plpython2", Found: false},
+				},
+			},
+			{
+				Hostname: "mdw",
+				Libraries: []*idl.ResolveLibrariesReply_Library{
+					{Name: "$libdir/plpgsql", Found: true},
+					{Name: "$libdir/plpython2", Found: false},
+				},
+			},
+		}
+
+		missing := hub.CompareLanguagesToTarget(languages, replies)
+
+		expected := []string{
+			`database "analytics": language "plpythonu" requires handler library "$libdir/plpython2" which is missing on hosts mdw, sdw1`,
+		}
+		if !reflect.DeepEqual(missing, expected) {
+			t.Errorf("got missing %q want %q", missing, expected)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+var checkFilesystems = disk.CheckFilesystems
+
+// CheckLinkModeFilesystems verifies that each target data directory and
+// tablespace of the master and primaries will be created on the same
+// filesystem as its source, since link mode hard links the source files into
+// the target.
+func CheckLinkModeFilesystems(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) error {
+	pairs := LinkModeDirPairs(source, intermediate, sourceTablespaces)
+
+	var mu sync.Mutex
+	var replies []*idl.CheckFilesystemsReply
+
+	local := func() error {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		devices, err := checkFilesystems(disk.Local, pairs[source.MasterHostname()]...)
+		if err != nil {
+			return err
+		}
+
+		replies = append(replies, &idl.CheckFilesystemsReply{Hostname: hostname, Devices: devices})
+		return nil
+	}
+
+	request := func(conn *idl.Connection) error {
+		if len(pairs[conn.Hostname]) == 0 {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.CheckFilesystems(context.Background(), &idl.CheckFilesystemsRequest{
+			Pairs: pairs[conn.Hostname],
+		})
+		if err != nil {
+			return xerrors.Errorf("checking filesystems on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		replies = append(replies, reply)
+		return nil
+	}
+
+	err := ExecuteOnMasterAndAgents(agentConns, source.MasterHostname(), local, request)
+	if err != nil {
+		return err
+	}
+
+	mismatches := FilesystemMismatches(replies)
+	if len(mismatches) > 0 {
+		return xerrors.Errorf(`link mode requires the target data directories and tablespaces to be on the same filesystem as the source, but found %d on different filesystems:
+%s
+Use copy mode, or ensure the parent directory of each target is not a separate mount.`,
+			len(mismatches), strings.Join(mismatches, "\n"))
+	}
+
+	return nil
+}
+
+// LinkModeDirPairs returns the source and target data directories and
+// tablespaces of the master and primaries keyed by hostname. Mirrors and the
+// standby are not hard linked, so they are not included.
+func LinkModeDirPairs(source *greenplum.Cluster, intermediate *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) map[string][]*idl.CheckFilesystemsRequest_DirPair {
+	var contents []int
+	for content := range source.Primaries {
+		contents = append(contents, content)
+	}
+	sort.Ints(contents)
+
+	pairs := make(map[string][]*idl.CheckFilesystemsRequest_DirPair)
+	for _, content := range contents {
+		sourceSeg := source.Primaries[content]
+		targetSeg, ok := intermediate.Primaries[content]
+		if !ok {
+			continue
+		}
+
+		pairs[sourceSeg.Hostname] = append(pairs[sourceSeg.Hostname], &idl.CheckFilesystemsRequest_DirPair{
+			Dbid:   int32(sourceSeg.DbID),
+			Source: sourceSeg.DataDir,
+			Target: targetSeg.DataDir,
+		})
+
+		var oids []int
+		for oid, tsInfo := range sourceTablespaces[sourceSeg.DbID] {
+			if tsInfo.IsUserDefined() {
+				oids = append(oids, oid)
+			}
+		}
+		sort.Ints(oids)
+
+		for _, oid := range oids {
+			tsInfo := sourceTablespaces[sourceSeg.DbID][oid]
+			pairs[sourceSeg.Hostname] = append(pairs[sourceSeg.Hostname], &idl.CheckFilesystemsRequest_DirPair{
+				Dbid:   int32(sourceSeg.DbID),
+				Source: segmentTablespaceDir(source.Version, tsInfo, sourceSeg.DbID),
+				Target: targetTablespaceDir(tsInfo.Location, targetSeg.DbID, intermediate.Version),
+			})
+		}
+	}
+
+	return pairs
+}
+
+// targetTablespaceDir returns the directory pg_upgrade creates for a target
+// tablespace, <location>/<dbid>/GPDB_<major>_<catalog>. The catalog version is
+// not known until the target cluster is created, but only the closest existing
+// parent of the target is stat'ed, so a wildcard is used in its place.
+func targetTablespaceDir(location string, dbid int, version semver.Version) string {
+	return upgrade.TablespacePath(location, dbid, version.Major, "*")
+}
+
+// FilesystemMismatches returns a description of each source and target
+// directory that are on different filesystems.
+func FilesystemMismatches(replies []*idl.CheckFilesystemsReply) []string {
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].GetHostname() < replies[j].GetHostname()
+	})
+
+	var mismatches []string
+	for _, reply := range replies {
+		for _, devices := range reply.GetDevices() {
+			if devices.GetSourceDevice() == devices.GetTargetDevice() {
+				continue
+			}
+
+			mismatches = append(mismatches, fmt.Sprintf("host %q dbid %d: source %q is on device %d but target %q would be on device %d",
+				reply.GetHostname(), devices.GetDbid(), devices.GetSource(), devices.GetSourceDevice(), devices.GetTarget(), devices.GetTargetDevice()))
+		}
+	}
+
+	return mismatches
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	sigar "github.com/cloudfoundry/gosigar"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/sys/unix"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestLinkModeDirPairs(t *testing.T) {
+	t.Run("pairs 5X tablespaces with the target dbid directory within them", func(t *testing.T) {
+		source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: -1, Hostname: "smdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		})
+
+		intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg.AAAAAAAAAAA.1", Role: greenplum.PrimaryRole},
+		})
+
+		source.Version = semver.MustParse("5.28.0")
+		intermediate.Version = semver.MustParse("6.20.0")
+
+		pairs := hub.LinkModeDirPairs(source, intermediate, testutils.CreateTablespaces())
+
+		expected := map[string][]*idl.CheckFilesystemsRequest_DirPair{
+			"mdw": {
+				{Dbid: 1, Source: "/data/qddir/seg-1", Target: "/data/qddir/seg.AAAAAAAAAAA.-1"},
+				{Dbid: 1, Source: "/tmp/user_ts/m/qddir/16384", Target: "/tmp/user_ts/m/qddir/16384/1/GPDB_6_*"},
+			},
+			"sdw1": {
+				{Dbid: 3, Source: "/data/dbfast/seg1", Target: "/data/dbfast/seg.AAAAAAAAAAA.1"},
+				{Dbid: 3, Source: "/tmp/user_ts/p1/16384", Target: "/tmp/user_ts/p1/16384/2/GPDB_6_*"},
+			},
+		}
+		if !reflect.DeepEqual(pairs, expected) {
+			t.Errorf("got %v want %v", pairs, expected)
+		}
+	})
+
+	t.Run("pairs the dbid directory of 6X tablespaces with the target directory", func(t *testing.T) {
+		source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Role: greenplum.PrimaryRole},
+		})
+		source.Version = semver.MustParse("6.20.0")
+
+		intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg.AAAAAAAAAAA.1", Role: greenplum.PrimaryRole},
+		})
+		intermediate.Version = semver.MustParse("7.0.0")
+
+		tablespaces := greenplum.Tablespaces{
+			1: {16384: {Location: "/tblspc", UserDefined: 1}},
+			2: {16384: {Location: "/tblspc", UserDefined: 1}},
+		}
+
+		pairs := hub.LinkModeDirPairs(source, intermediate, tablespaces)
+
+		expected := map[string][]*idl.CheckFilesystemsRequest_DirPair{
+			"mdw": {
+				{Dbid: 1, Source: "/data/qddir/seg-1", Target: "/data/qddir/seg.AAAAAAAAAAA.-1"},
+				{Dbid: 1, Source: "/tblspc/1", Target: "/tblspc/1/GPDB_7_*"},
+			},
+			"sdw1": {
+				{Dbid: 2, Source: "/data/dbfast/seg1", Target: "/data/dbfast/seg.AAAAAAAAAAA.1"},
+				{Dbid: 2, Source: "/tblspc/2", Target: "/tblspc/2/GPDB_7_*"},
+			},
+		}
+		if !reflect.DeepEqual(pairs, expected) {
+			t.Errorf("got %v want %v", pairs, expected)
+		}
+	})
+}
+
+func TestCheckLinkModeFilesystems(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Role: greenplum.PrimaryRole},
+	})
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/bind/dbfast/seg.AAAAAAAAAAA.1", Role: greenplum.PrimaryRole},
+	})
+
+	hub.SetCheckFilesystems(func(d disk.Disk, pairs ...*idl.CheckFilesystemsRequest_DirPair) ([]*idl.CheckFilesystemsReply_Devices, error) {
+		var devices []*idl.CheckFilesystemsReply_Devices
+		for _, pair := range pairs {
+			devices = append(devices, &idl.CheckFilesystemsReply_Devices{Dbid: pair.GetDbid(), Source: pair.GetSource(), Target: pair.GetTarget(), SourceDevice: 1, TargetDevice: 1})
+		}
+		return devices, nil
+	})
+	defer hub.ResetCheckFilesystems()
+
+	t.Run("succeeds when all directories share a filesystem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckFilesystems(
+			gomock.Any(),
+			&idl.CheckFilesystemsRequest{Pairs: []*idl.CheckFilesystemsRequest_DirPair{
+				{Dbid: 2, Source: "/data/dbfast/seg1", Target: "/bind/dbfast/seg.AAAAAAAAAAA.1"},
+			}},
+		).Return(&idl.CheckFilesystemsReply{Hostname: "sdw1", Devices: []*idl.CheckFilesystemsReply_Devices{
+			{Dbid: 2, Source: "/data/dbfast/seg1", Target: "/bind/dbfast/seg.AAAAAAAAAAA.1", SourceDevice: 2, TargetDevice: 2},
+		}}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CheckLinkModeFilesystems(agentConns, source, intermediate, greenplum.Tablespaces{})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors with each segment whose target is on a different filesystem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckFilesystems(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.CheckFilesystemsReply{Hostname: "sdw1", Devices: []*idl.CheckFilesystemsReply_Devices{
+			{Dbid: 2, Source: "/data/dbfast/seg1", Target: "/bind/dbfast/seg.AAAAAAAAAAA.1", SourceDevice: 2, TargetDevice: 3},
+		}}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CheckLinkModeFilesystems(agentConns, source, intermediate, greenplum.Tablespaces{})
+		expected := `host "sdw1" dbid 2: source "/data/dbfast/seg1" is on device 2 but target "/bind/dbfast/seg.AAAAAAAAAAA.1" would be on device 3`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", err, expected)
+		}
+	})
+
+	t.Run("errors when an agent fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckFilesystems(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.CheckLinkModeFilesystems(agentConns, source, intermediate, greenplum.Tablespaces{})
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestCheckLinkModeFilesystemsWithTablespaces(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+	})
+	source.Version = semver.MustParse("5.28.0")
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Role: greenplum.PrimaryRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+
+	tablespaces := greenplum.Tablespaces{
+		1: {16384: {Location: "/tblspc/qddir/16384", UserDefined: 1}},
+	}
+
+	// the data directories are on device 1 and the tablespace on device 2
+	devices := map[string]uint64{
+		"/data/qddir":         1,
+		"/data/qddir/seg-1":   1,
+		"/tblspc/qddir/16384": 2,
+	}
+
+	useDevices := func(devices map[string]uint64) {
+		hub.SetCheckFilesystems(func(_ disk.Disk, pairs ...*idl.CheckFilesystemsRequest_DirPair) ([]*idl.CheckFilesystemsReply_Devices, error) {
+			return disk.CheckFilesystems(statDisk(devices), pairs...)
+		})
+	}
+	defer hub.ResetCheckFilesystems()
+
+	t.Run("succeeds when the tablespace target is on the filesystem of the tablespace", func(t *testing.T) {
+		useDevices(devices)
+
+		err := hub.CheckLinkModeFilesystems(nil, source, intermediate, tablespaces)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("errors when the tablespace target is on a different filesystem than the tablespace", func(t *testing.T) {
+		mounted := map[string]uint64{"/tblspc/qddir/16384/1": 3}
+		for path, device := range devices {
+			mounted[path] = device
+		}
+		useDevices(mounted)
+
+		err := hub.CheckLinkModeFilesystems(nil, source, intermediate, tablespaces)
+		expected := `dbid 1: source "/tblspc/qddir/16384" is on device 2 but target "/tblspc/qddir/16384/1/GPDB_6_*" would be on device 3`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", err, expected)
+		}
+	})
+}
+
+// statDisk is a disk.Disk that only implements Stat, returning the device of
+// each path. Paths that are not listed do not exist.
+type statDisk map[string]uint64
+
+func (d statDisk) Filesystems() (sigar.FileSystemList, error) {
+	return sigar.FileSystemList{}, errors.New("not implemented")
+}
+
+func (d statDisk) Usage(string) (sigar.FileSystemUsage, error) {
+	return sigar.FileSystemUsage{}, errors.New("not implemented")
+}
+
+func (d statDisk) Stat(path string) (*unix.Stat_t, error) {
+	device, ok := d[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return &unix.Stat_t{Dev: device}, nil
+}
+
+func (d statDisk) DirUsage(string) (disk.DirUsage, error) {
+	return disk.DirUsage{}, errors.New("not implemented")
+}
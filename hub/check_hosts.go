@@ -0,0 +1,150 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const hostInventoryFileName = "host_inventory.json"
+
+// MinRsyncVersion is the oldest rsync supporting the options used to copy
+// the master and upgrade mirrors, such as --no-inc-recursive.
+const MinRsyncVersion = "3.0.0"
+
+var getHostInfo = GetHostInfo
+
+var rsyncVersionPattern = regexp.MustCompile(`^\d+(\.\d+)*`)
+
+// HostInventoryPath returns the location of the host inventory written by
+// CheckHosts.
+func HostInventoryPath(stateDir string) string {
+	return filepath.Join(stateDir, hostInventoryFileName)
+}
+
+// CheckHosts gathers the inventory of the master and every agent host, saves
+// it to the state directory for later reports, and verifies that each host has
+// the same target installation as the master and a compatible rsync.
+func CheckHosts(streams step.OutStreams, agentConns []*idl.Connection, masterHost string, sourceGPHome string, targetGPHome string, stateDir string) error {
+	master, err := getHostInfo(sourceGPHome, targetGPHome)
+	if err != nil {
+		return xerrors.Errorf("getting host info on master host: %w", err)
+	}
+
+	hosts, err := agentHostInfo(agentConns, masterHost, sourceGPHome, targetGPHome)
+	if err != nil {
+		return err
+	}
+
+	inventory := append([]*idl.HostInfo{master}, hosts...)
+	data, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := utils.AtomicallyWrite(HostInventoryPath(stateDir), data); err != nil {
+		return err
+	}
+
+	for _, info := range inventory {
+		fmt.Fprintf(streams.Stdout(), "host %q: %s, %s, target %q, rsync %s, %d MB free memory\n",
+			info.GetHostname(), info.GetOs(), info.GetKernel(), info.GetTargetVersion(), info.GetRsyncVersion(), info.GetFreeMemory()/1024)
+	}
+
+	problems := HostInventoryProblems(master, hosts, targetGPHome)
+	if len(problems) > 0 {
+		return xerrors.Errorf("found %d problems with the hosts:\n%s", len(problems), strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func agentHostInfo(agentConns []*idl.Connection, masterHost string, sourceGPHome string, targetGPHome string) ([]*idl.HostInfo, error) {
+	var mu sync.Mutex
+	var hosts []*idl.HostInfo
+
+	request := func(conn *idl.Connection) error {
+		if conn.Hostname == masterHost {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.GetHostInfo(context.Background(), &idl.GetHostInfoRequest{
+			SourceGPHome: sourceGPHome,
+			TargetGPHome: targetGPHome,
+		})
+		if err != nil {
+			return xerrors.Errorf("getting host info on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		hosts = append(hosts, reply.GetInfo())
+		return nil
+	}
+
+	err := ExecuteRPC(agentConns, request)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].GetHostname() < hosts[j].GetHostname()
+	})
+
+	return hosts, nil
+}
+
+// HostInventoryProblems returns a description of each host whose target
+// installation is missing or differs from the master, or whose rsync is
+// missing or older than MinRsyncVersion.
+func HostInventoryProblems(master *idl.HostInfo, hosts []*idl.HostInfo, targetGPHome string) []string {
+	var problems []string
+	for _, info := range append([]*idl.HostInfo{master}, hosts...) {
+		switch {
+		case info.GetTargetVersion() == "":
+			problems = append(problems, fmt.Sprintf("host %q: target installation %q not found", info.GetHostname(), targetGPHome))
+		case info != master && master.GetTargetVersion() != "" && info.GetTargetVersion() != master.GetTargetVersion():
+			problems = append(problems, fmt.Sprintf("host %q: target installation %q differs from the master host (%q versus %q)",
+				info.GetHostname(), targetGPHome, info.GetTargetVersion(), master.GetTargetVersion()))
+		}
+
+		if problem := rsyncProblem(info.GetRsyncVersion()); problem != "" {
+			problems = append(problems, fmt.Sprintf("host %q: %s", info.GetHostname(), problem))
+		}
+	}
+
+	return problems
+}
+
+func rsyncProblem(version string) string {
+	if version == "" {
+		return "rsync not found"
+	}
+
+	// ignore suffixes such as "dev" or "pre1" in development builds
+	v, err := semver.ParseTolerant(rsyncVersionPattern.FindString(version))
+	if err != nil {
+		return fmt.Sprintf("unable to parse rsync version %q", version)
+	}
+
+	if v.LT(semver.MustParse(MinRsyncVersion)) {
+		return fmt.Sprintf("rsync version %s is older than the minimum supported version %s", version, MinRsyncVersion)
+	}
+
+	return ""
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+const targetVersion = "postgres (Greenplum Database) 6.20.0 build commit:abc"
+
+func TestCheckHosts(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	master := &idl.HostInfo{Hostname: "mdw", TargetVersion: targetVersion, RsyncVersion: "3.1.2"}
+	hub.SetGetHostInfo(func(sourceGPHome, targetGPHome string) (*idl.HostInfo, error) {
+		return master, nil
+	})
+	defer hub.ResetGetHostInfo()
+
+	t.Run("saves the inventory of all hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := &idl.HostInfo{Hostname: "sdw1", TargetVersion: targetVersion, RsyncVersion: "3.1.3"}
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().GetHostInfo(
+			gomock.Any(),
+			&idl.GetHostInfoRequest{SourceGPHome: "/usr/local/gpdb5", TargetGPHome: "/usr/local/gpdb6"},
+		).Return(&idl.GetHostInfoReply{Info: sdw1}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "mdw"},
+			{AgentClient: client, Hostname: "sdw1"},
+		}
+
+		err := hub.CheckHosts(step.DevNullStream, agentConns, "mdw", "/usr/local/gpdb5", "/usr/local/gpdb6", stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		var inventory []*idl.HostInfo
+		if err := json.Unmarshal([]byte(testutils.MustReadFile(t, hub.HostInventoryPath(stateDir))), &inventory); err != nil {
+			t.Fatalf("unmarshalling inventory: %+v", err)
+		}
+
+		if len(inventory) != 2 || inventory[0].GetHostname() != "mdw" || inventory[1].GetHostname() != "sdw1" {
+			t.Errorf("got inventory %v want mdw and sdw1", inventory)
+		}
+	})
+
+	t.Run("reports hosts with a different target installation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().GetHostInfo(gomock.Any(), gomock.Any()).Return(&idl.GetHostInfoReply{Info: &idl.HostInfo{
+			Hostname:      "sdw1",
+			TargetVersion: "postgres (Greenplum Database) 6.19.0 build commit:def",
+			RsyncVersion:  "3.1.3",
+		}}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		err := hub.CheckHosts(step.DevNullStream, agentConns, "mdw", "/usr/local/gpdb5", "/usr/local/gpdb6", stateDir)
+		if err == nil || !strings.Contains(err.Error(), `host "sdw1": target installation "/usr/local/gpdb6" differs from the master host`) {
+			t.Errorf("got error %v want a target installation mismatch", err)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().GetHostInfo(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		err := hub.CheckHosts(step.DevNullStream, agentConns, "mdw", "/usr/local/gpdb5", "/usr/local/gpdb6", stateDir)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestHostInventoryProblems(t *testing.T) {
+	master := &idl.HostInfo{Hostname: "mdw", TargetVersion: targetVersion, RsyncVersion: "3.1.2"}
+
+	t.Run("returns no problems for matching hosts", func(t *testing.T) {
+		hosts := []*idl.HostInfo{
+			{Hostname: "sdw1", TargetVersion: targetVersion, RsyncVersion: "3.2.3"},
+			{Hostname: "sdw2", TargetVersion: targetVersion, RsyncVersion: "3.2.4dev"},
+		}
+
+		problems := hub.HostInventoryProblems(master, hosts, "/usr/local/gpdb6")
+		if len(problems) != 0 {
+			t.Errorf("unexpected problems %v", problems)
+		}
+	})
+
+	t.Run("reports missing and mismatched installations and incompatible rsync", func(t *testing.T) {
+		hosts := []*idl.HostInfo{
+			{Hostname: "sdw1", TargetVersion: "", RsyncVersion: "3.1.2"},
+			{Hostname: "sdw2", TargetVersion: "postgres (Greenplum Database) 6.19.0 build commit:def", RsyncVersion: "2.6.9"},
+			{Hostname: "sdw3", TargetVersion: targetVersion, RsyncVersion: ""},
+		}
+
+		problems := hub.HostInventoryProblems(master, hosts, "/usr/local/gpdb6")
+
+		expected := []string{
+			`host "sdw1": target installation "/usr/local/gpdb6" not found`,
+			`host "sdw2": target installation "/usr/local/gpdb6" differs from the master host ("postgres (Greenplum Database) 6.19.0 build commit:def" versus "postgres (Greenplum Database) 6.20.0 build commit:abc")`,
+			`host "sdw2": rsync version 2.6.9 is older than the minimum supported version 3.0.0`,
+			`host "sdw3": rsync not found`,
+		}
+		if !reflect.DeepEqual(problems, expected) {
+			t.Errorf("got problems %q want %q", problems, expected)
+		}
+	})
+}
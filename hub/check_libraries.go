@@ -0,0 +1,181 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// CheckLibraries verifies that the shared libraries of the user-defined C
+// functions in every source database resolve against the target installation
+// and dynamicLibraryPath on the master and every segment host. This includes
+// the functions of extensions. Procedural language call handlers are left to
+// CheckExtensions, which reports them along with their languages.
+func CheckLibraries(streams step.OutStreams, conn *greenplum.Conn, agentConns []*idl.Connection, source *greenplum.Cluster, targetGPHome string, dynamicLibraryPath string) error {
+	databases, err := listDatabases(conn, greenplum.ToSource(), greenplum.Port(source.MasterPort()))
+	if err != nil {
+		return err
+	}
+
+	// map each library to the databases using it
+	usedBy := make(map[string][]string)
+	for _, database := range databases {
+		libraries, err := sourceLibraries(conn, source, database)
+		if err != nil {
+			return xerrors.Errorf("database %q: %w", database, err)
+		}
+
+		for _, library := range libraries {
+			usedBy[library] = append(usedBy[library], database)
+		}
+	}
+
+	if len(usedBy) == 0 {
+		fmt.Fprintln(streams.Stdout(), "no user-defined C functions found")
+		return nil
+	}
+
+	var libraries []string
+	for library := range usedBy {
+		libraries = append(libraries, library)
+	}
+	sort.Strings(libraries)
+
+	replies, err := resolveLibrariesOnAllHosts(agentConns, source.MasterHostname(), targetGPHome, dynamicLibraryPath, libraries)
+	if err != nil {
+		return err
+	}
+
+	missing := MissingLibraries(usedBy, replies)
+	for _, m := range missing {
+		fmt.Fprintf(streams.Stdout(), "MISSING: %s\n", m)
+	}
+
+	if len(missing) > 0 {
+		return xerrors.Errorf("found %d libraries that do not resolve in the target installation %q with dynamic_library_path %q:\n%s",
+			len(missing), targetGPHome, dynamicLibraryPath, strings.Join(missing, "\n"))
+	}
+
+	fmt.Fprintf(streams.Stdout(), "all %d libraries resolved on all hosts\n", len(libraries))
+	return nil
+}
+
+func resolveLibrariesOnAllHosts(agentConns []*idl.Connection, masterHost string, gphome string, dynamicLibraryPath string, libraries []string) ([]*idl.ResolveLibrariesReply, error) {
+	var mu sync.Mutex
+	var replies []*idl.ResolveLibrariesReply
+
+	local := func() error {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		replies = append(replies, &idl.ResolveLibrariesReply{
+			Hostname:  hostname,
+			Libraries: greenplum.ResolveLibraries(gphome, dynamicLibraryPath, libraries),
+		})
+		return nil
+	}
+
+	request := func(conn *idl.Connection) error {
+		reply, err := conn.AgentClient.ResolveLibraries(context.Background(), &idl.ResolveLibrariesRequest{
+			Gphome:             gphome,
+			DynamicLibraryPath: dynamicLibraryPath,
+			Libraries:          libraries,
+		})
+		if err != nil {
+			return xerrors.Errorf("resolving libraries on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		replies = append(replies, reply)
+		return nil
+	}
+
+	err := ExecuteOnMasterAndAgents(agentConns, masterHost, local, request)
+	return replies, err
+}
+
+// MissingLibraries returns a description of each library that did not
+// resolve on a host, along with the databases using it.
+func MissingLibraries(usedBy map[string][]string, replies []*idl.ResolveLibrariesReply) []string {
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].GetHostname() < replies[j].GetHostname()
+	})
+
+	var missing []string
+	for _, reply := range replies {
+		for _, library := range reply.GetLibraries() {
+			if library.GetFound() {
+				continue
+			}
+
+			missing = append(missing, fmt.Sprintf("host %q: library %q used in databases %s was not found (expected %q)",
+				reply.GetHostname(), library.GetName(), strings.Join(usedBy[library.GetName()], ", "), library.GetPath()))
+		}
+	}
+
+	return missing
+}
+
+func sourceLibraries(conn *greenplum.Conn, source *greenplum.Cluster, database string) (libraries []string, err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToSource(), greenplum.Port(source.MasterPort()), greenplum.Database(database)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return LibrariesFromDB(db)
+}
+
+// LibrariesFromDB returns the distinct shared libraries of the user-defined C
+// functions in a database, other than procedural language call handlers.
+func LibrariesFromDB(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT DISTINCT p.probin
+FROM pg_proc p
+    JOIN pg_language l ON l.oid = p.prolang
+WHERE l.lanname = 'c'
+    AND p.oid >= 16384
+    AND p.probin IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM pg_language pl WHERE pl.lanplcallfoid = p.oid)
+ORDER BY 1;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying libraries: %w", err)
+	}
+	defer rows.Close()
+
+	var libraries []string
+	for rows.Next() {
+		var library string
+		if err := rows.Scan(&library); err != nil {
+			return nil, xerrors.Errorf("scanning libraries: %w", err)
+		}
+
+		libraries = append(libraries, library)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating libraries: %w", err)
+	}
+
+	return libraries, nil
+}
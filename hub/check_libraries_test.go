@@ -0,0 +1,92 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestLibrariesFromDB(t *testing.T) {
+	t.Run("returns the libraries of user-defined C functions other than language handlers", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT DISTINCT p.probin FROM pg_proc p .* WHERE l.lanname = 'c' .* AND NOT EXISTS \(SELECT 1 FROM pg_language pl WHERE pl.lanplcallfoid = p.oid\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"probin"}).
+				AddRow("$libdir/gp_array_agg").
+				AddRow("/usr/local/lib/mylib"))
+
+		libraries, err := hub.LibrariesFromDB(db)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{"$libdir/gp_array_agg", "/usr/local/lib/mylib"}
+		if !reflect.DeepEqual(libraries, expected) {
+			t.Errorf("got %q want %q", libraries, expected)
+		}
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("connection refused")
+		mock.ExpectQuery(`SELECT DISTINCT p.probin`).WillReturnError(expected)
+
+		_, err = hub.LibrariesFromDB(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestMissingLibraries(t *testing.T) {
+	usedBy := map[string][]string{
+		"$libdir/gp_array_agg": {"postgres"},
+		"mylib":                {"db1", "db2"},
+	}
+
+	replies := []*idl.ResolveLibrariesReply{
+		{
+			Hostname: "sdw1",
+			Libraries: []*idl.ResolveLibrariesReply_Library{
+				{Name: "$libdir/gp_array_agg", Path: "/gphome/lib/postgresql/gp_array_agg.so", Found: true},
+				{Name: "mylib", Path: "/gphome/lib/postgresql/mylib", Found: false},
+			},
+		},
+		{
+			Hostname: "mdw",
+			Libraries: []*idl.ResolveLibrariesReply_Library{
+				{Name: "$libdir/gp_array_agg", Path: "/gphome/lib/postgresql/gp_array_agg.so", Found: true},
+				{Name: "mylib", Path: "/ext/mylib.so", Found: true},
+			},
+		},
+	}
+
+	missing := hub.MissingLibraries(usedBy, replies)
+
+	expected := []string{
+		`host "sdw1": library "mylib" used in databases db1, db2 was not found (expected "/gphome/lib/postgresql/mylib")`,
+	}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("got %q want %q", missing, expected)
+	}
+}
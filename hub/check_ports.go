@@ -0,0 +1,216 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// portProbeBatchSize is the number of candidate ports probed at a time when
+// looking for free ports on a host.
+const portProbeBatchSize = 64
+
+var unavailablePorts = utils.UnavailablePorts
+
+// EnsureTargetPortsAvailable probes each host for the ports assigned to the
+// intermediate cluster. When autoAssign is set, segments whose ports are in use
+// are reassigned free ports on their host from the given ports. Otherwise the
+// user supplied temp_port_range is invalid and every conflict is reported.
+func EnsureTargetPortsAvailable(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, ports []int, autoAssign bool) error {
+	segments := allSegments(intermediate)
+	sort.Sort(segments)
+
+	planned := make(map[string][]uint32)
+	for _, seg := range segments {
+		planned[seg.Hostname] = append(planned[seg.Hostname], uint32(seg.Port))
+	}
+
+	conflicts, err := unavailablePortsByHost(agentConns, source.MasterHostname(), planned)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if !autoAssign {
+		return newPortConflictError(conflicts)
+	}
+
+	return reassignPorts(agentConns, source, intermediate, ports, conflicts)
+}
+
+// reassignPorts assigns free ports to the intermediate segments whose ports
+// are unavailable. Candidates on each host exclude the ports of the source
+// and intermediate clusters on that host.
+func reassignPorts(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, ports []int, conflicts map[string][]uint32) error {
+	for host, unavailable := range conflicts {
+		used := make(map[int]bool)
+		for _, seg := range append(allSegments(source), allSegments(intermediate)...) {
+			if seg.Hostname == host {
+				used[seg.Port] = true
+			}
+		}
+
+		var candidates []uint32
+		for _, port := range utils.Sanitize(ports) {
+			if !used[port] {
+				candidates = append(candidates, uint32(port))
+			}
+		}
+
+		free, err := findFreePorts(agentConns, source.MasterHostname(), host, candidates, len(unavailable))
+		if err != nil {
+			return err
+		}
+
+		isUnavailable := make(map[int]bool)
+		for _, port := range unavailable {
+			isUnavailable[int(port)] = true
+		}
+
+		for _, segments := range []greenplum.ContentToSegConfig{intermediate.Primaries, intermediate.Mirrors} {
+			var contents []int
+			for content := range segments {
+				contents = append(contents, content)
+			}
+			sort.Ints(contents)
+
+			for _, content := range contents {
+				seg := segments[content]
+				if seg.Hostname != host || !isUnavailable[seg.Port] {
+					continue
+				}
+
+				seg.Port = int(free[0])
+				free = free[1:]
+				segments[content] = seg
+			}
+		}
+	}
+
+	return nil
+}
+
+// findFreePorts probes candidates in batches on host until count free ports
+// are found.
+func findFreePorts(agentConns []*idl.Connection, masterHost string, host string, candidates []uint32, count int) ([]uint32, error) {
+	var free []uint32
+	for len(free) < count && len(candidates) > 0 {
+		batchSize := portProbeBatchSize
+		if batchSize > len(candidates) {
+			batchSize = len(candidates)
+		}
+		batch := candidates[:batchSize]
+		candidates = candidates[batchSize:]
+
+		conflicts, err := unavailablePortsByHost(agentConns, masterHost, map[string][]uint32{host: batch})
+		if err != nil {
+			return nil, err
+		}
+
+		isUnavailable := make(map[uint32]bool)
+		for _, port := range conflicts[host] {
+			isUnavailable[port] = true
+		}
+
+		for _, port := range batch {
+			if !isUnavailable[port] {
+				free = append(free, port)
+			}
+		}
+	}
+
+	if len(free) < count {
+		return nil, xerrors.Errorf("found %d free ports on host %q but need %d. Specify a larger temp_port_range.", len(free), host, count)
+	}
+
+	return free[:count], nil
+}
+
+// unavailablePortsByHost probes the given ports on each host and returns the
+// unavailable ports keyed by hostname. Hosts without conflicts are omitted.
+func unavailablePortsByHost(agentConns []*idl.Connection, masterHost string, portsByHost map[string][]uint32) (map[string][]uint32, error) {
+	var mu sync.Mutex
+	conflicts := make(map[string][]uint32)
+
+	local := func() error {
+		if ports, ok := portsByHost[masterHost]; ok {
+			if unavailable := unavailablePorts(ports); len(unavailable) > 0 {
+				conflicts[masterHost] = unavailable
+			}
+		}
+
+		return nil
+	}
+
+	request := func(conn *idl.Connection) error {
+		ports, ok := portsByHost[conn.Hostname]
+		if !ok {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.CheckPorts(context.Background(), &idl.CheckPortsRequest{Ports: ports})
+		if err != nil {
+			return xerrors.Errorf("checking ports on host %q: %w", conn.Hostname, err)
+		}
+
+		if len(reply.GetUnavailablePorts()) == 0 {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		conflicts[conn.Hostname] = reply.GetUnavailablePorts()
+		return nil
+	}
+
+	err := ExecuteOnMasterAndAgents(agentConns, masterHost, local, request)
+	return conflicts, err
+}
+
+func allSegments(cluster *greenplum.Cluster) greenplum.SegConfigs {
+	return cluster.SelectSegments(func(*greenplum.SegConfig) bool { return true })
+}
+
+type PortConflictError struct {
+	conflicts map[string][]uint32
+}
+
+func newPortConflictError(conflicts map[string][]uint32) *PortConflictError {
+	return &PortConflictError{conflicts: conflicts}
+}
+
+func (p *PortConflictError) Error() string {
+	var hosts []string
+	for host := range p.conflicts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var lines []string
+	for _, host := range hosts {
+		for _, port := range p.conflicts[host] {
+			lines = append(lines, fmt.Sprintf("host %q: port %d is in use", host, port))
+		}
+	}
+
+	return fmt.Sprintf("temp_port_range contains ports that are unavailable on the following hosts:\n%s\n"+
+		"Specify a temp_port_range with available ports.", strings.Join(lines, "\n"))
+}
+
+func (p *PortConflictError) Is(err error) bool {
+	return err == ErrInvalidTempPortRange
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestEnsureTargetPortsAvailable(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Port: 25432, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast/seg2", Port: 25433, Role: greenplum.PrimaryRole},
+	})
+
+	ports := []int{50432, 50433, 50434, 50435, 50436}
+
+	newIntermediate := func() *greenplum.Cluster {
+		return hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Port: 50432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg.AAAAAAAAAAA.1", Port: 50433, Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast/seg.AAAAAAAAAAA.2", Port: 50434, Role: greenplum.PrimaryRole},
+		})
+	}
+
+	hub.SetUnavailablePorts(func(ports []uint32) []uint32 {
+		return nil
+	})
+	defer hub.ResetUnavailablePorts()
+
+	t.Run("succeeds when all ports are available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckPorts(
+			gomock.Any(),
+			&idl.CheckPortsRequest{Ports: []uint32{50433, 50434}},
+		).Return(&idl.CheckPortsReply{Hostname: "sdw1"}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.EnsureTargetPortsAvailable(agentConns, source, newIntermediate(), ports, false)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("reports conflicts by host and port for a user supplied range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckPorts(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.CheckPortsReply{Hostname: "sdw1", UnavailablePorts: []uint32{50434}}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.EnsureTargetPortsAvailable(agentConns, source, newIntermediate(), ports, false)
+		if !errors.Is(err, hub.ErrInvalidTempPortRange) {
+			t.Fatalf("got error %#v want %#v", err, hub.ErrInvalidTempPortRange)
+		}
+
+		expected := `host "sdw1": port 50434 is in use`
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %q want it to contain %q", err.Error(), expected)
+		}
+	})
+
+	t.Run("assigns free ports per host when automatically assigning ports", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		gomock.InOrder(
+			sdw1.EXPECT().CheckPorts(
+				gomock.Any(),
+				&idl.CheckPortsRequest{Ports: []uint32{50433, 50434}},
+			).Return(&idl.CheckPortsReply{Hostname: "sdw1", UnavailablePorts: []uint32{50434}}, nil),
+			// candidates exclude the ports already assigned on the host
+			sdw1.EXPECT().CheckPorts(
+				gomock.Any(),
+				&idl.CheckPortsRequest{Ports: []uint32{50432, 50435, 50436}},
+			).Return(&idl.CheckPortsReply{Hostname: "sdw1", UnavailablePorts: []uint32{50432}}, nil),
+		)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		intermediate := newIntermediate()
+		err := hub.EnsureTargetPortsAvailable(agentConns, source, intermediate, ports, true)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if intermediate.Primaries[0].Port != 50433 {
+			t.Errorf("got port %d for content 0 want %d", intermediate.Primaries[0].Port, 50433)
+		}
+
+		if intermediate.Primaries[1].Port != 50435 {
+			t.Errorf("got port %d for content 1 want %d", intermediate.Primaries[1].Port, 50435)
+		}
+	})
+
+	t.Run("errors when there are not enough free ports", func(t *testing.T) {
+		hub.SetUnavailablePorts(func(ports []uint32) []uint32 {
+			return ports
+		})
+		defer hub.SetUnavailablePorts(func(ports []uint32) []uint32 {
+			return nil
+		})
+
+		err := hub.EnsureTargetPortsAvailable(nil, source, newIntermediate(), ports, true)
+		expected := `found 0 free ports on host "mdw" but need 1`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", err, expected)
+		}
+	})
+}
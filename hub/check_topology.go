@@ -0,0 +1,68 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// CheckTopology re-reads gp_segment_configuration and the tablespaces, and
+// ensures they match the configuration saved during initialize. Mirror
+// failovers, segments recovered elsewhere, or an expansion would otherwise
+// leave later substeps operating on the wrong directories.
+// Mirrors and the standby are only compared when includeMirrors is set, since
+// those of the intermediate cluster are not created until finalize.
+func CheckTopology(conn *greenplum.Conn, saved *greenplum.Cluster, includeMirrors bool) (err error) {
+	option := greenplum.ToSource()
+	if saved.Destination != idl.ClusterDestination_SOURCE {
+		option = greenplum.ToTarget()
+	}
+
+	db, err := sql.Open("pgx", conn.URI(option, greenplum.Port(saved.MasterPort())))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return CheckTopologyFromDB(db, saved, includeMirrors)
+}
+
+// CheckTopologyFromDB is CheckTopology for an open connection.
+func CheckTopologyFromDB(db *sql.DB, saved *greenplum.Cluster, includeMirrors bool) error {
+	current, err := greenplum.ClusterFromDB(db, saved.Version, saved.GPHome, saved.Destination)
+	if err != nil {
+		return xerrors.Errorf("retrieve cluster configuration: %w", err)
+	}
+
+	selector := func(seg *greenplum.SegConfig) bool {
+		return includeMirrors || seg.Role == greenplum.PrimaryRole
+	}
+
+	differences := greenplum.TopologyDifferences(saved.SelectSegments(selector), current.SelectSegments(selector))
+
+	if saved.Tablespaces != nil {
+		tuples, err := greenplum.GetTablespaceTuples(db, saved.Version)
+		if err != nil {
+			return xerrors.Errorf("retrieve tablespace information: %w", err)
+		}
+
+		differences = append(differences, greenplum.TablespaceDifferences(saved.Tablespaces, greenplum.NewTablespaces(tuples))...)
+	}
+
+	if len(differences) > 0 {
+		return greenplum.TopologyChangedError{Differences: differences}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestCheckTopologyFromDB(t *testing.T) {
+	segColumns := []string{"dbid", "contentid", "port", "hostname", "datadir", "role"}
+	tablespaceColumns := []string{"dbid", "oid", "name", "location", "userdefined"}
+
+	newSource := func() *greenplum.Cluster {
+		source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25432, Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25433, Role: greenplum.MirrorRole},
+		})
+		source.Version = semver.MustParse("6.20.0")
+		return source
+	}
+
+	t.Run("succeeds when the topology is unchanged", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnRows(sqlmock.NewRows(segColumns).
+			AddRow(1, -1, 15432, "mdw", "/data/qddir/seg-1", "p").
+			AddRow(2, 0, 25432, "sdw1", "/data/dbfast1/seg1", "p").
+			AddRow(3, 0, 25433, "sdw2", "/data/dbfast_mirror1/seg1", "m"))
+
+		err = hub.CheckTopologyFromDB(db, newSource(), true)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports a failover", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnRows(sqlmock.NewRows(segColumns).
+			AddRow(1, -1, 15432, "mdw", "/data/qddir/seg-1", "p").
+			AddRow(3, 0, 25433, "sdw2", "/data/dbfast_mirror1/seg1", "p").
+			AddRow(2, 0, 25432, "sdw1", "/data/dbfast1/seg1", "m"))
+
+		err = hub.CheckTopologyFromDB(db, newSource(), true)
+		var changedErr greenplum.TopologyChangedError
+		if !errors.As(err, &changedErr) {
+			t.Fatalf("got error %#v want %T", err, changedErr)
+		}
+
+		if len(changedErr.Differences) != 2 {
+			t.Errorf("got differences %q want 2", changedErr.Differences)
+		}
+	})
+
+	t.Run("ignores mirrors unless requested", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnRows(sqlmock.NewRows(segColumns).
+			AddRow(1, -1, 15432, "mdw", "/data/qddir/seg-1", "p").
+			AddRow(2, 0, 25432, "sdw1", "/data/dbfast1/seg1", "p"))
+
+		err = hub.CheckTopologyFromDB(db, newSource(), false)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports moved tablespaces for GPDB 5", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		source := newSource()
+		source.Version = semver.MustParse("5.28.0")
+		source.Tablespaces = greenplum.Tablespaces{
+			1: {16384: {Location: "/tmp/tblspc1/16384", UserDefined: 1}},
+		}
+
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnRows(sqlmock.NewRows(segColumns).
+			AddRow(1, -1, 15432, "mdw", "/data/qddir/seg-1", "p").
+			AddRow(2, 0, 25432, "sdw1", "/data/dbfast1/seg1", "p").
+			AddRow(3, 0, 25433, "sdw2", "/data/dbfast_mirror1/seg1", "m"))
+		mock.ExpectQuery("SELECT .* upgrade_tablespace").WillReturnRows(sqlmock.NewRows(tablespaceColumns).
+			AddRow(1, 16384, "foo", "/tmp/tblspc2/16384", 1))
+
+		err = hub.CheckTopologyFromDB(db, source, true)
+		if err == nil || !strings.Contains(err.Error(), `dbid 1: tablespace 16384 moved from "/tmp/tblspc1/16384" to "/tmp/tblspc2/16384"`) {
+			t.Errorf("got error %v want a moved tablespace", err)
+		}
+	})
+
+	t.Run("reports moved tablespaces for GPDB 6", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		source := newSource()
+		source.Version = semver.MustParse("6.20.0")
+		source.Tablespaces = greenplum.Tablespaces{
+			1: {16384: {Location: "/tmp/tblspc1", UserDefined: 1}},
+			2: {16384: {Location: "/tmp/tblspc1", UserDefined: 1}},
+			3: {16384: {Location: "/tmp/tblspc1", UserDefined: 1}},
+		}
+
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnRows(sqlmock.NewRows(segColumns).
+			AddRow(1, -1, 15432, "mdw", "/data/qddir/seg-1", "p").
+			AddRow(2, 0, 25432, "sdw1", "/data/dbfast1/seg1", "p").
+			AddRow(3, 0, 25433, "sdw2", "/data/dbfast_mirror1/seg1", "m"))
+		mock.ExpectQuery("SELECT .* gp_tablespace_location").WillReturnRows(sqlmock.NewRows(tablespaceColumns).
+			AddRow(1, 16384, "foo", "/tmp/tblspc1", 1).
+			AddRow(2, 16384, "foo", "/tmp/tblspc2", 1).
+			AddRow(3, 16384, "foo", "/tmp/tblspc2", 1))
+
+		err = hub.CheckTopologyFromDB(db, source, true)
+		if err == nil || !strings.Contains(err.Error(), `dbid 2: tablespace 16384 moved from "/tmp/tblspc1" to "/tmp/tblspc2"`) {
+			t.Errorf("got error %v want a moved tablespace", err)
+		}
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expected := errors.New("connection failed")
+		mock.ExpectQuery("SELECT .* FROM gp_segment_configuration").WillReturnError(expected)
+
+		err = hub.CheckTopologyFromDB(db, newSource(), true)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/disk"
 	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
@@ -35,6 +37,38 @@ func ResetCheckDiskUsage() {
 	checkDiskUsage = disk.CheckUsage
 }
 
+func SetEstimateDiskUsage(usageFunc disk.EstimateUsageType) {
+	estimateDiskUsage = usageFunc
+}
+
+func ResetEstimateDiskUsage() {
+	estimateDiskUsage = disk.EstimateUsage
+}
+
+func SetCheckFilesystems(checkFunc func(disk.Disk, ...*idl.CheckFilesystemsRequest_DirPair) ([]*idl.CheckFilesystemsReply_Devices, error)) {
+	checkFilesystems = checkFunc
+}
+
+func ResetCheckFilesystems() {
+	checkFilesystems = disk.CheckFilesystems
+}
+
+func SetUnavailablePorts(portsFunc func([]uint32) []uint32) {
+	unavailablePorts = portsFunc
+}
+
+func ResetUnavailablePorts() {
+	unavailablePorts = utils.UnavailablePorts
+}
+
+func SetDirUsage(usageFunc func(string) (disk.DirUsage, error)) {
+	dirUsage = usageFunc
+}
+
+func ResetDirUsage() {
+	dirUsage = disk.Local.DirUsage
+}
+
 // MustCreateCluster creates a utils.Cluster and calls t.Fatalf() if there is
 // any error.
 func MustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.Cluster {
@@ -47,3 +81,27 @@ func MustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.C
 
 	return &cluster
 }
+
+func SetGetHostInfo(infoFunc func(string, string) (*idl.HostInfo, error)) {
+	getHostInfo = infoFunc
+}
+
+func ResetGetHostInfo() {
+	getHostInfo = GetHostInfo
+}
+
+func SetRunningUtilities(utilitiesFunc func() ([]string, error)) {
+	runningUtilities = utilitiesFunc
+}
+
+func ResetRunningUtilities() {
+	runningUtilities = RunningUtilities
+}
+
+func SetGetControlData(controlDataFunc func(string, string) (*idl.ControlData, error)) {
+	getControlData = controlDataFunc
+}
+
+func ResetGetControlData() {
+	getControlData = greenplum.GetControlData
+}
@@ -5,11 +5,14 @@ package hub
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 
+	"github.com/blang/semver/v4"
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -25,7 +28,7 @@ type Result struct {
 	err    error
 }
 
-func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []string) error {
+func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []string, opts ...rsync.Option) error {
 	/*
 	 * Copy the directories once per host.
 	 */
@@ -49,6 +52,7 @@ func Copy(streams step.OutStreams, destinationDir string, sourceDirs, hosts []st
 				rsync.WithOptions("--archive", "--compress", "--delete", "--stats"),
 				rsync.WithStream(stream),
 			}
+			options = append(options, opts...)
 
 			err := rsync.Rsync(options...)
 			if err != nil {
@@ -88,14 +92,51 @@ func CopyMasterDataDir(streams step.OutStreams, masterDataDir string, destinatio
 	return Copy(streams, destination, source, hosts)
 }
 
-func CopyMasterTablespaces(streams step.OutStreams, tablespaces greenplum.Tablespaces, destinationDir string, hosts []string) error {
+// CopyMasterTablespaces copies the upgraded master tablespaces to
+// destinationDir/<tablespaceOid> on each host, from where they are restored
+// to the primaries.
+func CopyMasterTablespaces(streams step.OutStreams, sourceVersion semver.Version, targetVersion semver.Version, tablespaces greenplum.Tablespaces, destinationDir string, hosts []string) error {
 	if tablespaces == nil {
 		return nil
 	}
 
 	// include tablespace mapping file which is used as a parameter to pg_upgrade
 	sourcePaths := []string{utils.GetTablespaceMappingFile()}
-	sourcePaths = append(sourcePaths, tablespaces.GetMasterTablespaces().UserDefinedTablespacesLocations()...)
 
-	return Copy(streams, destinationDir+string(os.PathSeparator), sourcePaths, hosts)
+	// 5X tablespace locations already end with the tablespace oid, while
+	// 6X tablespace locations are shared by all segments and are copied
+	// individually.
+	if sourceVersion.Major == 5 {
+		sourcePaths = append(sourcePaths, tablespaces.GetMasterTablespaces().UserDefinedTablespacesLocations()...)
+	}
+
+	if err := Copy(streams, destinationDir+string(os.PathSeparator), sourcePaths, hosts); err != nil {
+		return err
+	}
+
+	if sourceVersion.Major == 5 {
+		return nil
+	}
+
+	// Only copy the upgraded master directory <location>/<dbid>/GPDB_<target>_*
+	// since the location also contains the source master directory, and
+	// possibly the directories of other segments on the master host.
+	for oid, tsInfo := range tablespaces.GetMasterTablespaces() {
+		if !tsInfo.IsUserDefined() {
+			continue
+		}
+
+		destination := greenplum.GetMasterTablespaceLocation(destinationDir, oid) + string(os.PathSeparator)
+		source := filepath.Join(tsInfo.Location, strconv.Itoa(greenplum.MasterDbid)) + string(os.PathSeparator)
+		options := []rsync.Option{
+			rsync.WithOptions("--include", fmt.Sprintf("/GPDB_%d_*", targetVersion.Major)),
+			rsync.WithExcludedFiles("/*"),
+		}
+
+		if err := Copy(streams, destination, []string{source}, hosts, options...); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
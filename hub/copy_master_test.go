@@ -11,8 +11,10 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -247,7 +249,7 @@ func TestCopyMasterTablespaces(t *testing.T) {
 		}
 		execCommandVerifier(t, hosts, expectedArgs)
 
-		err := CopyMasterTablespaces(step.DevNullStream, Tablespaces, "foobar/path", intermediate.PrimaryHostnames())
+		err := CopyMasterTablespaces(step.DevNullStream, semver.MustParse("5.28.0"), semver.MustParse("6.20.0"), Tablespaces, "foobar/path", intermediate.PrimaryHostnames())
 		if err != nil {
 			t.Errorf("copying master tablespace directories and mapping file: %+v", err)
 		}
@@ -258,6 +260,39 @@ func TestCopyMasterTablespaces(t *testing.T) {
 		verifyHosts(hosts, expectedHosts, t)
 	})
 
+	t.Run("copies only the upgraded master directory of each 6X tablespace to a directory named by its oid", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls [][]string
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			// remove the host from the destination host:/destination/directory
+			for i, arg := range args {
+				if parts := strings.SplitN(arg, ":", 2); len(parts) == 2 {
+					args[i] = parts[1]
+				}
+			}
+			calls = append(calls, args)
+		}))
+		defer rsync.ResetRsyncCommand()
+
+		err := CopyMasterTablespaces(step.DevNullStream, semver.MustParse("6.20.0"), semver.MustParse("7.0.0"), Tablespaces, "foobar/path", intermediate.PrimaryHostnames())
+		if err != nil {
+			t.Errorf("copying master tablespace directories and mapping file: %+v", err)
+		}
+
+		expected := [][]string{
+			{"--archive", "--compress", "--delete", "--stats", utils.GetTablespaceMappingFile(), "foobar/path/"},
+			{"--archive", "--compress", "--delete", "--stats", utils.GetTablespaceMappingFile(), "foobar/path/"},
+			{"--archive", "--compress", "--delete", "--stats", "--include", "/GPDB_7_*", "/tmp/tblspc2/1/", "foobar/path/1664/1/", "--exclude", "/*"},
+			{"--archive", "--compress", "--delete", "--stats", "--include", "/GPDB_7_*", "/tmp/tblspc2/1/", "foobar/path/1664/1/", "--exclude", "/*"},
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("rsync invoked with %q, want %q", calls, expected)
+		}
+	})
+
 	t.Run("CopyMasterTablespaces returns nil if there is no tablespaces", func(t *testing.T) {
 		// The verifier function can be called in parallel, so use a channel to
 		// communicate which hosts were actually used.
@@ -266,7 +301,7 @@ func TestCopyMasterTablespaces(t *testing.T) {
 		var expectedArgs []string
 		execCommandVerifier(t, hosts, expectedArgs)
 
-		err := CopyMasterTablespaces(step.DevNullStream, nil, "foobar/path", intermediate.PrimaryHostnames())
+		err := CopyMasterTablespaces(step.DevNullStream, semver.MustParse("5.28.0"), semver.MustParse("6.20.0"), nil, "foobar/path", intermediate.PrimaryHostnames())
 		if err != nil {
 			t.Errorf("got %+v, want nil", err)
 		}
@@ -36,7 +36,40 @@ func CreateRecoveryConfOnSegments(agentConns []*idl.Connection, intermediate *gr
 			connReqs = append(connReqs, connReq)
 		}
 
-		req := &idl.CreateRecoveryConfRequest{Connections: connReqs}
+		req := &idl.CreateRecoveryConfRequest{
+			Connections:   connReqs,
+			TargetVersion: intermediate.Version.String(),
+		}
+		_, err := conn.AgentClient.CreateRecoveryConf(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// CreateRecoveryConfOnStandby configures the standby to stream from the
+// master.
+func CreateRecoveryConfOnStandby(agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+	user, err := utils.System.Current()
+	if err != nil {
+		return err
+	}
+
+	request := func(conn *idl.Connection) error {
+		if intermediate.StandbyHostname() != conn.Hostname {
+			return nil
+		}
+
+		req := &idl.CreateRecoveryConfRequest{
+			Connections: []*idl.CreateRecoveryConfRequest_Connection{{
+				MirrorDataDir: intermediate.StandbyDataDir(),
+				User:          user.Username,
+				PrimaryHost:   intermediate.MasterHostname(),
+				PrimaryPort:   int32(intermediate.MasterPort()),
+			}},
+			TargetVersion: intermediate.Version.String(),
+		}
+
 		_, err := conn.AgentClient.CreateRecoveryConf(context.Background(), req)
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"os/user"
 	"testing"
 
+	"github.com/blang/semver/v4"
 	"github.com/golang/mock/gomock"
 	"golang.org/x/xerrors"
 
@@ -28,6 +29,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.HqtFHX54y0o.2", Port: 50436, Role: greenplum.PrimaryRole},
 		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
 	})
+	intermediate.Version = semver.MustParse("7.0.0")
 
 	utils.System.Current = func() (*user.User, error) {
 		return &user.User{Username: "gpadmin"}, nil
@@ -51,6 +53,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 						PrimaryHost:   "sdw2",
 						PrimaryPort:   int32(50436),
 					}},
+				TargetVersion: "7.0.0",
 			},
 		).Return(&idl.CreateRecoveryConfReply{}, nil)
 
@@ -65,6 +68,7 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 						PrimaryHost:   "sdw1",
 						PrimaryPort:   int32(50434),
 					}},
+				TargetVersion: "7.0.0",
 			},
 		).Return(&idl.CreateRecoveryConfReply{}, nil)
 
@@ -133,3 +137,67 @@ func TestCreateRecoveryConfOnSegments(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateRecoveryConfOnStandby(t *testing.T) {
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+
+	utils.System.Current = func() (*user.User, error) {
+		return &user.User{Username: "gpadmin"}, nil
+	}
+	defer func() {
+		utils.System.Current = user.Current
+	}()
+
+	t.Run("creates the recovery configuration only on the standby", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().CreateRecoveryConf(
+			gomock.Any(),
+			&idl.CreateRecoveryConfRequest{
+				Connections: []*idl.CreateRecoveryConfRequest_Connection{
+					{
+						MirrorDataDir: "/data/standby.HqtFHX54y0o",
+						User:          "gpadmin",
+						PrimaryHost:   "master",
+						PrimaryPort:   int32(50432),
+					}},
+				TargetVersion: "6.20.0",
+			},
+		).Return(&idl.CreateRecoveryConfReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"},
+		}
+
+		err := hub.CreateRecoveryConfOnStandby(agentConns, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns errors from the standby", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().CreateRecoveryConf(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: standby, Hostname: "standby"}}
+
+		err := hub.CreateRecoveryConfOnStandby(agentConns, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v, want %#v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+)
+
+// DumpSchema takes a schema-only dump of all databases in the cluster using
+// the cluster's own pg_dumpall, so that the dump can later be normalized with
+// the filter rules matching the cluster's version.
+func DumpSchema(streams step.OutStreams, cluster *greenplum.Cluster, path string) error {
+	err := cluster.RunGreenplumCmd(streams, "pg_dumpall", "--schema-only", "--file", path)
+	if err != nil {
+		return xerrors.Errorf("dump schema to %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestDumpSchema(t *testing.T) {
+	testlog.SetupLogger()
+
+	cluster := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+	})
+	cluster.GPHome = "/usr/local/greenplum-db"
+
+	t.Run("dumps the schema using the cluster's pg_dumpall", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			if utility != "bash" {
+				t.Errorf("got utility %q want bash", utility)
+			}
+
+			expected := "source /usr/local/greenplum-db/greenplum_path.sh && " +
+				"/usr/local/greenplum-db/bin/pg_dumpall --schema-only --file /state/source_schema.sql"
+			if len(args) != 2 || args[1] != expected {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer greenplum.ResetGreenplumCommand()
+
+		err := hub.DumpSchema(step.DevNullStream, cluster, "/state/source_schema.sql")
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns an error when pg_dumpall fails", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(hub.Failure))
+		defer greenplum.ResetGreenplumCommand()
+
+		err := hub.DumpSchema(step.DevNullStream, cluster, "/state/source_schema.sql")
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("got error %#v want %T", err, exitErr)
+		}
+
+		if !strings.Contains(err.Error(), "/state/source_schema.sql") {
+			t.Errorf("expected error %q to contain the dump path", err)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+// LinkModeOverheadRatio is the fraction of a data directory's size required
+// in link mode. User data files are hard linked rather than copied, so only
+// the new catalog and other non-relation files take additional space.
+const LinkModeOverheadRatio = 0.05
+
+// PgUpgradeDirSize is the space in kilobytes allowed for the pg_upgrade log
+// and work directories of each primary, which hold the schema dump and logs.
+const PgUpgradeDirSize = 100 * 1024
+
+var estimateDiskUsage = disk.EstimateUsage
+var dirUsage = disk.Local.DirUsage
+
+// EstimateDiskSpace measures the source cluster and checks that every host
+// has enough space and inodes for the upgrade in the chosen mode. Unlike
+// CheckDiskSpace which requires a fixed fraction of each filesystem to be
+// free, the requirements are based on the size and file count of the data
+// directories, tablespaces, and mirrors on each filesystem.
+func EstimateDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces, useLinkMode bool, stateDir string, logDir string) error {
+	masterUsage, err := masterBackupUsage(source, sourceTablespaces)
+	if err != nil {
+		return err
+	}
+
+	requirements := DiskSpaceRequirements(source, sourceTablespaces, useLinkMode, masterUsage, stateDir, logDir)
+
+	var mu sync.Mutex
+	totalUsage := make(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage)
+	addUsage := func(usages disk.FileSystemDiskUsage) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, usage := range usages {
+			totalUsage[disk.FilesystemHost{Filesystem: usage.GetFs(), Host: usage.GetHost()}] = usage
+		}
+	}
+
+	// Any segments on the master host are estimated along with the master so
+	// that their requirements count against the same filesystems.
+	local := func() error {
+		usage, err := estimateDiskUsage(streams, disk.Local, requirements[source.MasterHostname()]...)
+		if err != nil {
+			return err
+		}
+
+		addUsage(usage)
+		return nil
+	}
+
+	request := func(conn *idl.Connection) error {
+		if len(requirements[conn.Hostname]) == 0 {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.CheckDiskSpace(context.Background(), &idl.CheckSegmentDiskSpaceRequest{
+			Requirements: requirements[conn.Hostname],
+		})
+		if err != nil {
+			return xerrors.Errorf("checking disk space on host %q: %w", conn.Hostname, err)
+		}
+
+		addUsage(reply.GetUsage())
+		return nil
+	}
+
+	err = ExecuteOnMasterAndAgents(agentConns, source.MasterHostname(), local, request)
+	if err != nil {
+		return err
+	}
+
+	if len(totalUsage) > 0 {
+		return disk.NewSpaceUsageError(totalUsage)
+	}
+
+	return nil
+}
+
+// DiskSpaceRequirements returns the space required on each host keyed by
+// hostname. Primaries and the master need a full copy in copy mode and a
+// fraction in link mode, along with space for their pg_upgrade directories.
+// Mirrors need a full copy in copy mode since they are recreated in new data
+// directories, while in link mode the upgraded primaries are rsync'd over the
+// source mirrors. The standby needs a full copy in copy mode since it is
+// recreated with gpinitstandby, while in link mode the upgraded master is
+// rsync'd to it hard linking the unchanged files of the source standby.
+// Every primary host needs space in its state directory for the copy of the
+// upgraded master made by COPY_MASTER.
+func DiskSpaceRequirements(source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces, useLinkMode bool, masterBackup disk.DirUsage, stateDir string, logDir string) map[string][]*idl.CheckSegmentDiskSpaceRequest_Requirement {
+	ratio := 1.0
+	if useLinkMode {
+		ratio = LinkModeOverheadRatio
+	}
+
+	requirements := make(map[string][]*idl.CheckSegmentDiskSpaceRequest_Requirement)
+	add := func(seg greenplum.SegConfig, ratio float64) {
+		dirs := append([]string{seg.DataDir}, sourceTablespaces[seg.DbID].UserDefinedTablespacesLocations()...)
+		for _, dir := range dirs {
+			requirements[seg.Hostname] = append(requirements[seg.Hostname], &idl.CheckSegmentDiskSpaceRequest_Requirement{
+				Path:   dir,
+				SizeOf: dir,
+				Ratio:  ratio,
+			})
+		}
+	}
+
+	segments := source.SelectSegments(func(seg *greenplum.SegConfig) bool { return true })
+	sort.Sort(segments)
+
+	for _, seg := range segments {
+		switch {
+		case seg.IsMaster() || seg.IsPrimary():
+			add(seg, ratio)
+			requirements[seg.Hostname] = append(requirements[seg.Hostname], &idl.CheckSegmentDiskSpaceRequest_Requirement{
+				Path: logDir,
+				Size: PgUpgradeDirSize,
+			})
+		case seg.IsStandby():
+			add(seg, ratio)
+		case !useLinkMode:
+			add(seg, 1)
+		}
+	}
+
+	hosts := source.PrimaryHostnames()
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		requirements[host] = append(requirements[host], &idl.CheckSegmentDiskSpaceRequest_Requirement{
+			Path:  stateDir,
+			Size:  masterBackup.Size,
+			Files: masterBackup.Files,
+		})
+	}
+
+	return requirements
+}
+
+// masterBackupUsage returns the size and file count of the master data
+// directory and its tablespaces which are copied to every primary host.
+func masterBackupUsage(source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) (disk.DirUsage, error) {
+	dirs := append([]string{source.MasterDataDir()}, sourceTablespaces.GetMasterTablespaces().UserDefinedTablespacesLocations()...)
+
+	var total disk.DirUsage
+	for _, dir := range dirs {
+		usage, err := dirUsage(dir)
+		if err != nil {
+			return disk.DirUsage{}, xerrors.Errorf("getting size of %s: %w", dir, err)
+		}
+
+		total.Size += usage.Size
+		total.Files += usage.Files
+	}
+
+	return total, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+type requirement = idl.CheckSegmentDiskSpaceRequest_Requirement
+
+func TestDiskSpaceRequirements(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "smdw", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+
+	tablespaces := testutils.CreateTablespaces()
+
+	t.Run("requires full copies of all data directories in copy mode", func(t *testing.T) {
+		requirements := hub.DiskSpaceRequirements(source, tablespaces, false, disk.DirUsage{Size: 500, Files: 50}, "/home/gpadmin/.gpupgrade", "/home/gpadmin/gpAdminLogs/gpupgrade")
+
+		expected := map[string][]*requirement{
+			"mdw": {
+				{Path: "/data/qddir/seg-1", SizeOf: "/data/qddir/seg-1", Ratio: 1},
+				{Path: "/tmp/user_ts/m/qddir/16384", SizeOf: "/tmp/user_ts/m/qddir/16384", Ratio: 1},
+				{Path: "/home/gpadmin/gpAdminLogs/gpupgrade", Size: hub.PgUpgradeDirSize},
+			},
+			"smdw": {
+				{Path: "/data/standby", SizeOf: "/data/standby", Ratio: 1},
+				{Path: "/tmp/user_ts/m/standby/16384", SizeOf: "/tmp/user_ts/m/standby/16384", Ratio: 1},
+			},
+			"sdw1": {
+				{Path: "/data/dbfast/seg1", SizeOf: "/data/dbfast/seg1", Ratio: 1},
+				{Path: "/tmp/user_ts/p1/16384", SizeOf: "/tmp/user_ts/p1/16384", Ratio: 1},
+				{Path: "/home/gpadmin/gpAdminLogs/gpupgrade", Size: hub.PgUpgradeDirSize},
+				{Path: "/home/gpadmin/.gpupgrade", Size: 500, Files: 50},
+			},
+			"sdw2": {
+				{Path: "/data/dbfast_mirror1/seg1", SizeOf: "/data/dbfast_mirror1/seg1", Ratio: 1},
+				{Path: "/tmp/user_ts/m1/16384", SizeOf: "/tmp/user_ts/m1/16384", Ratio: 1},
+			},
+		}
+
+		if !reflect.DeepEqual(requirements, expected) {
+			t.Errorf("got %v want %v", requirements, expected)
+		}
+	})
+
+	t.Run("requires only the link mode overhead for the master, standby, and primaries and nothing for mirrors in link mode", func(t *testing.T) {
+		requirements := hub.DiskSpaceRequirements(source, tablespaces, true, disk.DirUsage{Size: 500, Files: 50}, "/home/gpadmin/.gpupgrade", "/home/gpadmin/gpAdminLogs/gpupgrade")
+
+		expected := map[string][]*requirement{
+			"mdw": {
+				{Path: "/data/qddir/seg-1", SizeOf: "/data/qddir/seg-1", Ratio: hub.LinkModeOverheadRatio},
+				{Path: "/tmp/user_ts/m/qddir/16384", SizeOf: "/tmp/user_ts/m/qddir/16384", Ratio: hub.LinkModeOverheadRatio},
+				{Path: "/home/gpadmin/gpAdminLogs/gpupgrade", Size: hub.PgUpgradeDirSize},
+			},
+			"smdw": {
+				{Path: "/data/standby", SizeOf: "/data/standby", Ratio: hub.LinkModeOverheadRatio},
+				{Path: "/tmp/user_ts/m/standby/16384", SizeOf: "/tmp/user_ts/m/standby/16384", Ratio: hub.LinkModeOverheadRatio},
+			},
+			"sdw1": {
+				{Path: "/data/dbfast/seg1", SizeOf: "/data/dbfast/seg1", Ratio: hub.LinkModeOverheadRatio},
+				{Path: "/tmp/user_ts/p1/16384", SizeOf: "/tmp/user_ts/p1/16384", Ratio: hub.LinkModeOverheadRatio},
+				{Path: "/home/gpadmin/gpAdminLogs/gpupgrade", Size: hub.PgUpgradeDirSize},
+				{Path: "/home/gpadmin/.gpupgrade", Size: 500, Files: 50},
+			},
+		}
+
+		if !reflect.DeepEqual(requirements, expected) {
+			t.Errorf("got %v want %v", requirements, expected)
+		}
+	})
+}
+
+func TestEstimateDiskSpace(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast/seg1", Role: greenplum.PrimaryRole},
+	})
+
+	tablespaces := greenplum.Tablespaces{}
+
+	hub.SetDirUsage(func(path string) (disk.DirUsage, error) {
+		return disk.DirUsage{Size: 500, Files: 50}, nil
+	})
+	defer hub.ResetDirUsage()
+
+	hub.SetEstimateDiskUsage(func(streams step.OutStreams, d disk.Disk, requirements ...*requirement) (disk.FileSystemDiskUsage, error) {
+		return nil, nil
+	})
+	defer hub.ResetEstimateDiskUsage()
+
+	t.Run("sends each host its requirements and succeeds when there is enough space", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckDiskSpace(
+			gomock.Any(),
+			&idl.CheckSegmentDiskSpaceRequest{Requirements: []*requirement{
+				{Path: "/data/dbfast/seg1", SizeOf: "/data/dbfast/seg1", Ratio: 1},
+				{Path: "/log", Size: hub.PgUpgradeDirSize},
+				{Path: "/state", Size: 500, Files: 50},
+			}},
+		).Return(&idl.CheckDiskSpaceReply{}, nil)
+
+		// the master host is checked locally
+		mdw := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: mdw, Hostname: "mdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.EstimateDiskSpace(step.DevNullStream, agentConns, source, tablespaces, false, "/state", "/log")
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns a space usage error combining all hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		masterUsage := &idl.CheckDiskSpaceReply_DiskUsage{Fs: "/", Host: "mdw", Available: 1, Required: 2}
+		hub.SetEstimateDiskUsage(func(streams step.OutStreams, d disk.Disk, requirements ...*requirement) (disk.FileSystemDiskUsage, error) {
+			return disk.FileSystemDiskUsage{masterUsage}, nil
+		})
+
+		segmentUsage := &idl.CheckDiskSpaceReply_DiskUsage{Fs: "/data", Host: "sdw1", Available: 3, Required: 4}
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CheckDiskSpace(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.CheckDiskSpaceReply{Usage: disk.FileSystemDiskUsage{segmentUsage}}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.EstimateDiskSpace(step.DevNullStream, agentConns, source, tablespaces, false, "/state", "/log")
+		var spaceErr *disk.SpaceUsageErr
+		if !errors.As(err, &spaceErr) {
+			t.Fatalf("got error %#v want %T", err, spaceErr)
+		}
+
+		expected := disk.NewSpaceUsageError(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage{
+			{Filesystem: "/", Host: "mdw"}:      masterUsage,
+			{Filesystem: "/data", Host: "sdw1"}: segmentUsage,
+		})
+		if !reflect.DeepEqual(spaceErr.Table(), expected.Table()) {
+			t.Errorf("got %v want %v", spaceErr.Table(), expected.Table())
+		}
+	})
+
+	t.Run("errors when measuring the master fails", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		hub.SetDirUsage(func(path string) (disk.DirUsage, error) {
+			return disk.DirUsage{}, expected
+		})
+		defer hub.SetDirUsage(func(path string) (disk.DirUsage, error) {
+			return disk.DirUsage{Size: 500, Files: 50}, nil
+		})
+
+		err := hub.EstimateDiskSpace(step.DevNullStream, nil, source, tablespaces, false, "/state", "/log")
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package hub
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"golang.org/x/xerrors"
@@ -36,10 +37,44 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 		}
 	}()
 
+	st.Run(idl.Substep_CHECK_CLUSTER_TOPOLOGY, func(_ step.OutStreams) error {
+		return CheckTopology(s.Connection, s.Source, true)
+	})
+
+	// Re-check disk space since the source cluster may have grown since
+	// initialize. This must happen before the source cluster is shut down.
+	st.RunConditionally(idl.Substep_CHECK_DISK_SPACE, s.DiskSpaceCheckEnabled() && !req.GetSkipDiskSpaceCheck(), func(streams step.OutStreams) error {
+		return s.checkDiskSpace(streams)
+	})
+
+	st.Run(idl.Substep_QUIESCE_SOURCE_CLUSTER, func(streams step.OutStreams) error {
+		action := req.GetQuiesce()
+		if action == "" {
+			action = QuiesceAbort
+		}
+
+		timeout := time.Duration(req.GetQuiesceTimeout()) * time.Second
+		if timeout == 0 {
+			timeout = DefaultQuiesceTimeout
+		}
+
+		return QuiesceSourceCluster(streams, s.Connection, s.Source, action, timeout)
+	})
+
+	// Snapshot the source data only once the source cluster is idle so that
+	// concurrent writes are not reported as differences in the target.
+	st.RunConditionally(idl.Substep_SNAPSHOT_SOURCE_DATA, DataValidationEnabled(s.DataValidation), func(streams step.OutStreams) error {
+		return SnapshotSourceData(streams, s.Connection, s.Source, s.DataValidation, s.StateDir)
+	})
+
 	st.Run(idl.Substep_SHUTDOWN_SOURCE_CLUSTER, func(streams step.OutStreams) error {
 		return s.Source.Stop(streams)
 	})
 
+	st.Run(idl.Substep_VERIFY_SOURCE_SHUTDOWN, func(_ step.OutStreams) error {
+		return VerifySourceShutdown(s.agentConns, s.Source)
+	})
+
 	st.Run(idl.Substep_UPGRADE_MASTER, func(streams step.OutStreams) error {
 		stateDir := s.StateDir
 		return UpgradeMaster(UpgradeMasterArgs{
@@ -58,7 +93,7 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 			return err
 		}
 
-		return CopyMasterTablespaces(streams, s.Source.Tablespaces, utils.GetTablespaceDir(), s.Intermediate.PrimaryHostnames())
+		return CopyMasterTablespaces(streams, s.Source.Version, s.Intermediate.Version, s.Source.Tablespaces, utils.GetTablespaceDir(), s.Intermediate.PrimaryHostnames())
 	})
 
 	st.Run(idl.Substep_UPGRADE_PRIMARIES, func(_ step.OutStreams) error {
@@ -83,11 +118,24 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 		return s.Intermediate.Start(streams)
 	})
 
+	st.RunConditionally(idl.Substep_VALIDATE_TARGET_DATA, DataValidationEnabled(s.DataValidation), func(streams step.OutStreams) error {
+		return ValidateTargetData(streams, s.Connection, s.Intermediate, s.StateDir)
+	})
+
+	st.RunConditionally(idl.Substep_DUMP_TARGET_SCHEMA, s.DumpSchemas, func(streams step.OutStreams) error {
+		return DumpSchema(streams, s.Intermediate, utils.GetTargetSchemaDump())
+	})
+
+	st.RunConditionally(idl.Substep_RUN_SMOKE_TESTS, s.SmokeTestDir != "", func(streams step.OutStreams) error {
+		return RunSmokeTests(streams, s.Intermediate, s.SmokeTestDir, utils.GetSmokeTestResultsDir())
+	})
+
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_ExecuteResponse{
 		ExecuteResponse: &idl.ExecuteResponse{
 			Target: &idl.Cluster{
 				Port:                int32(s.Intermediate.MasterPort()),
 				MasterDataDirectory: s.Intermediate.MasterDataDir(),
+				Version:             s.Intermediate.Version.String(),
 			}},
 	}}}}
 
@@ -6,6 +6,7 @@ package hub
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
@@ -38,6 +39,16 @@ func FillConfiguration(config *Config, request *idl.InitializeRequest, conn *gre
 
 	config.AgentPort = int(request.GetAgentPort())
 	config.UseHbaHostnames = request.GetUseHbaHostnames()
+	config.DataValidation = request.GetDataValidation()
+	config.DumpSchemas = request.GetDumpSchemas()
+	config.SmokeTestDir = request.GetSmokeTestDir()
+	config.UpgradeExtensions = request.GetUpgradeExtensions()
+	config.DiskFreeRatio = request.GetDiskFreeRatio()
+	config.EstimateDiskSpace = request.GetEstimateDiskSpace()
+	config.ClusterReadyTimeout = time.Duration(request.GetClusterReadyTimeout()) * time.Second
+	config.Rebalance = request.GetRebalance()
+	config.GpinitsystemOverrides = request.GetGpinitsystemOverrides()
+	config.RsyncMirrors = request.GetRsyncMirrors()
 	config.UpgradeID = upgrade.NewID()
 
 	source, err := greenplum.ClusterFromDB(db, conn.SourceVersion, request.GetSourceGPHome(), idl.ClusterDestination_SOURCE)
@@ -45,11 +56,6 @@ func FillConfiguration(config *Config, request *idl.InitializeRequest, conn *gre
 		return xerrors.Errorf("retrieve source configuration: %w", err)
 	}
 
-	err = source.WaitForClusterToBeReady(conn)
-	if err != nil {
-		return err
-	}
-
 	target := source // create target cluster based off source cluster
 	config.Source = &source
 	config.Target = &target
@@ -72,12 +78,14 @@ func FillConfiguration(config *Config, request *idl.InitializeRequest, conn *gre
 		return err
 	}
 
-	if config.Source.Version.Major == 5 {
+	// GPDB 7 is the latest target, so only 5X and 6X sources have tablespaces
+	// that need to be upgraded.
+	if config.Source.Version.Major < 7 {
 		if err := utils.System.MkdirAll(utils.GetTablespaceDir(), 0700); err != nil {
 			return xerrors.Errorf("create tablespace directory %q: %w", utils.GetTablespaceDir(), err)
 		}
 
-		config.Source.Tablespaces, err = greenplum.TablespacesFromDB(db, utils.GetTablespaceMappingFile())
+		config.Source.Tablespaces, err = sourceTablespaces(conn, int(request.GetSourcePort()), config.Source.Version)
 		if err != nil {
 			return xerrors.Errorf("extract tablespace information: %w", err)
 		}
@@ -90,6 +98,37 @@ func FillConfiguration(config *Config, request *idl.InitializeRequest, conn *gre
 	return nil
 }
 
+func sourceTablespaces(conn *greenplum.Conn, port int, version semver.Version) (tablespaces greenplum.Tablespaces, err error) {
+	db, err := sql.Open("pgx", tablespacesURI(conn, port))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return greenplum.TablespacesFromDB(db, utils.GetTablespaceMappingFile(), version)
+}
+
+// tablespacesURI returns the URI used to query the source tablespaces. 5X
+// reads the segment locations from pg_filespace_entry on the master which
+// works in utility mode. 6X calls gp_tablespace_location() which dispatches to
+// the segments, so it needs a normal connection.
+func tablespacesURI(conn *greenplum.Conn, port int) string {
+	options := []greenplum.Option{
+		greenplum.ToSource(),
+		greenplum.Port(port),
+	}
+
+	if conn.SourceVersion.Major < 6 {
+		options = append(options, greenplum.UtilityMode())
+	}
+
+	return conn.URI(options...)
+}
+
 func GenerateIntermediateCluster(source *greenplum.Cluster, ports []int, upgradeID upgrade.ID, version semver.Version, gphome string) (*greenplum.Cluster, error) {
 	ports = utils.Sanitize(ports)
 
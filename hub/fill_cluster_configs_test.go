@@ -6,6 +6,7 @@ package hub
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/blang/semver/v4"
@@ -351,3 +352,29 @@ func TestEnsureTempPortRangeDoesNotOverlapWithSourceClusterPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestTablespacesURI(t *testing.T) {
+	t.Run("uses utility mode for 5X which reads pg_filespace_entry on the master", func(t *testing.T) {
+		conn := greenplum.Connection(semver.MustParse("5.28.0"), semver.MustParse("6.20.0"))
+
+		uri := tablespacesURI(conn, 15432)
+		if !strings.Contains(uri, "gp_session_role=utility") {
+			t.Errorf("got URI %q, want utility mode", uri)
+		}
+	})
+
+	t.Run("does not use utility mode for 6X since gp_tablespace_location dispatches to the segments", func(t *testing.T) {
+		conn := greenplum.Connection(semver.MustParse("6.20.0"), semver.MustParse("7.0.0"))
+
+		uri := tablespacesURI(conn, 15432)
+		if strings.Contains(uri, "utility") {
+			t.Errorf("got URI %q, want a dispatching connection", uri)
+		}
+
+		expected := "postgresql://localhost:15432/template1?search_path="
+		if uri != expected {
+			t.Errorf("got URI %q want %q", uri, expected)
+		}
+	})
+}
+
@@ -31,20 +31,38 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 		}
 	}()
 
-	st.RunConditionally(idl.Substep_UPGRADE_MIRRORS, s.Source.HasMirrors() && s.UseLinkMode, func(streams step.OutStreams) error {
-		return UpgradeMirrorsUsingRsync(s.Connection, s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames)
+	st.RunInternalSubstep(func() error {
+		return EnsureSmokeTestsPassed(s.SmokeTestDir)
 	})
 
-	st.RunConditionally(idl.Substep_UPGRADE_MIRRORS, s.Source.HasMirrors() && !s.UseLinkMode, func(streams step.OutStreams) error {
+	// The source cluster was shut down during execute, so check that the
+	// intermediate cluster has not changed before adding mirrors to it.
+	st.Run(idl.Substep_CHECK_CLUSTER_TOPOLOGY, func(_ step.OutStreams) error {
+		return CheckTopology(s.Connection, s.Intermediate, false)
+	})
+
+	st.Run(idl.Substep_MIGRATE_AUTH_FILES, func(streams step.OutStreams) error {
+		return MigrateAuthFiles(streams, s.agentConns, s.Source, s.Intermediate)
+	})
+
+	st.RunConditionally(idl.Substep_UPGRADE_MIRRORS, s.Source.HasMirrors() && (s.UseLinkMode || s.RsyncMirrors), func(streams step.OutStreams) error {
+		return UpgradeMirrorsUsingRsync(s.Connection, s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames, s.UseLinkMode)
+	})
+
+	st.RunConditionally(idl.Substep_UPGRADE_MIRRORS, s.Source.HasMirrors() && !s.UseLinkMode && !s.RsyncMirrors, func(streams step.OutStreams) error {
 		return UpgradeMirrorsUsingGpAddMirrors(streams, s.Intermediate, s.UseHbaHostnames)
 	})
 
-	st.RunConditionally(idl.Substep_UPGRADE_STANDBY, s.Source.HasStandby(), func(streams step.OutStreams) error {
+	st.RunConditionally(idl.Substep_UPGRADE_STANDBY, s.Source.HasStandby() && s.UseLinkMode, func(streams step.OutStreams) error {
+		return UpgradeStandbyUsingRsync(s.Connection, s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames)
+	})
+
+	st.RunConditionally(idl.Substep_UPGRADE_STANDBY, s.Source.HasStandby() && !s.UseLinkMode, func(streams step.OutStreams) error {
 		return UpgradeStandby(streams, s.Intermediate, s.UseHbaHostnames)
 	})
 
 	st.Run(idl.Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_ADDING_MIRRORS_AND_STANDBY, func(streams step.OutStreams) error {
-		return s.Intermediate.WaitForClusterToBeReady(s.Connection)
+		return s.Intermediate.WaitForClusterToBeReady(s.Connection, s.ReadyTimeout())
 	})
 
 	st.Run(idl.Substep_SHUTDOWN_TARGET_CLUSTER, func(streams step.OutStreams) error {
@@ -72,6 +90,8 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 			s.Target.Version,
 			s.Intermediate,
 			s.Target,
+			s.UseLinkMode,
+			s.RsyncMirrors,
 		)
 	})
 
@@ -80,7 +100,19 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 	})
 
 	st.Run(idl.Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG, func(streams step.OutStreams) error {
-		return s.Target.WaitForClusterToBeReady(s.Connection)
+		return s.Target.WaitForClusterToBeReady(s.Connection, s.ReadyTimeout())
+	})
+
+	st.RunConditionally(idl.Substep_UPGRADE_EXTENSIONS, s.UpgradeExtensions, func(streams step.OutStreams) error {
+		return UpgradeExtensions(streams, s.Connection, s.Target, s.StateDir)
+	})
+
+	// Read the saved failures since UPGRADE_EXTENSIONS is skipped when
+	// finalize is re-run after it completed.
+	var extensionUpgradeFailures []string
+	st.RunInternalSubstep(func() error {
+		extensionUpgradeFailures, err = ReadExtensionUpgradeFailures(s.StateDir)
+		return err
 	})
 
 	st.Run(idl.Substep_STOP_TARGET_CLUSTER, func(streams step.OutStreams) error {
@@ -107,10 +139,12 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 			LogArchiveDirectory:               logArchiveDir,
 			ArchivedSourceMasterDataDirectory: s.Config.Intermediate.MasterDataDir() + upgrade.OldSuffix,
 			UpgradeID:                         s.Config.UpgradeID.String(),
+			ExtensionUpgradeFailures:          extensionUpgradeFailures,
 			TargetCluster: &idl.Cluster{
 				GPHome:              s.Target.GPHome,
 				Port:                int32(s.Target.MasterPort()),
 				MasterDataDirectory: s.Target.MasterDataDir(),
+				Version:             s.Target.Version.String(),
 			},
 		},
 	}}}}
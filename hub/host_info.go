@@ -0,0 +1,148 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bufio"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+var unameCommand = exec.Command
+
+// GetHostInfo gathers the inventory of the local host. Components that cannot
+// be determined, such as a missing target installation or rsync, are left
+// empty and logged so that the caller can report them for every host at once.
+func GetHostInfo(sourceGPHome string, targetGPHome string) (*idl.HostInfo, error) {
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		return nil, xerrors.Errorf("getting hostname: %w", err)
+	}
+
+	info := &idl.HostInfo{
+		Hostname: hostname,
+		Os:       operatingSystem(),
+		Kernel:   kernel(),
+	}
+
+	if info.GpupgradeVersion, err = upgrade.LocalVersion(); err != nil {
+		gplog.Warn("getting gpupgrade version: %v", err)
+	}
+	info.GpupgradeVersion = strings.TrimSpace(info.GpupgradeVersion)
+
+	if info.SourceVersion, err = greenplum.FullVersion(sourceGPHome); err != nil {
+		gplog.Warn("getting source version: %v", err)
+	}
+
+	if info.TargetVersion, err = greenplum.FullVersion(targetGPHome); err != nil {
+		gplog.Warn("getting target version: %v", err)
+	}
+
+	if info.RsyncVersion, err = rsync.Version(); err != nil {
+		gplog.Warn("getting rsync version: %v", err)
+	}
+
+	if info.FreeMemory, err = freeMemory(); err != nil {
+		gplog.Warn("getting free memory: %v", err)
+	}
+
+	return info, nil
+}
+
+func operatingSystem() string {
+	contents, err := utils.System.ReadFile("/etc/os-release")
+	if err != nil {
+		return runtime.GOOS
+	}
+
+	if name := ParseOSRelease(string(contents)); name != "" {
+		return name
+	}
+
+	return runtime.GOOS
+}
+
+// ParseOSRelease returns the PRETTY_NAME of an os-release file.
+func ParseOSRelease(contents string) string {
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "PRETTY_NAME=") {
+			continue
+		}
+
+		return strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), `"'`)
+	}
+
+	return ""
+}
+
+func kernel() string {
+	cmd := unameCommand("uname", "-sr")
+	output, err := cmd.Output()
+	if err != nil {
+		gplog.Warn("%q failed: %v", cmd.String(), err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// freeMemory returns the available memory in kilobytes. It is only supported
+// on Linux and returns zero elsewhere.
+func freeMemory() (uint64, error) {
+	if runtime.GOOS != "linux" {
+		return 0, nil
+	}
+
+	contents, err := utils.System.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseMemAvailable(string(contents))
+}
+
+// ParseMemAvailable returns the MemAvailable value of a /proc/meminfo file in
+// kilobytes, falling back to MemFree on kernels that predate MemAvailable.
+func ParseMemAvailable(contents string) (uint64, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		values[strings.TrimSuffix(fields[0], ":")] = fields[1]
+	}
+
+	for _, key := range []string{"MemAvailable", "MemFree"} {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		kb, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, xerrors.Errorf("parsing %s: %w", key, err)
+		}
+
+		return kb, nil
+	}
+
+	return 0, xerrors.New("MemAvailable and MemFree not found in meminfo")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	contents := `NAME="CentOS Linux"
+VERSION="7 (Core)"
+ID="centos"
+PRETTY_NAME="CentOS Linux 7 (Core)"
+`
+
+	name := hub.ParseOSRelease(contents)
+	if name != "CentOS Linux 7 (Core)" {
+		t.Errorf("got %q want %q", name, "CentOS Linux 7 (Core)")
+	}
+
+	name = hub.ParseOSRelease(`NAME="CentOS Linux"`)
+	if name != "" {
+		t.Errorf("got %q want empty name", name)
+	}
+}
+
+func TestParseMemAvailable(t *testing.T) {
+	t.Run("returns MemAvailable", func(t *testing.T) {
+		kb, err := hub.ParseMemAvailable("MemTotal:       16266396 kB\nMemFree:         1123456 kB\nMemAvailable:    8123456 kB\n")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if kb != 8123456 {
+			t.Errorf("got %d want %d", kb, 8123456)
+		}
+	})
+
+	t.Run("falls back to MemFree", func(t *testing.T) {
+		kb, err := hub.ParseMemAvailable("MemTotal:       16266396 kB\nMemFree:         1123456 kB\n")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if kb != 1123456 {
+			t.Errorf("got %d want %d", kb, 1123456)
+		}
+	})
+
+	t.Run("errors when no value is found", func(t *testing.T) {
+		_, err := hub.ParseMemAvailable("MemTotal:       16266396 kB\n")
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -4,7 +4,6 @@
 package hub
 
 import (
-	"bufio"
 	"database/sql"
 	"fmt"
 	"io/ioutil"
@@ -49,11 +48,25 @@ func (s *Server) writeConf(db *sql.DB) error {
 		return err
 	}
 
-	gpinitsystemConfig, err = GetCheckpointSegmentsAndEncoding(gpinitsystemConfig, s.Source.Version, db)
+	gpinitsystemConfig, err = GetCheckpointSegmentsAndEncoding(gpinitsystemConfig, s.Intermediate.Version, db)
 	if err != nil {
 		return err
 	}
 
+	gpinitsystemConfig, err = GetInitdbSettings(gpinitsystemConfig, db)
+	if err != nil {
+		return err
+	}
+
+	if s.GpinitsystemOverrides != "" {
+		overrides, err := ReadInitsystemOverrides(s.GpinitsystemOverrides)
+		if err != nil {
+			return err
+		}
+
+		gpinitsystemConfig = MergeInitsystemOverrides(gpinitsystemConfig, overrides)
+	}
+
 	gpinitsystemConfig, err = WriteSegmentArray(gpinitsystemConfig, s.Intermediate)
 	if err != nil {
 		return xerrors.Errorf("generating segment array: %w", err)
@@ -109,7 +122,9 @@ func InitTargetCluster(stream step.OutStreams, intermediate *greenplum.Cluster)
 	return intermediate.RunGreenplumCmdWithEnvironment(stream, "gpinitsystem", args, env)
 }
 
-func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version semver.Version, db *sql.DB) ([]string, error) {
+// GetCheckpointSegmentsAndEncoding adds the encoding and checkpoint segments of
+// the source cluster for gpinitsystem of the given target version.
+func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, targetVersion semver.Version, db *sql.DB) ([]string, error) {
 	var encoding string
 	err := db.QueryRow("SELECT current_setting('server_encoding') AS string").Scan(&encoding)
 	if err != nil {
@@ -118,8 +133,10 @@ func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version semve
 
 	gpinitsystemConfig = append(gpinitsystemConfig, fmt.Sprintf("ENCODING=%s", encoding))
 
-	// The 7X guc max_wal_size supersedes checkpoint_segments and its default value is sufficient.
-	if version.Major < 7 {
+	// The 7X guc max_wal_size supersedes checkpoint_segments and its default
+	// value is sufficient, so 7X gpinitsystem no longer accepts
+	// CHECK_POINT_SEGMENTS.
+	if targetVersion.Major < 7 {
 		var checkpointSegments string
 		err := db.QueryRow("SELECT current_setting('checkpoint_segments') AS string").Scan(&checkpointSegments)
 		if err != nil {
@@ -132,6 +149,30 @@ func GetCheckpointSegmentsAndEncoding(gpinitsystemConfig []string, version semve
 	return gpinitsystemConfig, nil
 }
 
+// GetInitdbSettings adds the locale and data checksum settings of the source
+// cluster, which pg_upgrade requires to match between the source and target.
+func GetInitdbSettings(gpinitsystemConfig []string, db *sql.DB) ([]string, error) {
+	var lcCollate, lcCtype, lcMessages, lcMonetary, lcNumeric, lcTime, dataChecksums string
+	row := db.QueryRow(`SELECT current_setting('lc_collate'), current_setting('lc_ctype'), current_setting('lc_messages'),
+    current_setting('lc_monetary'), current_setting('lc_numeric'), current_setting('lc_time'), current_setting('data_checksums');`)
+	err := row.Scan(&lcCollate, &lcCtype, &lcMessages, &lcMonetary, &lcNumeric, &lcTime, &dataChecksums)
+	if err != nil {
+		return gpinitsystemConfig, xerrors.Errorf("retrieve locale and data checksums: %w", err)
+	}
+
+	gpinitsystemConfig = append(gpinitsystemConfig,
+		"LC_COLLATE="+lcCollate,
+		"LC_CTYPE="+lcCtype,
+		"LC_MESSAGES="+lcMessages,
+		"LC_MONETARY="+lcMonetary,
+		"LC_NUMERIC="+lcNumeric,
+		"LC_TIME="+lcTime,
+		"HEAP_CHECKSUM="+dataChecksums,
+	)
+
+	return gpinitsystemConfig, nil
+}
+
 func CreateInitialInitsystemConfig(targetMasterDataDir string, useHbaHostnames bool) ([]string, error) {
 	gpinitsystemConfig := []string{`ARRAY_NAME="gp_upgrade cluster"`}
 
@@ -217,26 +258,14 @@ func GetCatalogVersion(intermediate *greenplum.Cluster) (string, error) {
 		return "", err
 	}
 
-	// parse pg_control data
-	var version string
-	prefix := "Catalog version number:"
-
-	scanner := bufio.NewScanner(strings.NewReader(stream.StdoutBuf.String()))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, prefix) {
-			line = strings.TrimPrefix(line, prefix)
-			version = strings.TrimSpace(line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return "", xerrors.Errorf("scanning pg_controldata: %w", err)
+	controlData, err := greenplum.ParseControlData(stream.StdoutBuf.String())
+	if err != nil {
+		return "", err
 	}
 
-	if version == "" {
+	if controlData.GetCatalogVersion() == "" {
 		return "", ErrUnknownCatalogVersion
 	}
 
-	return version, nil
+	return controlData.GetCatalogVersion(), nil
 }
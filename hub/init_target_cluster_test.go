@@ -47,10 +47,18 @@ Latest checkpoint's REDO location:    0/180001D0
 `)
 }
 
+func pg_controldata_NoCatalogVersion() {
+	os.Stdout.WriteString(`
+pg_control version number:            9420600
+Database cluster state:               in production
+`)
+}
+
 func init() {
 	exectest.RegisterMains(
 		gpinitsystem_Exits1,
 		pg_controldata,
+		pg_controldata_NoCatalogVersion,
 	)
 }
 
@@ -159,6 +167,57 @@ func TestGetCheckpointSegmentsAndEncoding(t *testing.T) {
 	}
 }
 
+func TestGetInitdbSettings(t *testing.T) {
+	t.Run("adds the locale and data checksum settings", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT current_setting\('lc_collate'\).*current_setting\('data_checksums'\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"lc_collate", "lc_ctype", "lc_messages", "lc_monetary", "lc_numeric", "lc_time", "data_checksums"}).
+				AddRow("en_US.utf8", "en_US.utf8", "C", "en_US.utf8", "en_US.utf8", "en_US.utf8", "on"))
+
+		actual, err := GetInitdbSettings([]string{"ENCODING=UTF8"}, db)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{
+			"ENCODING=UTF8",
+			"LC_COLLATE=en_US.utf8",
+			"LC_CTYPE=en_US.utf8",
+			"LC_MESSAGES=C",
+			"LC_MONETARY=en_US.utf8",
+			"LC_NUMERIC=en_US.utf8",
+			"LC_TIME=en_US.utf8",
+			"HEAP_CHECKSUM=on",
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %v, want %v", actual, expected)
+		}
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery("SELECT current_setting").WillReturnError(expected)
+
+		_, err = GetInitdbSettings([]string{}, db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
 func TestWriteSegmentArray(t *testing.T) {
 	test := func(t *testing.T, intermediate *greenplum.Cluster, expected []string) {
 		t.Helper()
@@ -451,10 +510,24 @@ func TestGetCatalogVersion(t *testing.T) {
 		}
 	})
 
-	t.Run("errors when catalog version is not found", func(t *testing.T) {
+	t.Run("errors when pg_controldata output cannot be parsed", func(t *testing.T) {
 		greenplum.SetGreenplumCommand(exectest.NewCommand(Success))
 		defer greenplum.ResetGreenplumCommand()
 
+		version, err := GetCatalogVersion(intermediate)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+
+		if version != "" {
+			t.Errorf("got version %s want empty string", version)
+		}
+	})
+
+	t.Run("errors when catalog version is not found", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(pg_controldata_NoCatalogVersion))
+		defer greenplum.ResetGreenplumCommand()
+
 		version, err := GetCatalogVersion(intermediate)
 		if !errors.Is(err, ErrUnknownCatalogVersion) {
 			t.Errorf("got error %#v want %#v", err, ErrUnknownCatalogVersion)
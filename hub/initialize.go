@@ -15,6 +15,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
@@ -55,6 +56,10 @@ func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_Init
 		return FillConfiguration(s.Config, req, s.Connection, s.SaveConfig)
 	})
 
+	st.Run(idl.Substep_CHECK_SOURCE_CLUSTER_HEALTH, func(streams step.OutStreams) error {
+		return CheckSourceClusterHealth(streams, s.Connection, s.Source, s.ReadyTimeout(), s.Rebalance)
+	})
+
 	// Since the agents might not be up if gpupgrade is not properly installed, check it early on using ssh.
 	st.RunInternalSubstep(func() error {
 		return upgrade.EnsureGpupgradeVersionsMatch(AgentHosts(s.Source))
@@ -65,8 +70,34 @@ func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_Init
 		return err
 	})
 
-	st.RunConditionally(idl.Substep_CHECK_DISK_SPACE, req.GetDiskFreeRatio() > 0, func(streams step.OutStreams) error {
-		return CheckDiskSpace(streams, s.agentConns, req.GetDiskFreeRatio(), s.Source, s.Source.Tablespaces)
+	st.Run(idl.Substep_CHECK_HOSTS, func(streams step.OutStreams) error {
+		return CheckHosts(streams, s.agentConns, s.Source.MasterHostname(), s.Source.GPHome, s.Intermediate.GPHome, s.StateDir)
+	})
+
+	st.RunConditionally(idl.Substep_CHECK_DISK_SPACE, s.DiskSpaceCheckEnabled(), func(streams step.OutStreams) error {
+		return s.checkDiskSpace(streams)
+	})
+
+	st.RunConditionally(idl.Substep_CHECK_LINK_MODE_FILESYSTEMS, s.UseLinkMode, func(streams step.OutStreams) error {
+		return CheckLinkModeFilesystems(s.agentConns, s.Source, s.Intermediate, s.Source.Tablespaces)
+	})
+
+	st.Run(idl.Substep_CHECK_TARGET_PORTS, func(streams step.OutStreams) error {
+		var ports []int
+		for _, p := range req.GetPorts() {
+			ports = append(ports, int(p))
+		}
+
+		err := EnsureTargetPortsAvailable(s.agentConns, s.Source, s.Intermediate, ports, req.GetAutoAssignPorts())
+		if err != nil {
+			return err
+		}
+
+		return s.SaveConfig()
+	})
+
+	st.RunConditionally(idl.Substep_DUMP_SOURCE_SCHEMA, s.DumpSchemas, func(streams step.OutStreams) error {
+		return DumpSchema(streams, s.Source, utils.GetSourceSchemaDump())
 	})
 
 	return st.Err()
@@ -88,6 +119,14 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		}
 	}()
 
+	st.Run(idl.Substep_CHECK_EXTENSIONS, func(streams step.OutStreams) error {
+		return CheckExtensions(streams, s.Connection, s.agentConns, s.Source, s.Intermediate.GPHome, req.GetDynamicLibraryPath())
+	})
+
+	st.Run(idl.Substep_CHECK_LIBRARIES, func(streams step.OutStreams) error {
+		return CheckLibraries(streams, s.Connection, s.agentConns, s.Source, s.Intermediate.GPHome, req.GetDynamicLibraryPath())
+	})
+
 	st.Run(idl.Substep_GENERATE_TARGET_CONFIG, func(_ step.OutStreams) error {
 		return s.GenerateInitsystemConfig()
 	})
@@ -119,6 +158,10 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		return AppendDynamicLibraryPath(s.Intermediate, req.GetDynamicLibraryPath())
 	})
 
+	st.Run(idl.Substep_MIGRATE_SOURCE_SETTINGS, func(streams step.OutStreams) error {
+		return MigrateSettings(streams, s.Connection, s.Source, s.Intermediate)
+	})
+
 	st.Run(idl.Substep_SHUTDOWN_TARGET_CLUSTER, func(stream step.OutStreams) error {
 		return s.Intermediate.Stop(stream)
 	})
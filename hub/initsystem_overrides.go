@@ -0,0 +1,133 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// initsystemOverridePattern matches a gpinitsystem_config parameter such as
+// MASTER_MAX_CONNECT=250.
+var initsystemOverridePattern = regexp.MustCompile(`^([A-Z][A-Z0-9_]*)=(.*)$`)
+
+// managedInitsystemParameters are set by gpupgrade to match the source
+// cluster and cannot be overridden.
+var managedInitsystemParameters = map[string]bool{
+	"SEG_PREFIX":            true,
+	"HBA_HOSTNAMES":         true,
+	"ENCODING":              true,
+	"LOCALE":                true,
+	"LC_ALL":                true,
+	"LC_COLLATE":            true,
+	"LC_CTYPE":              true,
+	"LC_MESSAGES":           true,
+	"LC_MONETARY":           true,
+	"LC_NUMERIC":            true,
+	"LC_TIME":               true,
+	"HEAP_CHECKSUM":         true,
+	"QD_PRIMARY_ARRAY":      true,
+	"PRIMARY_ARRAY":         true,
+	"MIRROR_ARRAY":          true,
+	"MACHINE_LIST_FILE":     true,
+	"MASTER_HOSTNAME":       true,
+	"MASTER_DIRECTORY":      true,
+	"MASTER_PORT":           true,
+	"COORDINATOR_HOSTNAME":  true,
+	"COORDINATOR_DIRECTORY": true,
+	"COORDINATOR_PORT":      true,
+	"PORT_BASE":             true,
+	"DATA_DIRECTORY":        true,
+	"MIRROR_PORT_BASE":      true,
+	"MIRROR_DATA_DIRECTORY": true,
+}
+
+// InitsystemOverride is a parameter of a user supplied gpinitsystem_config
+// overrides file.
+type InitsystemOverride struct {
+	Name  string
+	Value string
+}
+
+func (o InitsystemOverride) String() string {
+	return o.Name + "=" + o.Value
+}
+
+// ReadInitsystemOverrides reads and validates the overrides file at path.
+func ReadInitsystemOverrides(path string) ([]InitsystemOverride, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("reading gpinitsystem overrides: %w", err)
+	}
+
+	overrides, err := ParseInitsystemOverrides(string(contents))
+	if err != nil {
+		return nil, xerrors.Errorf("in gpinitsystem overrides %q: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// ParseInitsystemOverrides parses lines of NAME=VALUE, ignoring blank lines and
+// comments. Every invalid line and parameter managed by gpupgrade is
+// reported.
+func ParseInitsystemOverrides(contents string) ([]InitsystemOverride, error) {
+	var overrides []InitsystemOverride
+	var errs error
+
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		matches := initsystemOverridePattern.FindStringSubmatch(line)
+		if matches == nil {
+			errs = errorlist.Append(errs, fmt.Errorf("line %d: %q is not of the form NAME=VALUE", i+1, line))
+			continue
+		}
+
+		name, value := matches[1], matches[2]
+		if managedInitsystemParameters[name] {
+			errs = errorlist.Append(errs, fmt.Errorf("line %d: %s is set by gpupgrade to match the source cluster and cannot be overridden", i+1, name))
+			continue
+		}
+
+		overrides = append(overrides, InitsystemOverride{Name: name, Value: value})
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return overrides, nil
+}
+
+// MergeInitsystemOverrides replaces the parameters of gpinitsystemConfig that
+// are overridden and appends the remaining overrides.
+func MergeInitsystemOverrides(gpinitsystemConfig []string, overrides []InitsystemOverride) []string {
+	merged := append([]string{}, gpinitsystemConfig...)
+
+	for _, override := range overrides {
+		replaced := false
+		for i, line := range merged {
+			if strings.HasPrefix(line, override.Name+"=") {
+				merged[i] = override.String()
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, override.String())
+		}
+	}
+
+	return merged
+}
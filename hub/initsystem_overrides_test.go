@@ -0,0 +1,104 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestParseInitsystemOverrides(t *testing.T) {
+	t.Run("parses parameters ignoring comments and blank lines", func(t *testing.T) {
+		overrides, err := hub.ParseInitsystemOverrides(`# overrides
+MASTER_MAX_CONNECT=250
+
+  TRUSTED_SHELL=ssh
+ARRAY_NAME="production upgrade"
+`)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []hub.InitsystemOverride{
+			{Name: "MASTER_MAX_CONNECT", Value: "250"},
+			{Name: "TRUSTED_SHELL", Value: "ssh"},
+			{Name: "ARRAY_NAME", Value: `"production upgrade"`},
+		}
+		if !reflect.DeepEqual(overrides, expected) {
+			t.Errorf("got %v want %v", overrides, expected)
+		}
+	})
+
+	t.Run("reports every invalid line and managed parameter", func(t *testing.T) {
+		_, err := hub.ParseInitsystemOverrides("MASTER_MAX_CONNECT 250\nLC_COLLATE=C\nlowercase=1\nHEAP_CHECKSUM=off\nCOORDINATOR_PORT=7000\n")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		for _, expected := range []string{
+			`line 1: "MASTER_MAX_CONNECT 250" is not of the form NAME=VALUE`,
+			"line 2: LC_COLLATE is set by gpupgrade",
+			`line 3: "lowercase=1" is not of the form NAME=VALUE`,
+			"line 4: HEAP_CHECKSUM is set by gpupgrade",
+			"line 5: COORDINATOR_PORT is set by gpupgrade",
+		} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected error %q to contain %q", err, expected)
+			}
+		}
+	})
+}
+
+func TestReadInitsystemOverrides(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	t.Run("reads the overrides file", func(t *testing.T) {
+		path := filepath.Join(dir, "overrides")
+		testutils.MustWriteToFile(t, path, "MASTER_MAX_CONNECT=250\n")
+
+		overrides, err := hub.ReadInitsystemOverrides(path)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []hub.InitsystemOverride{{Name: "MASTER_MAX_CONNECT", Value: "250"}}
+		if !reflect.DeepEqual(overrides, expected) {
+			t.Errorf("got %v want %v", overrides, expected)
+		}
+	})
+
+	t.Run("errors when the file does not exist", func(t *testing.T) {
+		_, err := hub.ReadInitsystemOverrides(filepath.Join(dir, "missing"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+}
+
+func TestMergeInitsystemOverrides(t *testing.T) {
+	config := []string{`ARRAY_NAME="gp_upgrade cluster"`, "SEG_PREFIX=demoDataDir", "ENCODING=UTF8"}
+	overrides := []hub.InitsystemOverride{
+		{Name: "ARRAY_NAME", Value: `"production"`},
+		{Name: "MASTER_MAX_CONNECT", Value: "250"},
+	}
+
+	merged := hub.MergeInitsystemOverrides(config, overrides)
+
+	expected := []string{`ARRAY_NAME="production"`, "SEG_PREFIX=demoDataDir", "ENCODING=UTF8", "MASTER_MAX_CONNECT=250"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("got %v want %v", merged, expected)
+	}
+
+	if config[0] != `ARRAY_NAME="gp_upgrade cluster"` {
+		t.Errorf("expected the original config to be unchanged, got %v", config)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const (
+	PgHbaConf   = "pg_hba.conf"
+	PgIdentConf = "pg_ident.conf"
+)
+
+// migratedEntriesHeader precedes the entries appended from the source cluster.
+const migratedEntriesHeader = "# entries migrated from the source cluster by gpupgrade"
+
+// AuthMethodRule describes a pg_hba.conf authentication method that is no
+// longer accepted starting with the target major version.
+type AuthMethodRule struct {
+	TargetMajor uint64
+	Method      string
+	Reason      string
+}
+
+var authMethodRules = []AuthMethodRule{
+	{TargetMajor: 6, Method: "crypt", Reason: "crypt authentication was removed; use md5"},
+	{TargetMajor: 6, Method: "krb5", Reason: "krb5 authentication was removed; use gss"},
+	{TargetMajor: 7, Method: "crypt", Reason: "crypt authentication was removed; use md5 or scram-sha-256"},
+	{TargetMajor: 7, Method: "krb5", Reason: "krb5 authentication was removed; use gss"},
+	{TargetMajor: 7, Method: "password", Reason: "password authentication sends passwords in clear text; use md5 or scram-sha-256"},
+}
+
+// MigrateAuthFiles merges the pg_hba.conf and pg_ident.conf rules of the
+// source master and primaries into the intermediate cluster, and prints the
+// entries that were added or dropped for review. It runs before the mirrors
+// and standby are created so that they copy the merged files.
+func MigrateAuthFiles(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	changes, err := MergeAuthFiles(source.MasterDataDir(), intermediate.MasterDataDir(), intermediate.Version)
+	if err != nil {
+		return xerrors.Errorf("migrating authentication files on master host: %w", err)
+	}
+
+	var mu sync.Mutex
+	request := func(conn *idl.Connection) error {
+		segments := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsPrimary() && !seg.IsMaster() && seg.IsOnHost(conn.Hostname)
+		})
+		if len(segments) == 0 {
+			return nil
+		}
+		sort.Sort(segments)
+
+		var pairs []*idl.DataDirPair
+		for _, seg := range segments {
+			pairs = append(pairs, &idl.DataDirPair{
+				SourceDataDir: source.Primaries[seg.ContentID].DataDir,
+				TargetDataDir: seg.DataDir,
+			})
+		}
+
+		reply, err := conn.AgentClient.MigrateAuthFiles(context.Background(), &idl.MigrateAuthFilesRequest{
+			DataDirPairs:  pairs,
+			TargetVersion: intermediate.Version.String(),
+		})
+		if err != nil {
+			return xerrors.Errorf("migrating authentication files on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, change := range reply.GetChanges() {
+			changes = append(changes, fmt.Sprintf("host %q %s", conn.Hostname, change))
+		}
+
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(streams.Stdout(), "no authentication rules to migrate from the source cluster")
+		return nil
+	}
+
+	fmt.Fprintln(streams.Stdout(), "migrated authentication rules from the source cluster:")
+	for _, change := range changes {
+		fmt.Fprintln(streams.Stdout(), change)
+	}
+
+	return nil
+}
+
+// MergeAuthFiles merges the pg_hba.conf and pg_ident.conf of the source data
+// directory into the target data directory, and returns the lines that were
+// added or dropped prefixed with the path of the target file. Merging is
+// idempotent since entries already in the target are not added again.
+func MergeAuthFiles(sourceDataDir string, targetDataDir string, targetVersion semver.Version) ([]string, error) {
+	var changes []string
+
+	for _, name := range []string{PgHbaConf, PgIdentConf} {
+		source, err := readAuthFile(filepath.Join(sourceDataDir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		targetPath := filepath.Join(targetDataDir, name)
+		target, err := readAuthFile(targetPath)
+		if err != nil {
+			return nil, err
+		}
+
+		merged, diff := MergeIdent(target, source)
+		if name == PgHbaConf {
+			merged, diff = MergeHba(target, source, targetVersion)
+		}
+
+		for _, line := range diff {
+			changes = append(changes, fmt.Sprintf("%s: %s", targetPath, line))
+		}
+
+		if merged == target {
+			continue
+		}
+
+		if err := utils.AtomicallyWrite(targetPath, []byte(merged)); err != nil {
+			return nil, xerrors.Errorf("writing %q: %w", targetPath, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// readAuthFile returns the contents of path, or an empty string when it does
+// not exist.
+func readAuthFile(path string) (string, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", xerrors.Errorf("reading %q: %w", path, err)
+	}
+
+	return string(contents), nil
+}
+
+// MergeHba appends the entries of the source pg_hba.conf that are not in the
+// target. Entries already in the target, such as those written by
+// gpinitsystem and for replication, are kept first so that they continue to
+// match before the migrated entries. Source entries using an authentication
+// method the target no longer accepts are dropped. The returned diff has a
+// "+" line for each added entry and a "-" line for each dropped one.
+func MergeHba(target string, source string, targetVersion semver.Version) (string, []string) {
+	return mergeEntries(target, source, func(fields []string) string {
+		return droppedAuthMethodReason(fields, targetVersion.Major)
+	})
+}
+
+// MergeIdent appends the user name maps of the source pg_ident.conf that are
+// not in the target.
+func MergeIdent(target string, source string) (string, []string) {
+	return mergeEntries(target, source, func(_ []string) string { return "" })
+}
+
+// mergeEntries appends the source entries that are not in the target and for
+// which drop returns no reason. Entries are compared by their fields so that
+// differences in whitespace and comments are ignored.
+func mergeEntries(target string, source string, drop func(fields []string) string) (string, []string) {
+	existing := make(map[string]bool)
+	for _, fields := range entries(target) {
+		existing[strings.Join(fields, " ")] = true
+	}
+
+	var added []string
+	var diff []string
+	for _, fields := range entries(source) {
+		entry := strings.Join(fields, " ")
+		if existing[entry] {
+			continue
+		}
+		existing[entry] = true
+
+		if reason := drop(fields); reason != "" {
+			diff = append(diff, fmt.Sprintf("- %s (%s)", entry, reason))
+			continue
+		}
+
+		added = append(added, entry)
+		diff = append(diff, fmt.Sprintf("+ %s", entry))
+	}
+
+	if len(added) == 0 {
+		return target, diff
+	}
+
+	merged := target
+	if merged != "" && !strings.HasSuffix(merged, "\n") {
+		merged += "\n"
+	}
+
+	merged += "\n" + migratedEntriesHeader + "\n" + strings.Join(added, "\n") + "\n"
+	return merged, diff
+}
+
+// entries returns the fields of each line that is not blank or a comment.
+func entries(contents string) [][]string {
+	var result [][]string
+	for _, line := range strings.Split(contents, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		result = append(result, fields)
+	}
+
+	return result
+}
+
+// droppedAuthMethodReason returns why the authentication method of the
+// pg_hba.conf entry is not accepted by the target major version, or an empty
+// string when it is.
+func droppedAuthMethodReason(fields []string, targetMajor uint64) string {
+	method := hbaAuthMethod(fields)
+	for _, rule := range authMethodRules {
+		if rule.TargetMajor == targetMajor && rule.Method == method {
+			return rule.Reason
+		}
+	}
+
+	return ""
+}
+
+// hbaAuthMethod returns the authentication method of a pg_hba.conf entry.
+// Local entries have no address, and host entries may give the address as an
+// IP address followed by a separate mask.
+func hbaAuthMethod(fields []string) string {
+	index := 4
+	if fields[0] == "local" {
+		index = 3
+	} else if len(fields) > 5 && net.ParseIP(fields[3]) != nil && net.ParseIP(fields[4]) != nil {
+		index = 5
+	}
+
+	if len(fields) <= index {
+		return ""
+	}
+
+	return fields[index]
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+const targetHba = `# TYPE  DATABASE  USER  ADDRESS  METHOD
+local    all         gpadmin         ident
+host     all         gpadmin         127.0.0.1/28    trust
+host replication gpadmin samehost trust
+`
+
+func TestMergeHba(t *testing.T) {
+	source := `# a comment
+local    all         gpadmin         ident
+host  all  all  10.0.0.0/8  md5   # applications
+host all app 192.168.0.1 255.255.255.255 password
+hostssl all all 0.0.0.0/0 crypt
+local all all krb5
+host all all 10.0.0.0/8 md5
+`
+
+	t.Run("appends source entries not in the target", func(t *testing.T) {
+		merged, diff := hub.MergeHba(targetHba, source, semver.MustParse("6.20.0"))
+
+		expected := targetHba + `
+# entries migrated from the source cluster by gpupgrade
+host all all 10.0.0.0/8 md5
+host all app 192.168.0.1 255.255.255.255 password
+`
+		if merged != expected {
+			t.Errorf("got %q want %q", merged, expected)
+		}
+
+		expectedDiff := []string{
+			"+ host all all 10.0.0.0/8 md5",
+			"+ host all app 192.168.0.1 255.255.255.255 password",
+			"- hostssl all all 0.0.0.0/0 crypt (crypt authentication was removed; use md5)",
+			"- local all all krb5 (krb5 authentication was removed; use gss)",
+		}
+		if !reflect.DeepEqual(diff, expectedDiff) {
+			t.Errorf("got diff %q want %q", diff, expectedDiff)
+		}
+	})
+
+	t.Run("drops password entries for GPDB 7", func(t *testing.T) {
+		merged, diff := hub.MergeHba(targetHba, source, semver.MustParse("7.0.0"))
+
+		if strings.Contains(merged, "password") {
+			t.Errorf("expected password entry to be dropped from %q", merged)
+		}
+
+		expected := "- host all app 192.168.0.1 255.255.255.255 password (password authentication sends passwords in clear text; use md5 or scram-sha-256)"
+		if diff[1] != expected {
+			t.Errorf("got %q want %q", diff[1], expected)
+		}
+	})
+
+	t.Run("does not change the target when there is nothing to add", func(t *testing.T) {
+		merged, diff := hub.MergeHba(targetHba, "local all gpadmin ident\n", semver.MustParse("6.20.0"))
+
+		if merged != targetHba {
+			t.Errorf("got %q want %q", merged, targetHba)
+		}
+
+		if len(diff) != 0 {
+			t.Errorf("got diff %q want none", diff)
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		merged, _ := hub.MergeHba(targetHba, source, semver.MustParse("6.20.0"))
+		remerged, diff := hub.MergeHba(merged, source, semver.MustParse("6.20.0"))
+
+		if remerged != merged {
+			t.Errorf("got %q want %q", remerged, merged)
+		}
+
+		// only the dropped entries are reported again
+		if len(diff) != 2 {
+			t.Errorf("got diff %q want two dropped entries", diff)
+		}
+	})
+}
+
+func TestMergeIdent(t *testing.T) {
+	merged, diff := hub.MergeIdent("# MAPNAME SYSTEM-USERNAME PG-USERNAME", "omicron bryanh bryanh\n# comment\nomicron ann ann\n")
+
+	expected := `# MAPNAME SYSTEM-USERNAME PG-USERNAME
+
+# entries migrated from the source cluster by gpupgrade
+omicron bryanh bryanh
+omicron ann ann
+`
+	if merged != expected {
+		t.Errorf("got %q want %q", merged, expected)
+	}
+
+	expectedDiff := []string{"+ omicron bryanh bryanh", "+ omicron ann ann"}
+	if !reflect.DeepEqual(diff, expectedDiff) {
+		t.Errorf("got diff %q want %q", diff, expectedDiff)
+	}
+}
+
+func TestMergeAuthFiles(t *testing.T) {
+	t.Run("merges both files into the target data directory", func(t *testing.T) {
+		sourceDir := testutils.GetTempDir(t, "source")
+		defer testutils.MustRemoveAll(t, sourceDir)
+
+		targetDir := testutils.GetTempDir(t, "target")
+		defer testutils.MustRemoveAll(t, targetDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, hub.PgHbaConf), "host all all 10.0.0.0/8 md5\n")
+		testutils.MustWriteToFile(t, filepath.Join(sourceDir, hub.PgIdentConf), "omicron ann ann\n")
+		testutils.MustWriteToFile(t, filepath.Join(targetDir, hub.PgHbaConf), targetHba)
+
+		changes, err := hub.MergeAuthFiles(sourceDir, targetDir, semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{
+			filepath.Join(targetDir, hub.PgHbaConf) + ": + host all all 10.0.0.0/8 md5",
+			filepath.Join(targetDir, hub.PgIdentConf) + ": + omicron ann ann",
+		}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("got %q want %q", changes, expected)
+		}
+
+		hba := testutils.MustReadFile(t, filepath.Join(targetDir, hub.PgHbaConf))
+		if !strings.HasPrefix(hba, targetHba) || !strings.HasSuffix(hba, "host all all 10.0.0.0/8 md5\n") {
+			t.Errorf("unexpected pg_hba.conf %q", hba)
+		}
+
+		ident := testutils.MustReadFile(t, filepath.Join(targetDir, hub.PgIdentConf))
+		if !strings.HasSuffix(ident, "omicron ann ann\n") {
+			t.Errorf("unexpected pg_ident.conf %q", ident)
+		}
+	})
+
+	t.Run("skips files missing from the source", func(t *testing.T) {
+		sourceDir := testutils.GetTempDir(t, "source")
+		defer testutils.MustRemoveAll(t, sourceDir)
+
+		targetDir := testutils.GetTempDir(t, "target")
+		defer testutils.MustRemoveAll(t, targetDir)
+
+		changes, err := hub.MergeAuthFiles(sourceDir, targetDir, semver.MustParse("6.20.0"))
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(changes) != 0 {
+			t.Errorf("got changes %q want none", changes)
+		}
+
+		testutils.PathMustNotExist(t, filepath.Join(targetDir, hub.PgHbaConf))
+	})
+
+	t.Run("errors when a file cannot be read", func(t *testing.T) {
+		sourceDir := testutils.GetTempDir(t, "source")
+		defer testutils.MustRemoveAll(t, sourceDir)
+
+		// a directory cannot be read as a file
+		testutils.MustCreateDir(t, filepath.Join(sourceDir, hub.PgHbaConf))
+
+		_, err := hub.MergeAuthFiles(sourceDir, sourceDir, semver.MustParse("6.20.0"))
+		var pathErr *os.PathError
+		if !errors.As(err, &pathErr) {
+			t.Errorf("got error %#v want %T", err, pathErr)
+		}
+	})
+}
+
+func TestMigrateAuthFiles(t *testing.T) {
+	sourceMaster := testutils.GetTempDir(t, "source")
+	defer testutils.MustRemoveAll(t, sourceMaster)
+
+	intermediateMaster := testutils.GetTempDir(t, "intermediate")
+	defer testutils.MustRemoveAll(t, intermediateMaster)
+
+	testutils.MustWriteToFile(t, filepath.Join(sourceMaster, hub.PgHbaConf), "host all all 10.0.0.0/8 md5\n")
+	testutils.MustWriteToFile(t, filepath.Join(intermediateMaster, hub.PgHbaConf), targetHba)
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: sourceMaster, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+	})
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: intermediateMaster, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.AAAAAAAAAAA.1", Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast2/seg.AAAAAAAAAAA.2", Role: greenplum.PrimaryRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+
+	t.Run("merges the master locally and the primaries on their hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MigrateAuthFiles(gomock.Any(), &idl.MigrateAuthFilesRequest{
+			DataDirPairs: []*idl.DataDirPair{
+				{SourceDataDir: "/data/dbfast1/seg1", TargetDataDir: "/data/dbfast1/seg.AAAAAAAAAAA.1"},
+				{SourceDataDir: "/data/dbfast2/seg2", TargetDataDir: "/data/dbfast2/seg.AAAAAAAAAAA.2"},
+			},
+			TargetVersion: "6.20.0",
+		}).Return(&idl.MigrateAuthFilesReply{
+			Changes: []string{"/data/dbfast1/seg.AAAAAAAAAAA.1/pg_hba.conf: + host all all 10.0.0.0/8 md5"},
+		}, nil)
+
+		// mirrors are created from the primaries after the files are merged
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		stdout := new(bytes.Buffer)
+		err := hub.MigrateAuthFiles(testutils.DevNullSpy{OutStream: stdout}, agentConns, source, intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `migrated authentication rules from the source cluster:
+` + filepath.Join(intermediateMaster, hub.PgHbaConf) + `: + host all all 10.0.0.0/8 md5
+host "sdw1" /data/dbfast1/seg.AAAAAAAAAAA.1/pg_hba.conf: + host all all 10.0.0.0/8 md5
+`
+		if stdout.String() != expected {
+			t.Errorf("got %q want %q", stdout.String(), expected)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MigrateAuthFiles(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.MigrateAuthFiles(testutils.DevNullSpy{}, agentConns, source, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
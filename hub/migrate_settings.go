@@ -0,0 +1,306 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/conf"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// Setting is a GUC set in the configuration files of the source cluster, with
+// its value on the master and on the primaries.
+type Setting struct {
+	Name         string
+	MasterValue  string
+	SegmentValue string
+}
+
+// SettingRule describes a GUC that was renamed or removed between major
+// versions. A rule without a NewName drops the setting. Convert, when set,
+// maps the source value to the value of the renamed setting.
+type SettingRule struct {
+	SourceMajor uint64
+	TargetMajor uint64
+	Name        string
+	NewName     string
+	Convert     func(string) string
+	Reason      string
+}
+
+var settingRules = []SettingRule{
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_workfile_compress_algorithm", NewName: "gp_workfile_compression", Convert: workfileCompression, Reason: "replaced by gp_workfile_compression"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "unix_socket_directory", NewName: "unix_socket_directories", Reason: "renamed to unix_socket_directories"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "max_fsm_pages", Reason: "the free space map is managed automatically"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "max_fsm_relations", Reason: "the free space map is managed automatically"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "add_missing_from", Reason: "removed in GPDB 6"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "regex_flavor", Reason: "removed in GPDB 6"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "custom_variable_classes", Reason: "removed in GPDB 6"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "silent_mode", Reason: "removed in GPDB 6"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_connectemc_mode", Reason: "EMC Connect support was removed"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_fts_probe_threadcount", Reason: "removed with the new fault tolerance service"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_email_*", Reason: "email alerts were removed"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_snmp_*", Reason: "SNMP alerts were removed"},
+	{SourceMajor: 5, TargetMajor: 6, Name: "gp_filerep_*", Reason: "file replication was replaced by WAL replication"},
+	{SourceMajor: 6, TargetMajor: 7, Name: "sql_inheritance", Reason: "removed in GPDB 7"},
+	{SourceMajor: 6, TargetMajor: 7, Name: "ssl_renegotiation_limit", Reason: "removed in GPDB 7"},
+	{SourceMajor: 6, TargetMajor: 7, Name: "default_with_oids", Reason: "tables with OIDs are not supported in GPDB 7"},
+	{SourceMajor: 6, TargetMajor: 7, Name: "gp_enable_gpperfmon", Reason: "gpperfmon was removed in GPDB 7"},
+	{SourceMajor: 6, TargetMajor: 7, Name: "gpperfmon_*", Reason: "gpperfmon was removed in GPDB 7"},
+}
+
+// ignoredSettings are configured by gpinitsystem or gpupgrade for the target
+// cluster and must not be copied from the source.
+var ignoredSettings = map[string]bool{
+	"port":                       true,
+	"listen_addresses":           true,
+	"data_directory":             true,
+	"config_file":                true,
+	"hba_file":                   true,
+	"ident_file":                 true,
+	"external_pid_file":          true,
+	"gp_contentid":               true,
+	"gp_dbid":                    true,
+	"gp_num_contents_in_cluster": true,
+	"checkpoint_segments":        true,
+	"dynamic_library_path":       true,
+}
+
+// sourceSpecificSettings refer to the libraries, commands, or directories of
+// the source cluster and are not valid for the target cluster. They are
+// reported as dropped so they can be reviewed and set manually.
+var sourceSpecificSettings = map[string]string{
+	"shared_preload_libraries": "the libraries must be installed for the target cluster",
+	"archive_mode":             "WAL archiving must be configured for the target cluster",
+	"archive_command":          "WAL archiving must be configured for the target cluster",
+	"log_directory":            "the target cluster must not log to the directory of the source cluster",
+}
+
+func workfileCompression(algorithm string) string {
+	if algorithm == "" || algorithm == "none" {
+		return "off"
+	}
+
+	return "on"
+}
+
+func (r SettingRule) matches(name string, sourceMajor uint64, targetMajor uint64) bool {
+	if r.SourceMajor != sourceMajor || r.TargetMajor != targetMajor {
+		return false
+	}
+
+	if strings.HasSuffix(r.Name, "*") {
+		return strings.HasPrefix(name, strings.TrimSuffix(r.Name, "*"))
+	}
+
+	return name == r.Name
+}
+
+// MigrateSettings copies the GUCs set in the configuration files of the
+// source cluster to the running intermediate cluster using gpconfig, and
+// prints a report of the settings that were renamed or dropped.
+func MigrateSettings(streams step.OutStreams, conn *greenplum.Conn, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	settings, err := querySourceSettings(conn, source)
+	if err != nil {
+		return err
+	}
+
+	available, err := queryTargetSettingNames(conn, intermediate)
+	if err != nil {
+		return err
+	}
+
+	migrated, report := MapSettings(settings, source.Version, intermediate.Version, available)
+
+	for _, setting := range migrated {
+		if err := ApplySetting(intermediate, setting); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(streams.Stdout(), "migrated %d settings from the source cluster\n", len(migrated))
+	for _, line := range report {
+		fmt.Fprintln(streams.Stdout(), line)
+	}
+
+	return nil
+}
+
+func querySourceSettings(conn *greenplum.Conn, source *greenplum.Cluster) (settings []Setting, err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToSource(), greenplum.Port(source.MasterPort())))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return SourceSettings(db)
+}
+
+func queryTargetSettingNames(conn *greenplum.Conn, intermediate *greenplum.Cluster) (names map[string]bool, err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToTarget(), greenplum.Port(intermediate.MasterPort())))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return SettingNames(db)
+}
+
+// SourceSettings returns the GUCs set in the master configuration file along
+// with their values on the primaries. Settings that differ between primaries
+// use the value of the first primary.
+func SourceSettings(db *sql.DB) ([]Setting, error) {
+	rows, err := db.Query(`SELECT name, current_setting(name) FROM pg_settings WHERE source = 'configuration file' ORDER BY name;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying pg_settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []Setting
+	for rows.Next() {
+		var s Setting
+		if err := rows.Scan(&s.Name, &s.MasterValue); err != nil {
+			return nil, xerrors.Errorf("scanning pg_settings: %w", err)
+		}
+
+		settings = append(settings, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating pg_settings: %w", err)
+	}
+
+	for i := range settings {
+		row := db.QueryRow(`SELECT paramvalue FROM gp_toolkit.gp_param_setting($1) ORDER BY paramsegment LIMIT 1;`, settings[i].Name)
+		if err := row.Scan(&settings[i].SegmentValue); err != nil {
+			if err == sql.ErrNoRows {
+				settings[i].SegmentValue = settings[i].MasterValue
+				continue
+			}
+
+			return nil, xerrors.Errorf("querying segment value of %q: %w", settings[i].Name, err)
+		}
+	}
+
+	return settings, nil
+}
+
+// SettingNames returns the names of all GUCs.
+func SettingNames(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query(`SELECT name FROM pg_settings;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying pg_settings: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, xerrors.Errorf("scanning pg_settings: %w", err)
+		}
+
+		names[name] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating pg_settings: %w", err)
+	}
+
+	return names, nil
+}
+
+// MapSettings applies the rules between the source and target major versions
+// and drops the settings gpupgrade manages, those specific to the source, and
+// those unknown to the target. It returns the settings to apply and a line describing each one
+// that was renamed or dropped.
+func MapSettings(settings []Setting, sourceVersion semver.Version, targetVersion semver.Version, available map[string]bool) ([]Setting, []string) {
+	var migrated []Setting
+	var report []string
+
+	for _, setting := range settings {
+		if ignoredSettings[setting.Name] {
+			continue
+		}
+
+		if reason, ok := sourceSpecificSettings[setting.Name]; ok {
+			report = append(report, fmt.Sprintf("dropped %s = %s: %s", setting.Name, setting.MasterValue, reason))
+			continue
+		}
+
+		rule, ok := findSettingRule(setting.Name, sourceVersion.Major, targetVersion.Major)
+		if ok && rule.NewName == "" {
+			report = append(report, fmt.Sprintf("dropped %s = %s: %s", setting.Name, setting.MasterValue, rule.Reason))
+			continue
+		}
+
+		if ok {
+			renamed := Setting{Name: rule.NewName, MasterValue: setting.MasterValue, SegmentValue: setting.SegmentValue}
+			if rule.Convert != nil {
+				renamed.MasterValue = rule.Convert(setting.MasterValue)
+				renamed.SegmentValue = rule.Convert(setting.SegmentValue)
+			}
+
+			report = append(report, fmt.Sprintf("changed %s = %s to %s = %s: %s",
+				setting.Name, setting.MasterValue, renamed.Name, renamed.MasterValue, rule.Reason))
+			setting = renamed
+		}
+
+		if !available[setting.Name] {
+			report = append(report, fmt.Sprintf("dropped %s = %s: not supported by the target cluster", setting.Name, setting.MasterValue))
+			continue
+		}
+
+		migrated = append(migrated, setting)
+	}
+
+	sort.Strings(report)
+	return migrated, report
+}
+
+func findSettingRule(name string, sourceMajor uint64, targetMajor uint64) (SettingRule, bool) {
+	for _, rule := range settingRules {
+		if rule.matches(name, sourceMajor, targetMajor) {
+			return rule, true
+		}
+	}
+
+	return SettingRule{}, false
+}
+
+// ApplySetting sets the GUC on the intermediate cluster, using a separate
+// master value when it differs from the primaries.
+func ApplySetting(intermediate *greenplum.Cluster, setting Setting) error {
+	args := []string{"-c", setting.Name, "-v", conf.FormatValue(setting.SegmentValue)}
+	if setting.MasterValue != setting.SegmentValue {
+		args = append(args, "-m", conf.FormatValue(setting.MasterValue))
+	}
+
+	stream := &step.BufferedStreams{}
+	err := intermediate.RunGreenplumCmdWithEnvironment(stream, "gpconfig", args,
+		utils.FilterEnv([]string{"USER"})) // gpconfig requires the USER environment variable
+	if err != nil {
+		return xerrors.Errorf("setting %q: %w", setting.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestSourceSettings(t *testing.T) {
+	t.Run("returns the master and segment values", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(`SELECT name, current_setting\(name\) FROM pg_settings WHERE source = 'configuration file'`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "current_setting"}).
+				AddRow("max_connections", "250").
+				AddRow("statement_mem", "250MB"))
+		mock.ExpectQuery(`SELECT paramvalue FROM gp_toolkit.gp_param_setting`).WithArgs("max_connections").
+			WillReturnRows(sqlmock.NewRows([]string{"paramvalue"}).AddRow("750"))
+		mock.ExpectQuery(`SELECT paramvalue FROM gp_toolkit.gp_param_setting`).WithArgs("statement_mem").
+			WillReturnRows(sqlmock.NewRows([]string{"paramvalue"}))
+
+		settings, err := hub.SourceSettings(db)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []hub.Setting{
+			{Name: "max_connections", MasterValue: "250", SegmentValue: "750"},
+			{Name: "statement_mem", MasterValue: "250MB", SegmentValue: "250MB"},
+		}
+		if !reflect.DeepEqual(settings, expected) {
+			t.Errorf("got settings %v want %v", settings, expected)
+		}
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expected := errors.New("connection failed")
+		mock.ExpectQuery(`SELECT name, current_setting\(name\) FROM pg_settings`).WillReturnError(expected)
+
+		_, err = hub.SourceSettings(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestMapSettings(t *testing.T) {
+	available := map[string]bool{
+		"statement_mem":           true,
+		"gp_workfile_compression": true,
+		"max_connections":         true,
+		"unix_socket_directories": true,
+		"archive_command":         true,
+	}
+
+	settings := []hub.Setting{
+		{Name: "port", MasterValue: "5432", SegmentValue: "40000"},
+		{Name: "statement_mem", MasterValue: "250MB", SegmentValue: "250MB"},
+		{Name: "gp_workfile_compress_algorithm", MasterValue: "zlib", SegmentValue: "zlib"},
+		{Name: "max_fsm_pages", MasterValue: "200000", SegmentValue: "200000"},
+		{Name: "gp_snmp_community", MasterValue: "public", SegmentValue: "public"},
+		{Name: "my_extension.setting", MasterValue: "on", SegmentValue: "on"},
+		{Name: "unix_socket_directory", MasterValue: "/tmp", SegmentValue: "/tmp"},
+		{Name: "archive_command", MasterValue: "cp %p /archive/%f", SegmentValue: "cp %p /archive/%f"},
+	}
+
+	migrated, report := hub.MapSettings(settings, semver.MustParse("5.28.0"), semver.MustParse("6.20.0"), available)
+
+	expectedMigrated := []hub.Setting{
+		{Name: "statement_mem", MasterValue: "250MB", SegmentValue: "250MB"},
+		{Name: "gp_workfile_compression", MasterValue: "on", SegmentValue: "on"},
+		{Name: "unix_socket_directories", MasterValue: "/tmp", SegmentValue: "/tmp"},
+	}
+	if !reflect.DeepEqual(migrated, expectedMigrated) {
+		t.Errorf("got migrated settings %v want %v", migrated, expectedMigrated)
+	}
+
+	expectedReport := []string{
+		"changed gp_workfile_compress_algorithm = zlib to gp_workfile_compression = on: replaced by gp_workfile_compression",
+		"changed unix_socket_directory = /tmp to unix_socket_directories = /tmp: renamed to unix_socket_directories",
+		"dropped archive_command = cp %p /archive/%f: WAL archiving must be configured for the target cluster",
+		"dropped gp_snmp_community = public: SNMP alerts were removed",
+		"dropped max_fsm_pages = 200000: the free space map is managed automatically",
+		"dropped my_extension.setting = on: not supported by the target cluster",
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("got report %q want %q", report, expectedReport)
+	}
+
+	t.Run("only applies rules for the source and target versions", func(t *testing.T) {
+		settings := []hub.Setting{{Name: "max_fsm_pages", MasterValue: "200000", SegmentValue: "200000"}}
+
+		migrated, _ := hub.MapSettings(settings, semver.MustParse("6.20.0"), semver.MustParse("7.0.0"), map[string]bool{"max_fsm_pages": true})
+		if !reflect.DeepEqual(migrated, settings) {
+			t.Errorf("got migrated settings %v want %v", migrated, settings)
+		}
+	})
+}
+
+func TestApplySetting(t *testing.T) {
+	testlog.SetupLogger()
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+	})
+	intermediate.GPHome = "/usr/local/gpdb6"
+
+	cases := []struct {
+		name     string
+		setting  hub.Setting
+		expected string
+	}{
+		{
+			name:     "sets the same value on all segments",
+			setting:  hub.Setting{Name: "statement_mem", MasterValue: "250MB", SegmentValue: "250MB"},
+			expected: "source /usr/local/gpdb6/greenplum_path.sh && /usr/local/gpdb6/bin/gpconfig -c statement_mem -v 250MB",
+		},
+		{
+			name:     "sets a separate master value",
+			setting:  hub.Setting{Name: "search_path", MasterValue: "$user, public", SegmentValue: "public"},
+			expected: "source /usr/local/gpdb6/greenplum_path.sh && /usr/local/gpdb6/bin/gpconfig -c search_path -v public -m \\''$user, public'\\'",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			greenplum.SetGreenplumCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+				if len(args) != 2 || args[1] != c.expected {
+					t.Errorf("got args %q want %q", args, c.expected)
+				}
+			}))
+			defer greenplum.ResetGreenplumCommand()
+
+			if err := hub.ApplySetting(intermediate, c.setting); err != nil {
+				t.Errorf("unexpected error %+v", err)
+			}
+		})
+	}
+
+	t.Run("returns gpconfig errors", func(t *testing.T) {
+		greenplum.SetGreenplumCommand(exectest.NewCommand(hub.Failure))
+		defer greenplum.ResetGreenplumCommand()
+
+		err := hub.ApplySetting(intermediate, hub.Setting{Name: "statement_mem", MasterValue: "250MB", SegmentValue: "250MB"})
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
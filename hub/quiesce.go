@@ -0,0 +1,297 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// The actions taken when the source cluster has activity before it is shut
+// down.
+const (
+	QuiesceAbort     = "abort"
+	QuiesceWait      = "wait"
+	QuiesceTerminate = "terminate"
+)
+
+// DefaultQuiesceTimeout is how long to wait for activity to finish when
+// waiting or terminating sessions.
+const DefaultQuiesceTimeout = 5 * time.Minute
+
+// gpexpandStatusFile is written to the master data directory while gpexpand
+// is setting up an expansion.
+const gpexpandStatusFile = "gpexpand.status"
+
+// utilities that must not be interrupted by shutting down the cluster
+var quiesceUtilities = []string{"gpbackup", "gprestore", "gpcrondump", "gpdbrestore", "gpcopy", "gpexpand"}
+
+var processListCommand = exec.Command
+
+func IsValidQuiesceAction(action string) bool {
+	switch action {
+	case QuiesceAbort, QuiesceWait, QuiesceTerminate:
+		return true
+	}
+
+	return false
+}
+
+// Session is a client connection from pg_stat_activity.
+type Session struct {
+	Pid             int
+	User            string
+	Database        string
+	ApplicationName string
+	ClientAddr      string
+	Query           string
+}
+
+// SourceActivity is the activity preventing a clean shutdown of the source
+// cluster.
+type SourceActivity struct {
+	Sessions  []Session
+	Gpexpand  bool
+	Utilities []string
+}
+
+func (a SourceActivity) Idle() bool {
+	return len(a.Sessions) == 0 && !a.Gpexpand && len(a.Utilities) == 0
+}
+
+func (a SourceActivity) String() string {
+	var lines []string
+	for _, s := range a.Sessions {
+		lines = append(lines, fmt.Sprintf("session %d: user %q database %q application %q client %q query %q",
+			s.Pid, s.User, s.Database, s.ApplicationName, s.ClientAddr, s.Query))
+	}
+
+	if a.Gpexpand {
+		lines = append(lines, fmt.Sprintf("gpexpand is in progress (found %s in the master data directory)", gpexpandStatusFile))
+	}
+
+	for _, u := range a.Utilities {
+		lines = append(lines, fmt.Sprintf("utility running: %s", u))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// QuiesceSourceCluster ensures that no sessions, gpexpand, or backup utilities
+// are active on the source cluster before it is shut down, and forces a
+// checkpoint so that the shutdown is quick.
+func QuiesceSourceCluster(streams step.OutStreams, conn *greenplum.Conn, source *greenplum.Cluster, action string, timeout time.Duration) (err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToSource(), greenplum.Port(source.MasterPort())))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return Quiesce(streams, db, source, action, timeout)
+}
+
+// Quiesce is QuiesceSourceCluster for an open connection. With the abort
+// action any activity is an error. With the wait action activity is polled
+// until it finishes or the timeout is exceeded. With the terminate action
+// sessions are terminated once gpexpand and utilities have finished, since
+// interrupting them or their sessions is not safe.
+func Quiesce(streams step.OutStreams, db *sql.DB, source *greenplum.Cluster, action string, timeout time.Duration) error {
+	activity, err := CheckSourceActivity(db, source)
+	if err != nil {
+		return err
+	}
+
+	if !activity.Idle() {
+		fmt.Fprintf(streams.Stdout(), "found activity on the source cluster:\n%s\n", activity)
+	}
+
+	startTime := time.Now()
+	for !activity.Idle() {
+		switch {
+		case action == QuiesceAbort:
+			return newNotQuiescedError(activity)
+		case time.Since(startTime) > timeout:
+			return xerrors.Errorf("%s timeout exceeded waiting for the source cluster to be idle: %w", timeout, newNotQuiescedError(activity))
+		case action == QuiesceTerminate && !activity.Gpexpand && len(activity.Utilities) == 0:
+			if err := TerminateSessions(streams, db, activity.Sessions); err != nil {
+				return err
+			}
+		}
+
+		time.Sleep(time.Second)
+
+		activity, err = CheckSourceActivity(db, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(streams.Stdout(), "forcing a checkpoint on the source cluster")
+	if _, err := db.Exec("CHECKPOINT;"); err != nil {
+		return xerrors.Errorf("checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// CheckSourceActivity returns the sessions, gpexpand, and utilities active on
+// the source cluster.
+func CheckSourceActivity(db *sql.DB, source *greenplum.Cluster) (SourceActivity, error) {
+	sessions, err := ActiveSessions(db, source.Version)
+	if err != nil {
+		return SourceActivity{}, err
+	}
+
+	gpexpand := true
+	if _, err := utils.System.Stat(filepath.Join(source.MasterDataDir(), gpexpandStatusFile)); err != nil {
+		if !os.IsNotExist(err) {
+			return SourceActivity{}, err
+		}
+
+		gpexpand = false
+	}
+
+	utilities, err := runningUtilities()
+	if err != nil {
+		return SourceActivity{}, err
+	}
+
+	return SourceActivity{Sessions: sessions, Gpexpand: gpexpand, Utilities: utilities}, nil
+}
+
+// ActiveSessions returns the client sessions other than our own.
+func ActiveSessions(db *sql.DB, version semver.Version) ([]Session, error) {
+	rows, err := db.Query(activeSessionsQuery(version))
+	if err != nil {
+		return nil, xerrors.Errorf("querying pg_stat_activity: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []Session
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.Pid, &s.User, &s.Database, &s.ApplicationName, &s.ClientAddr, &s.Query); err != nil {
+			return nil, xerrors.Errorf("scanning pg_stat_activity: %w", err)
+		}
+
+		sessions = append(sessions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating pg_stat_activity: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// activeSessionsQuery lists client sessions. GPDB 5 names the pid and query
+// columns procpid and current_query.
+func activeSessionsQuery(version semver.Version) string {
+	pid, query := "pid", "query"
+	if version.Major == 5 {
+		pid, query = "procpid", "current_query"
+	}
+
+	return fmt.Sprintf(`SELECT %[1]s, coalesce(usename, ''), coalesce(datname, ''), coalesce(application_name, ''),
+    coalesce(host(client_addr), ''), coalesce(%[2]s, '')
+FROM pg_stat_activity
+WHERE %[1]s <> pg_backend_pid()
+ORDER BY %[1]s;`, pid, query)
+}
+
+// TerminateSessions terminates the given sessions with pg_terminate_backend.
+func TerminateSessions(streams step.OutStreams, db *sql.DB, sessions []Session) error {
+	for _, s := range sessions {
+		fmt.Fprintf(streams.Stdout(), "terminating session %d of user %q on database %q\n", s.Pid, s.User, s.Database)
+		if _, err := db.Exec("SELECT pg_terminate_backend($1);", s.Pid); err != nil {
+			return xerrors.Errorf("terminating session %d: %w", s.Pid, err)
+		}
+	}
+
+	return nil
+}
+
+var runningUtilities = RunningUtilities
+
+// RunningUtilities returns the backup and expansion utilities running on the
+// master host.
+func RunningUtilities() ([]string, error) {
+	cmd := processListCommand("ps", "-eo", "pid=,args=")
+	gplog.Debug(cmd.String())
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, xerrors.Errorf("%q failed: %w", cmd.String(), err)
+	}
+
+	return ParseUtilityProcesses(string(output)), nil
+}
+
+// ParseUtilityProcesses returns the processes of ps output that run one of the
+// utilities that must not be interrupted. Python utilities such as gpexpand
+// run under an interpreter, in which case the script is considered.
+func ParseUtilityProcesses(output string) []string {
+	var processes []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		program := filepath.Base(fields[1])
+		if strings.HasPrefix(program, "python") && len(fields) > 2 {
+			program = filepath.Base(fields[2])
+		}
+
+		if isQuiesceUtility(program) {
+			processes = append(processes, strings.Join(fields, " "))
+		}
+	}
+
+	return processes
+}
+
+func isQuiesceUtility(name string) bool {
+	for _, u := range quiesceUtilities {
+		if name == u {
+			return true
+		}
+	}
+
+	return false
+}
+
+type NotQuiescedError struct {
+	Activity SourceActivity
+}
+
+func newNotQuiescedError(activity SourceActivity) NotQuiescedError {
+	return NotQuiescedError{Activity: activity}
+}
+
+func (e NotQuiescedError) Error() string {
+	return fmt.Sprintf(`The source cluster is not idle:
+%s
+
+Stop the activity above, or rerun execute with "--quiesce wait" to wait for it
+or "--quiesce terminate" to terminate sessions.`, e.Activity)
+}
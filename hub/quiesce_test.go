@@ -0,0 +1,176 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestQuiesce(t *testing.T) {
+	masterDataDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, masterDataDir)
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: masterDataDir, Port: 15432, Role: greenplum.PrimaryRole},
+	})
+	source.Version = semver.MustParse("6.20.0")
+
+	columns := []string{"pid", "usename", "datname", "application_name", "client_addr", "query"}
+	expectSessions := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectQuery(`SELECT pid, .* FROM pg_stat_activity\s+WHERE pid <> pg_backend_pid\(\)`).WillReturnRows(rows)
+	}
+	expectCheckpoint := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CHECKPOINT;`).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	hub.SetRunningUtilities(func() ([]string, error) {
+		return nil, nil
+	})
+	defer hub.ResetRunningUtilities()
+
+	t.Run("checkpoints an idle cluster", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expectSessions(mock, sqlmock.NewRows(columns))
+		expectCheckpoint(mock)
+
+		err = hub.Quiesce(step.DevNullStream, db, source, hub.QuiesceAbort, time.Minute)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("aborts when sessions are active", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expectSessions(mock, sqlmock.NewRows(columns).AddRow(123, "app", "sales", "psql", "10.0.0.1", "SELECT 1"))
+
+		err = hub.Quiesce(step.DevNullStream, db, source, hub.QuiesceAbort, time.Minute)
+		var notQuiesced hub.NotQuiescedError
+		if !errors.As(err, &notQuiesced) {
+			t.Fatalf("got error %#v want %T", err, notQuiesced)
+		}
+
+		expected := `session 123: user "app" database "sales" application "psql" client "10.0.0.1" query "SELECT 1"`
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %q want it to contain %q", err, expected)
+		}
+	})
+
+	t.Run("terminates sessions", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expectSessions(mock, sqlmock.NewRows(columns).AddRow(123, "app", "sales", "psql", "10.0.0.1", "SELECT 1"))
+		mock.ExpectExec(`SELECT pg_terminate_backend\(\$1\);`).WithArgs(123).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectSessions(mock, sqlmock.NewRows(columns))
+		expectCheckpoint(mock)
+
+		err = hub.Quiesce(step.DevNullStream, db, source, hub.QuiesceTerminate, time.Minute)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("does not terminate sessions while utilities are running", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		hub.SetRunningUtilities(func() ([]string, error) {
+			return []string{"4242 /usr/local/greenplum-db/bin/gpbackup --dbname sales"}, nil
+		})
+		defer hub.SetRunningUtilities(func() ([]string, error) {
+			return nil, nil
+		})
+
+		expectSessions(mock, sqlmock.NewRows(columns).AddRow(123, "gpadmin", "sales", "", "", "COPY"))
+
+		err = hub.Quiesce(step.DevNullStream, db, source, hub.QuiesceTerminate, -1*time.Second)
+		var notQuiesced hub.NotQuiescedError
+		if !errors.As(err, &notQuiesced) {
+			t.Fatalf("got error %#v want %T", err, notQuiesced)
+		}
+
+		if !strings.Contains(err.Error(), "utility running: 4242 /usr/local/greenplum-db/bin/gpbackup --dbname sales") {
+			t.Errorf("got error %q want it to list gpbackup", err)
+		}
+	})
+
+	t.Run("reports gpexpand in progress", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		statusFile := filepath.Join(masterDataDir, "gpexpand.status")
+		testutils.MustWriteToFile(t, statusFile, "")
+		defer testutils.MustRemoveAll(t, statusFile)
+
+		expectSessions(mock, sqlmock.NewRows(columns))
+
+		err = hub.Quiesce(step.DevNullStream, db, source, hub.QuiesceAbort, time.Minute)
+		if err == nil || !strings.Contains(err.Error(), "gpexpand is in progress") {
+			t.Errorf("got error %v want gpexpand in progress", err)
+		}
+	})
+}
+
+func TestParseUtilityProcesses(t *testing.T) {
+	output := `    1 /sbin/init
+ 4242 /usr/local/greenplum-db/bin/gpbackup --dbname sales
+ 4300 python /usr/local/greenplum-db/bin/gpexpand -i input
+ 4400 vim gpbackup_notes.txt
+ 4500 grep gpbackup
+`
+
+	processes := hub.ParseUtilityProcesses(output)
+
+	expected := []string{
+		"4242 /usr/local/greenplum-db/bin/gpbackup --dbname sales",
+		"4300 python /usr/local/greenplum-db/bin/gpexpand -i input",
+	}
+	if !reflect.DeepEqual(processes, expected) {
+		t.Errorf("got %q want %q", processes, expected)
+	}
+}
+
+func TestIsValidQuiesceAction(t *testing.T) {
+	for _, action := range []string{hub.QuiesceAbort, hub.QuiesceWait, hub.QuiesceTerminate} {
+		if !hub.IsValidQuiesceAction(action) {
+			t.Errorf("expected %q to be valid", action)
+		}
+	}
+
+	if hub.IsValidQuiesceAction("kill") {
+		t.Errorf("expected %q to be invalid", "kill")
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"sync"
 
+	"github.com/blang/semver/v4"
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
@@ -60,7 +63,7 @@ func RsyncMasterAndPrimariesTablespaces(stream step.OutStreams, agentConns []*id
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		errs <- RsyncMasterTablespaces(stream, source.StandbyHostname(), source.Tablespaces[source.Master().DbID], source.Tablespaces[source.Standby().DbID])
+		errs <- RsyncMasterTablespaces(stream, source, source.Tablespaces)
 	}()
 
 	errs <- RsyncPrimariesTablespaces(agentConns, source, source.Tablespaces)
@@ -82,8 +85,8 @@ func Recoverseg(stream step.OutStreams, cluster *greenplum.Cluster, useHbaHostna
 		hbaHostnames = "--hba-hostnames"
 	}
 
-	script := fmt.Sprintf("source %[1]s/greenplum_path.sh && MASTER_DATA_DIRECTORY=%[2]s PGPORT=%[3]d %[1]s/bin/gprecoverseg -a %[4]s",
-		cluster.GPHome, cluster.MasterDataDir(), cluster.MasterPort(), hbaHostnames)
+	script := fmt.Sprintf("source %[1]s/greenplum_path.sh && %[2]s=%[3]s PGPORT=%[4]d %[1]s/bin/gprecoverseg -a %[5]s",
+		cluster.GPHome, greenplum.MasterDataDirectoryEnv(cluster.Version), cluster.MasterDataDir(), cluster.MasterPort(), hbaHostnames)
 	cmd := RecoversegCmd("bash", "-c", script)
 
 	cmd.Stdout = stream.Stdout()
@@ -106,16 +109,32 @@ func RsyncMaster(stream step.OutStreams, standby greenplum.SegConfig, master gre
 	return rsync.Rsync(opts...)
 }
 
-func RsyncMasterTablespaces(stream step.OutStreams, standbyHostname string, masterTablespaces greenplum.SegmentTablespaces, standbyTablespaces greenplum.SegmentTablespaces) error {
-	for oid, masterTsInfo := range masterTablespaces {
+// segmentTablespaceDir returns the directory containing the tablespace files
+// of a segment. 5X tablespace locations are unique to each segment, while 6X
+// tablespace locations are shared by the segments which each use a
+// subdirectory named by their dbid.
+func segmentTablespaceDir(version semver.Version, tsInfo greenplum.TablespaceInfo, dbid int) string {
+	if version.Major == 5 {
+		return tsInfo.Location
+	}
+
+	return filepath.Join(tsInfo.Location, strconv.Itoa(dbid))
+}
+
+func RsyncMasterTablespaces(stream step.OutStreams, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
+	master := source.Master()
+	standby := source.Standby()
+
+	standbyTablespaces := tablespaces[standby.DbID]
+	for oid, masterTsInfo := range tablespaces[master.DbID] {
 		if !masterTsInfo.IsUserDefined() {
 			continue
 		}
 
 		opts := []rsync.Option{
-			rsync.WithSourceHost(standbyHostname),
-			rsync.WithSources(standbyTablespaces[oid].Location + string(os.PathSeparator)),
-			rsync.WithDestination(masterTsInfo.Location),
+			rsync.WithSourceHost(standby.Hostname),
+			rsync.WithSources(segmentTablespaceDir(source.Version, standbyTablespaces[oid], standby.DbID) + string(os.PathSeparator)),
+			rsync.WithDestination(segmentTablespaceDir(source.Version, masterTsInfo, master.DbID)),
 			rsync.WithOptions(Options...),
 			rsync.WithStream(stream),
 		}
@@ -181,9 +200,9 @@ func RsyncPrimariesTablespaces(agentConns []*idl.Connection, source *greenplum.C
 				}
 
 				opt := &idl.RsyncRequest_RsyncOptions{
-					Sources:         []string{mirrorTsInfo.Location + string(os.PathSeparator)},
+					Sources:         []string{segmentTablespaceDir(source.Version, mirrorTsInfo, mirror.DbID) + string(os.PathSeparator)},
 					DestinationHost: primary.Hostname,
-					Destination:     primaryTablespaces[oid].Location,
+					Destination:     segmentTablespaceDir(source.Version, primaryTablespaces[oid], primary.DbID),
 					Options:         Options,
 					ExcludedFiles:   Excludes,
 				}
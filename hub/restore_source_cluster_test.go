@@ -49,6 +49,15 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 
 	tablespaces := testutils.CreateTablespaces()
 
+	// 6X segments share the tablespace location and use a subdirectory named
+	// by their dbid.
+	tablespaces6X := greenplum.Tablespaces{}
+	for dbid := 1; dbid <= 6; dbid++ {
+		tablespaces6X[dbid] = greenplum.SegmentTablespaces{
+			16384: greenplum.TablespaceInfo{Location: "/data/tblspc", UserDefined: 1},
+		}
+	}
+
 	t.Run("restores master in link mode using correct rsync arguments", func(t *testing.T) {
 		defer rsync.ResetRsyncCommand()
 		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
@@ -111,7 +120,25 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 			}
 		}))
 
-		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster.StandbyHostname(), tablespaces[cluster.Master().DbID], tablespaces[cluster.Standby().DbID])
+		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster, tablespaces)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("restores the master dbid directory of 6X tablespaces", func(t *testing.T) {
+		cluster6X := *cluster
+		cluster6X.Version = semver.MustParse("6.20.0")
+
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			expected := append(append([]string{}, hub.Options...), "standby:/data/tblspc/2/", "/data/tblspc/1")
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, &cluster6X, tablespaces6X)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -229,6 +256,52 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 		}
 	})
 
+	t.Run("restores the primary dbid directories of 6X tablespaces", func(t *testing.T) {
+		cluster6X := *cluster
+		cluster6X.Version = semver.MustParse("6.20.0")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		msdw1 := mock_idl.NewMockAgentClient(ctrl)
+		msdw1.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{{
+					Sources:         []string{"/data/tblspc/4" + string(os.PathSeparator)},
+					DestinationHost: "sdw1",
+					Destination:     "/data/tblspc/3",
+					Options:         hub.Options,
+					ExcludedFiles:   hub.Excludes,
+				}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		msdw2 := mock_idl.NewMockAgentClient(ctrl)
+		msdw2.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{{
+					Sources:         []string{"/data/tblspc/6" + string(os.PathSeparator)},
+					DestinationHost: "sdw2",
+					Destination:     "/data/tblspc/5",
+					Options:         hub.Options,
+					ExcludedFiles:   hub.Excludes,
+				}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: msdw1, Hostname: "msdw1"},
+			{AgentClient: msdw2, Hostname: "msdw2"},
+		}
+
+		err := hub.RsyncPrimariesTablespaces(agentConns, &cluster6X, tablespaces6X)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
 	t.Run("errors when restoring the master fails in link mode", func(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
@@ -243,7 +316,7 @@ func TestRsyncMasterAndPrimaries(t *testing.T) {
 		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster.MasterHostname(), tablespaces[greenplum.MasterDbid], tablespaces[cluster.Standby().DbID])
+		err := hub.RsyncMasterTablespaces(&testutils.DevNullWithClose{}, cluster, tablespaces)
 		if err == nil {
 			t.Error("unexpected nil error")
 		}
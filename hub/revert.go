@@ -157,6 +157,7 @@ func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer)
 			Source: &idl.Cluster{
 				Port:                int32(s.Source.MasterPort()),
 				MasterDataDirectory: s.Source.MasterDataDir(),
+				Version:             s.Source.Version.String(),
 			},
 		},
 	}}}}
@@ -36,3 +36,21 @@ func ExecuteRPC(agentConns []*idl.Connection, executeRequest func(conn *idl.Conn
 
 	return err
 }
+
+// ExecuteOnMasterAndAgents runs local on the master host and executeRequest
+// on every other agent host. Agents are only started on the hosts of the
+// standby and segments, so the master host is always handled locally and
+// any agent running there is skipped.
+func ExecuteOnMasterAndAgents(agentConns []*idl.Connection, masterHost string, local func() error, executeRequest func(conn *idl.Connection) error) error {
+	if err := local(); err != nil {
+		return err
+	}
+
+	return ExecuteRPC(agentConns, func(conn *idl.Connection) error {
+		if conn.Hostname == masterHost {
+			return nil
+		}
+
+		return executeRequest(conn)
+	})
+}
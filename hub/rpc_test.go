@@ -67,3 +67,64 @@ func TestExecuteRPC(t *testing.T) {
 		}
 	})
 }
+
+func TestExecuteOnMasterAndAgents(t *testing.T) {
+	agentConns := []*idl.Connection{
+		{Hostname: "mdw"},
+		{Hostname: "sdw1"},
+		{Hostname: "sdw2"},
+	}
+
+	t.Run("runs locally on the master host and remotely on the other hosts", func(t *testing.T) {
+		ranLocally := false
+		local := func() error {
+			ranLocally = true
+			return nil
+		}
+
+		hosts := make(chan string, len(agentConns))
+		request := func(conn *idl.Connection) error {
+			hosts <- conn.Hostname
+			return nil
+		}
+
+		err := hub.ExecuteOnMasterAndAgents(agentConns, "mdw", local, request)
+		if err != nil {
+			t.Errorf("ExecuteOnMasterAndAgents returned error %+v", err)
+		}
+
+		close(hosts)
+
+		if !ranLocally {
+			t.Errorf("expected the master host to be handled locally")
+		}
+
+		var actual []string
+		for host := range hosts {
+			actual = append(actual, host)
+		}
+
+		expected := []string{"sdw1", "sdw2"}
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("got %v want %v", actual, expected)
+		}
+	})
+
+	t.Run("does not make requests when the local function fails", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		local := func() error {
+			return expected
+		}
+
+		request := func(conn *idl.Connection) error {
+			t.Errorf("unexpected request to host %q", conn.Hostname)
+			return nil
+		}
+
+		err := hub.ExecuteOnMasterAndAgents(agentConns, "mdw", local, request)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
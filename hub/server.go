@@ -371,6 +371,50 @@ type Config struct {
 	UseLinkMode     bool
 	UseHbaHostnames bool
 	UpgradeID       upgrade.ID
+
+	// DataValidation is the mode used to snapshot the quiesced source data and
+	// validate the target data, both during execute.
+	DataValidation string
+
+	// DumpSchemas takes schema-only dumps of the source during initialize and
+	// the target during execute so they can be compared with compare-dumps.
+	DumpSchemas bool
+
+	// SmokeTestDir is the directory of SQL smoke tests run against the target
+	// cluster at the end of execute. Empty when no smoke tests are configured.
+	SmokeTestDir string
+
+	// UpgradeExtensions updates outdated extensions in every database of the
+	// target cluster at the end of finalize.
+	UpgradeExtensions bool
+
+	// DiskFreeRatio is the fraction of each filesystem that must be free when
+	// checking disk space. It is only used when EstimateDiskSpace is false,
+	// and zero disables the check.
+	DiskFreeRatio float64
+
+	// EstimateDiskSpace estimates the disk space required by the upgrade from
+	// the size of the source cluster rather than using DiskFreeRatio.
+	EstimateDiskSpace bool
+
+	// ClusterReadyTimeout is how long to wait for the segments of a cluster
+	// to be up, in their preferred roles, and synchronized. Zero uses
+	// greenplum.DefaultReadyTimeout.
+	ClusterReadyTimeout time.Duration
+
+	// Rebalance runs gprecoverseg -r during initialize when source segments
+	// are not in their preferred roles.
+	Rebalance bool
+
+	// GpinitsystemOverrides is a file of gpinitsystem_config parameters
+	// merged into the configuration of the target cluster. Empty when there
+	// are no overrides.
+	GpinitsystemOverrides string
+
+	// RsyncMirrors upgrades the mirrors in copy mode by rsyncing the upgraded
+	// primaries into new mirror data directories seeded from the source
+	// mirrors rather than with gpaddmirrors. Link mode always uses rsync.
+	RsyncMirrors bool
 }
 
 func (c *Config) Load(r io.Reader) error {
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/testutils"
@@ -34,6 +35,16 @@ func TestConfig(t *testing.T) {
 			false,           // UseLinkMode
 			false,           // UseHbaHostnames
 			upgrade.NewID(), // UpgradeID
+			"exact",         // DataValidation
+			true,            // DumpSchemas
+			"/smoke/tests",  // SmokeTestDir
+			true,            // UpgradeExtensions
+			0.6,             // DiskFreeRatio
+			false,           // EstimateDiskSpace
+			time.Minute,     // ClusterReadyTimeout
+			true,            // Rebalance
+			"/overrides",    // GpinitsystemOverrides
+			true,            // RsyncMirrors
 		}
 
 		buf := new(bytes.Buffer)
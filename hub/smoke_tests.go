@@ -0,0 +1,165 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// SmokeTestOptionalHeader marks a smoke test as optional when it is the first
+// line of the test file. Failures of optional tests are reported but do not
+// block finalize.
+const SmokeTestOptionalHeader = "-- gpupgrade: optional"
+
+type SmokeTestResult struct {
+	Name     string
+	Required bool
+	Err      error
+}
+
+func (r SmokeTestResult) String() string {
+	kind := "required"
+	if !r.Required {
+		kind = "optional"
+	}
+
+	if r.Err != nil {
+		return fmt.Sprintf("FAIL  %s (%s): %v", r.Name, kind, r.Err)
+	}
+
+	return fmt.Sprintf("PASS  %s (%s)", r.Name, kind)
+}
+
+// RunSmokeTests runs each *.sql file in testDir with psql against the cluster
+// and reports the result of each test. When a test has a matching *.out file
+// its output must match, otherwise the test passes if psql succeeds. The
+// output of each test is written to resultsDir. An error is returned if any
+// required test fails.
+func RunSmokeTests(streams step.OutStreams, cluster *greenplum.Cluster, testDir string, resultsDir string) error {
+	tests, err := filepath.Glob(filepath.Join(testDir, "*.sql"))
+	if err != nil {
+		return xerrors.Errorf("finding smoke tests: %w", err)
+	}
+
+	if len(tests) == 0 {
+		return xerrors.Errorf("no smoke tests found in %q", testDir)
+	}
+	sort.Strings(tests)
+
+	if err := os.MkdirAll(resultsDir, 0700); err != nil {
+		return xerrors.Errorf("create smoke test results directory: %w", err)
+	}
+
+	var errs error
+	for _, test := range tests {
+		result := RunSmokeTest(cluster, test, resultsDir)
+
+		if _, err := fmt.Fprintln(streams.Stdout(), result); err != nil {
+			return err
+		}
+
+		if result.Required && result.Err != nil {
+			errs = errorlist.Append(errs, xerrors.Errorf("smoke test %q: %w", result.Name, result.Err))
+		}
+	}
+
+	return errs
+}
+
+func RunSmokeTest(cluster *greenplum.Cluster, path string, resultsDir string) SmokeTestResult {
+	name := strings.TrimSuffix(filepath.Base(path), ".sql")
+	result := SmokeTestResult{Name: name, Required: true}
+
+	required, err := isRequiredSmokeTest(path)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Required = required
+
+	stream := &step.BufferedStreams{}
+	err = cluster.RunGreenplumCmd(stream, "psql", "-X", "-a", "-q", "-v", "ON_ERROR_STOP=1", "-d", "postgres", "-f", path)
+
+	actualPath := filepath.Join(resultsDir, name+".out")
+	if wErr := utils.AtomicallyWrite(actualPath, stream.StdoutBuf.Bytes()); wErr != nil {
+		err = errorlist.Append(err, wErr)
+	}
+
+	if err != nil {
+		result.Err = err
+		if stderr := strings.TrimSpace(stream.StderrBuf.String()); stderr != "" {
+			result.Err = xerrors.Errorf("%s: %w", stderr, err)
+		}
+		return result
+	}
+
+	expectedPath := strings.TrimSuffix(path, ".sql") + ".out"
+	expected, err := ioutil.ReadFile(expectedPath)
+	if os.IsNotExist(err) {
+		// Without expected output the test only asserts that no error occurred.
+		return result
+	}
+
+	if err != nil {
+		result.Err = xerrors.Errorf("read expected output: %w", err)
+		return result
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(stream.StdoutBuf.Bytes())) {
+		result.Err = xerrors.Errorf("output differs from %q. See %q for the actual output.", expectedPath, actualPath)
+	}
+
+	return result
+}
+
+func isRequiredSmokeTest(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text()) != SmokeTestOptionalHeader, nil
+	}
+
+	return true, scanner.Err()
+}
+
+// EnsureSmokeTestsPassed prevents finalize from running when smoke tests are
+// configured and the required tests have not passed during execute.
+func EnsureSmokeTestsPassed(smokeTestDir string) error {
+	if smokeTestDir == "" {
+		return nil
+	}
+
+	passed, err := step.HasCompleted(idl.Step_EXECUTE, idl.Substep_RUN_SMOKE_TESTS)
+	if err != nil {
+		return err
+	}
+
+	if !passed {
+		return utils.NewNextActionErr(
+			xerrors.New("required smoke tests have not passed against the target cluster"),
+			`Fix the failing smoke tests in `+smokeTestDir+` and re-run "gpupgrade execute" before running "gpupgrade finalize".`)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const smokeTestOutput = "SELECT count(*) FROM foo;\n count \n-------\n    10\n(1 row)\n"
+
+func psql_PrintsOutput() {
+	fmt.Print(smokeTestOutput)
+}
+
+func psql_Errors() {
+	fmt.Fprint(os.Stderr, `psql:test.sql:1: ERROR:  relation "foo" does not exist`)
+	os.Exit(3)
+}
+
+func init() {
+	exectest.RegisterMains(
+		psql_PrintsOutput,
+		psql_Errors,
+	)
+}
+
+func TestRunSmokeTests(t *testing.T) {
+	testlog.SetupLogger()
+
+	cluster := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+	})
+
+	setup := func(t *testing.T) (string, string) {
+		dir := testutils.GetTempDir(t, "")
+		testDir := filepath.Join(dir, "tests")
+		resultsDir := filepath.Join(dir, "results")
+		testutils.MustCreateDir(t, testDir)
+		return testDir, resultsDir
+	}
+
+	t.Run("passes tests that match their expected output or do not error", func(t *testing.T) {
+		testDir, resultsDir := setup(t)
+		defer testutils.MustRemoveAll(t, filepath.Dir(testDir))
+
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "count.sql"), "SELECT count(*) FROM foo;\n")
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "count.out"), smokeTestOutput)
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "no_error.sql"), "SELECT 1;\n")
+
+		var args [][]string
+		greenplum.SetGreenplumCommand(exectest.NewCommandWithVerifier(psql_PrintsOutput, func(utility string, a ...string) {
+			args = append(args, a)
+		}))
+		defer greenplum.ResetGreenplumCommand()
+
+		streams := &step.BufferedStreams{}
+		err := hub.RunSmokeTests(streams, cluster, testDir, resultsDir)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		expected := "PASS  count (required)\nPASS  no_error (required)\n"
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got output %q want %q", streams.StdoutBuf.String(), expected)
+		}
+
+		if len(args) != 2 || !strings.HasSuffix(args[0][1], "bin/psql -X -a -q -v ON_ERROR_STOP=1 -d postgres -f "+filepath.Join(testDir, "count.sql")) {
+			t.Errorf("got args %q", args)
+		}
+
+		testutils.PathMustExist(t, filepath.Join(resultsDir, "count.out"))
+	})
+
+	t.Run("fails when a required test does not match its expected output", func(t *testing.T) {
+		testDir, resultsDir := setup(t)
+		defer testutils.MustRemoveAll(t, filepath.Dir(testDir))
+
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "count.sql"), "SELECT count(*) FROM foo;\n")
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "count.out"), "(0 rows)\n")
+
+		greenplum.SetGreenplumCommand(exectest.NewCommand(psql_PrintsOutput))
+		defer greenplum.ResetGreenplumCommand()
+
+		streams := &step.BufferedStreams{}
+		err := hub.RunSmokeTests(streams, cluster, testDir, resultsDir)
+		if err == nil || !strings.Contains(err.Error(), "output differs") {
+			t.Errorf("got error %+v, want output mismatch", err)
+		}
+
+		if !strings.HasPrefix(streams.StdoutBuf.String(), "FAIL  count (required)") {
+			t.Errorf("got output %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("fails when a required test errors", func(t *testing.T) {
+		testDir, resultsDir := setup(t)
+		defer testutils.MustRemoveAll(t, filepath.Dir(testDir))
+
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "missing.sql"), "SELECT * FROM foo;\n")
+
+		greenplum.SetGreenplumCommand(exectest.NewCommand(psql_Errors))
+		defer greenplum.ResetGreenplumCommand()
+
+		err := hub.RunSmokeTests(step.DevNullStream, cluster, testDir, resultsDir)
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("got error %#v want %T", err, exitErr)
+		}
+
+		if !strings.Contains(err.Error(), `relation "foo" does not exist`) {
+			t.Errorf("expected error %q to contain stderr", err)
+		}
+	})
+
+	t.Run("reports but does not fail on optional test failures", func(t *testing.T) {
+		testDir, resultsDir := setup(t)
+		defer testutils.MustRemoveAll(t, filepath.Dir(testDir))
+
+		testutils.MustWriteToFile(t, filepath.Join(testDir, "optional.sql"), hub.SmokeTestOptionalHeader+"\nSELECT * FROM foo;\n")
+
+		greenplum.SetGreenplumCommand(exectest.NewCommand(psql_Errors))
+		defer greenplum.ResetGreenplumCommand()
+
+		streams := &step.BufferedStreams{}
+		err := hub.RunSmokeTests(streams, cluster, testDir, resultsDir)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		if !strings.HasPrefix(streams.StdoutBuf.String(), "FAIL  optional (optional)") {
+			t.Errorf("got output %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("errors when there are no tests", func(t *testing.T) {
+		testDir, resultsDir := setup(t)
+		defer testutils.MustRemoveAll(t, filepath.Dir(testDir))
+
+		err := hub.RunSmokeTests(step.DevNullStream, cluster, testDir, resultsDir)
+		if err == nil || !strings.Contains(err.Error(), "no smoke tests found") {
+			t.Errorf("got error %+v, want no smoke tests found", err)
+		}
+	})
+}
+
+func TestEnsureSmokeTestsPassed(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("succeeds when no smoke tests are configured", func(t *testing.T) {
+		if err := hub.EnsureSmokeTestsPassed(""); err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("errors with a next action when the smoke tests have not passed", func(t *testing.T) {
+		store, err := step.NewSubstepFileStore()
+		if err != nil {
+			t.Fatalf("step.NewSubstepFileStore returned error %+v", err)
+		}
+
+		if err := store.Write(idl.Step_EXECUTE, idl.Substep_RUN_SMOKE_TESTS, idl.Status_FAILED); err != nil {
+			t.Fatalf("store.Write returned error %+v", err)
+		}
+
+		err = hub.EnsureSmokeTestsPassed("/smoke/tests")
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Errorf("got error %#v want %T", err, nextActionErr)
+		}
+	})
+
+	t.Run("succeeds when the smoke tests have passed", func(t *testing.T) {
+		store, err := step.NewSubstepFileStore()
+		if err != nil {
+			t.Fatalf("step.NewSubstepFileStore returned error %+v", err)
+		}
+
+		if err := store.Write(idl.Step_EXECUTE, idl.Substep_RUN_SMOKE_TESTS, idl.Status_COMPLETE); err != nil {
+			t.Fatalf("store.Write returned error %+v", err)
+		}
+
+		if err := hub.EnsureSmokeTestsPassed("/smoke/tests"); err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+}
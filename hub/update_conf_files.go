@@ -5,9 +5,8 @@ package hub
 
 import (
 	"context"
-	"fmt"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/blang/semver/v4"
@@ -16,16 +15,17 @@ import (
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/conf"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version semver.Version, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
+func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version semver.Version, intermediate *greenplum.Cluster, target *greenplum.Cluster, useLinkMode bool, rsyncMirrors bool) error {
 	if version.Major < 7 {
 		// update gpperfmon.conf on master
 		err := UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{
-			Path:        filepath.Join(target.MasterDataDir(), "gpperfmon", "conf", "gpperfmon.conf"),
-			Pattern:     `^log_location = .*$`,
-			Replacement: fmt.Sprintf("log_location = %s", filepath.Join(target.MasterDataDir(), "gpperfmon", "logs")),
+			Path:     filepath.Join(target.MasterDataDir(), "gpperfmon", "conf", "gpperfmon.conf"),
+			Name:     "log_location",
+			NewValue: filepath.Join(target.MasterDataDir(), "gpperfmon", "logs"),
 		}})
 		if err != nil {
 			return err
@@ -34,15 +34,16 @@ func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version se
 
 	// update postgresql.conf on master
 	err := UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{
-		Path:        filepath.Join(target.MasterDataDir(), "postgresql.conf"),
-		Pattern:     fmt.Sprintf(`(^port[ \t]*=[ \t]*)%d([^0-9]|$)`, intermediate.MasterPort()),
-		Replacement: fmt.Sprintf(`\1%d\2`, target.MasterPort()),
+		Path:     filepath.Join(target.MasterDataDir(), "postgresql.conf"),
+		Name:     "port",
+		OldValue: strconv.Itoa(intermediate.MasterPort()),
+		NewValue: strconv.Itoa(target.MasterPort()),
 	}})
 	if err != nil {
 		return err
 	}
 
-	if err := UpdatePostgresqlConfOnSegments(agentConns, intermediate, target); err != nil {
+	if err := UpdatePostgresqlConfOnSegments(agentConns, intermediate, target, useLinkMode, rsyncMirrors); err != nil {
 		return err
 	}
 
@@ -53,9 +54,23 @@ func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version se
 	return nil
 }
 
-func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
-	pattern := `(^port[ \t]*=[ \t]*)%d([^0-9]|$)`
-	replacement := `\1%d\2`
+// UpdatePostgresqlConfOnSegments sets the port of every segment to its target
+// port. A standby or mirror rsync'd from its primary has the port of the
+// intermediate primary, while one created by gpinitstandby or gpaddmirrors has
+// its own intermediate port.
+func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *greenplum.Cluster, target *greenplum.Cluster, useLinkMode bool, rsyncMirrors bool) error {
+	standbyPort := intermediate.StandbyPort()
+	if useLinkMode {
+		standbyPort = intermediate.MasterPort()
+	}
+
+	mirrorPort := func(contentID int) int {
+		if useLinkMode || rsyncMirrors {
+			return intermediate.Primaries[contentID].Port
+		}
+
+		return intermediate.Mirrors[contentID].Port
+	}
 
 	request := func(conn *idl.Connection) error {
 		var opts []*idl.UpdateFileConfOptions
@@ -63,9 +78,10 @@ func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *
 		// add standby
 		if target.Standby().Hostname == conn.Hostname {
 			opt := &idl.UpdateFileConfOptions{
-				Path:        filepath.Join(target.StandbyDataDir(), "postgresql.conf"),
-				Pattern:     fmt.Sprintf(pattern, intermediate.StandbyPort()),
-				Replacement: fmt.Sprintf(replacement, target.StandbyPort()),
+				Path:     filepath.Join(target.StandbyDataDir(), "postgresql.conf"),
+				Name:     "port",
+				OldValue: strconv.Itoa(standbyPort),
+				NewValue: strconv.Itoa(target.StandbyPort()),
 			}
 
 			opts = append(opts, opt)
@@ -78,9 +94,10 @@ func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *
 
 		for _, mirror := range mirrors {
 			opt := &idl.UpdateFileConfOptions{
-				Path:        filepath.Join(mirror.DataDir, "postgresql.conf"),
-				Pattern:     fmt.Sprintf(pattern, intermediate.Primaries[mirror.ContentID].Port),
-				Replacement: fmt.Sprintf(replacement, mirror.Port),
+				Path:     filepath.Join(mirror.DataDir, "postgresql.conf"),
+				Name:     "port",
+				OldValue: strconv.Itoa(mirrorPort(mirror.ContentID)),
+				NewValue: strconv.Itoa(mirror.Port),
 			}
 
 			opts = append(opts, opt)
@@ -93,9 +110,10 @@ func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *
 
 		for _, primary := range primaries {
 			opt := &idl.UpdateFileConfOptions{
-				Path:        filepath.Join(primary.DataDir, "postgresql.conf"),
-				Pattern:     fmt.Sprintf(pattern, intermediate.Primaries[primary.ContentID].Port),
-				Replacement: fmt.Sprintf(replacement, primary.Port),
+				Path:     filepath.Join(primary.DataDir, "postgresql.conf"),
+				Name:     "port",
+				OldValue: strconv.Itoa(intermediate.Primaries[primary.ContentID].Port),
+				NewValue: strconv.Itoa(primary.Port),
 			}
 
 			opts = append(opts, opt)
@@ -115,9 +133,6 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 		file = "recovery.conf"
 	}
 
-	pattern := `(primary_conninfo .* port[ \t]*=[ \t]*)%d([^0-9]|$)`
-	replacement := `\1%d\2`
-
 	request := func(conn *idl.Connection) error {
 		var opts []*idl.UpdateFileConfOptions
 
@@ -125,8 +140,10 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 		if target.Standby().Hostname == conn.Hostname {
 			opt := &idl.UpdateFileConfOptions{
 				Path:        filepath.Join(target.StandbyDataDir(), file),
-				Pattern:     fmt.Sprintf(pattern, intermediateCluster.MasterPort()),
-				Replacement: fmt.Sprintf(replacement, target.MasterPort()),
+				Name:        "primary_conninfo",
+				ConnInfoKey: "port",
+				OldValue:    strconv.Itoa(intermediateCluster.MasterPort()),
+				NewValue:    strconv.Itoa(target.MasterPort()),
 			}
 
 			opts = append(opts, opt)
@@ -140,8 +157,10 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 		for _, mirror := range mirrors {
 			opt := &idl.UpdateFileConfOptions{
 				Path:        filepath.Join(mirror.DataDir, file),
-				Pattern:     fmt.Sprintf(pattern, intermediateCluster.Primaries[mirror.ContentID].Port),
-				Replacement: fmt.Sprintf(replacement, target.Primaries[mirror.ContentID].Port),
+				Name:        "primary_conninfo",
+				ConnInfoKey: "port",
+				OldValue:    strconv.Itoa(intermediateCluster.Primaries[mirror.ContentID].Port),
+				NewValue:    strconv.Itoa(target.Primaries[mirror.ContentID].Port),
 			}
 
 			opts = append(opts, opt)
@@ -156,9 +175,6 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 }
 
 func UpdateInternalAutoConfOnMirrors(agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
-	pattern := `(^gp_dbid=)%d([^0-9]|$)`
-	replacement := `\1%d\2`
-
 	request := func(conn *idl.Connection) error {
 		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
@@ -171,9 +187,10 @@ func UpdateInternalAutoConfOnMirrors(agentConns []*idl.Connection, intermediate
 		var opts []*idl.UpdateFileConfOptions
 		for _, intermediateMirror := range intermediateMirrors {
 			opt := &idl.UpdateFileConfOptions{
-				Path:        filepath.Join(intermediateMirror.DataDir, "internal.auto.conf"),
-				Pattern:     fmt.Sprintf(pattern, intermediate.Primaries[intermediateMirror.ContentID].DbID),
-				Replacement: fmt.Sprintf(replacement, intermediateMirror.DbID),
+				Path:     filepath.Join(intermediateMirror.DataDir, "internal.auto.conf"),
+				Name:     "gp_dbid",
+				OldValue: strconv.Itoa(intermediate.Primaries[intermediateMirror.ContentID].DbID),
+				NewValue: strconv.Itoa(intermediateMirror.DbID),
 			}
 
 			opts = append(opts, opt)
@@ -188,26 +205,56 @@ func UpdateInternalAutoConfOnMirrors(agentConns []*idl.Connection, intermediate
 	return ExecuteRPC(agentConns, request)
 }
 
-func UpdateConfigurationFile(opts []*idl.UpdateFileConfOptions) error {
-	var wg sync.WaitGroup
-	errs := make(chan error, len(opts))
+// UpdateInternalAutoConfOnStandby sets the gp_dbid of the standby, which was
+// copied from the master.
+func UpdateInternalAutoConfOnStandby(agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+	request := func(conn *idl.Connection) error {
+		if intermediate.StandbyHostname() != conn.Hostname {
+			return nil
+		}
+
+		opt := &idl.UpdateFileConfOptions{
+			Path:     filepath.Join(intermediate.StandbyDataDir(), "internal.auto.conf"),
+			Name:     "gp_dbid",
+			OldValue: strconv.Itoa(intermediate.Master().DbID),
+			NewValue: strconv.Itoa(intermediate.Standby().DbID),
+		}
+
+		req := &idl.UpdateConfigurationRequest{Options: []*idl.UpdateFileConfOptions{opt}}
+		_, err := conn.AgentClient.UpdateConfiguration(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
 
+// UpdateConfigurationFile applies the options to their configuration files.
+// Options for the same file are applied together so that it is written once.
+// An error is returned for any setting or old value that is not found, rather
+// than silently leaving the file unchanged.
+func UpdateConfigurationFile(opts []*idl.UpdateFileConfOptions) error {
+	var paths []string
+	optsByPath := make(map[string][]*idl.UpdateFileConfOptions)
 	for _, opt := range opts {
+		if _, ok := optsByPath[opt.GetPath()]; !ok {
+			paths = append(paths, opt.GetPath())
+		}
+
+		optsByPath[opt.GetPath()] = append(optsByPath[opt.GetPath()], opt)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(paths))
 
+	for _, path := range paths {
 		wg.Add(1)
-		go func(opt *idl.UpdateFileConfOptions) {
+		go func(path string, opts []*idl.UpdateFileConfOptions) {
 			defer wg.Done()
 
-			cmd := exec.Command("sed", "-E", "-i.bak",
-				fmt.Sprintf(`s@%s@%s@`, opt.GetPattern(), opt.GetReplacement()),
-				opt.GetPath(),
-			)
-
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				errs <- xerrors.Errorf("update %s using %q failed with %q: %w", filepath.Base(opt.GetPath()), cmd.String(), string(output), err)
+			if err := updateConfigurationFile(path, opts); err != nil {
+				errs <- xerrors.Errorf("update %s: %w", filepath.Base(path), err)
 			}
-		}(opt)
+		}(path, optsByPath[path])
 	}
 
 	wg.Wait()
@@ -220,3 +267,24 @@ func UpdateConfigurationFile(opts []*idl.UpdateFileConfOptions) error {
 
 	return err
 }
+
+func updateConfigurationFile(path string, opts []*idl.UpdateFileConfOptions) error {
+	file, err := conf.Read(path)
+	if err != nil {
+		return err
+	}
+
+	for _, opt := range opts {
+		if opt.GetConnInfoKey() != "" {
+			err = file.ReplaceConnInfo(opt.GetName(), opt.GetConnInfoKey(), opt.GetOldValue(), opt.GetNewValue())
+		} else {
+			err = file.Replace(opt.GetName(), opt.GetOldValue(), opt.GetNewValue())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return file.Write()
+}
@@ -6,8 +6,8 @@ package hub_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
-	"strings"
 	"testing"
 
 	"github.com/blang/semver/v4"
@@ -19,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/conf"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
@@ -41,10 +42,7 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
 	})
 
-	pattern := `(^port[ \t]*=[ \t]*)%d([^0-9]|$)`
-	replacement := `\1%d\2`
-
-	t.Run("updates postgresql.conf on segments", func(t *testing.T) {
+	t.Run("updates postgresql.conf on segments with mirrors rsync'd in copy mode", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -53,9 +51,10 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			gomock.Any(),
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{{
-					Path:        "/data/standby/postgresql.conf",
-					Pattern:     fmt.Sprintf(pattern, 50433),
-					Replacement: fmt.Sprintf(replacement, 16432),
+					Path:     "/data/standby/postgresql.conf",
+					Name:     "port",
+					OldValue: "50433",
+					NewValue: "16432",
 				}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -66,14 +65,16 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{
 					{
-						Path:        "/data/dbfast_mirror2/seg2/postgresql.conf",
-						Pattern:     fmt.Sprintf(pattern, 50436),
-						Replacement: fmt.Sprintf(replacement, 25436),
+						Path:     "/data/dbfast_mirror2/seg2/postgresql.conf",
+						Name:     "port",
+						OldValue: "50436",
+						NewValue: "25436",
 					},
 					{
-						Path:        "/data/dbfast1/seg1/postgresql.conf",
-						Pattern:     fmt.Sprintf(pattern, 50434),
-						Replacement: fmt.Sprintf(replacement, 25433),
+						Path:     "/data/dbfast1/seg1/postgresql.conf",
+						Name:     "port",
+						OldValue: "50434",
+						NewValue: "25433",
 					}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -84,14 +85,16 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{
 					{
-						Path:        "/data/dbfast_mirror1/seg1/postgresql.conf",
-						Pattern:     fmt.Sprintf(pattern, 50434),
-						Replacement: fmt.Sprintf(replacement, 25434),
+						Path:     "/data/dbfast_mirror1/seg1/postgresql.conf",
+						Name:     "port",
+						OldValue: "50434",
+						NewValue: "25434",
 					},
 					{
-						Path:        "/data/dbfast2/seg2/postgresql.conf",
-						Pattern:     fmt.Sprintf(pattern, 50436),
-						Replacement: fmt.Sprintf(replacement, 25435),
+						Path:     "/data/dbfast2/seg2/postgresql.conf",
+						Name:     "port",
+						OldValue: "50436",
+						NewValue: "25435",
 					}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -102,12 +105,76 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target)
+		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target, false, true)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
+	portCases := []struct {
+		name         string
+		useLinkMode  bool
+		rsyncMirrors bool
+		standbyPort  string
+		mirrorPorts  [2]string // of content 0 and 1
+	}{
+		{"uses the intermediate master and primary ports in link mode", true, false, "50432", [2]string{"50434", "50436"}},
+		{"uses the intermediate standby and mirror ports when created by gpinitstandby and gpaddmirrors", false, false, "50433", [2]string{"50435", "50437"}},
+	}
+
+	for _, c := range portCases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			standby := mock_idl.NewMockAgentClient(ctrl)
+			standby.EXPECT().UpdateConfiguration(
+				gomock.Any(),
+				&idl.UpdateConfigurationRequest{
+					Options: []*idl.UpdateFileConfOptions{{
+						Path:     "/data/standby/postgresql.conf",
+						Name:     "port",
+						OldValue: c.standbyPort,
+						NewValue: "16432",
+					}},
+				},
+			).Return(&idl.UpdateConfigurationReply{}, nil)
+
+			sdw1 := mock_idl.NewMockAgentClient(ctrl)
+			sdw1.EXPECT().UpdateConfiguration(
+				gomock.Any(),
+				&idl.UpdateConfigurationRequest{
+					Options: []*idl.UpdateFileConfOptions{
+						{Path: "/data/dbfast_mirror2/seg2/postgresql.conf", Name: "port", OldValue: c.mirrorPorts[1], NewValue: "25436"},
+						{Path: "/data/dbfast1/seg1/postgresql.conf", Name: "port", OldValue: "50434", NewValue: "25433"},
+					},
+				},
+			).Return(&idl.UpdateConfigurationReply{}, nil)
+
+			sdw2 := mock_idl.NewMockAgentClient(ctrl)
+			sdw2.EXPECT().UpdateConfiguration(
+				gomock.Any(),
+				&idl.UpdateConfigurationRequest{
+					Options: []*idl.UpdateFileConfOptions{
+						{Path: "/data/dbfast_mirror1/seg1/postgresql.conf", Name: "port", OldValue: c.mirrorPorts[0], NewValue: "25434"},
+						{Path: "/data/dbfast2/seg2/postgresql.conf", Name: "port", OldValue: "50436", NewValue: "25435"},
+					},
+				},
+			).Return(&idl.UpdateConfigurationReply{}, nil)
+
+			agentConns := []*idl.Connection{
+				{AgentClient: standby, Hostname: "standby"},
+				{AgentClient: sdw1, Hostname: "sdw1"},
+				{AgentClient: sdw2, Hostname: "sdw2"},
+			}
+
+			err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target, c.useLinkMode, c.rsyncMirrors)
+			if err != nil {
+				t.Errorf("unexpected err %#v", err)
+			}
+		})
+	}
+
 	t.Run("returns errors when failing to update postgresql.conf on segments", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -117,9 +184,10 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			gomock.Any(),
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{{
-					Path:        "/data/standby/postgresql.conf",
-					Pattern:     fmt.Sprintf(pattern, 50433),
-					Replacement: fmt.Sprintf(replacement, 16432),
+					Path:     "/data/standby/postgresql.conf",
+					Name:     "port",
+					OldValue: "50433",
+					NewValue: "16432",
 				}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -143,7 +211,7 @@ func TestUpdatePostgresqlConfOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target)
+		err := hub.UpdatePostgresqlConfOnSegments(agentConns, intermediate, target, false, true)
 		var errs errorlist.Errors
 		if !xerrors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -180,9 +248,6 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
 	})
 
-	pattern := `(primary_conninfo .* port[ \t]*=[ \t]*)%d([^0-9]|$)`
-	replacement := `\1%d\2`
-
 	cases := []struct {
 		name    string
 		version semver.Version
@@ -211,8 +276,10 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 				&idl.UpdateConfigurationRequest{
 					Options: []*idl.UpdateFileConfOptions{{
 						Path:        filepath.Join("/data/standby", c.file),
-						Pattern:     fmt.Sprintf(pattern, 50432),
-						Replacement: fmt.Sprintf(replacement, 15432),
+						Name:        "primary_conninfo",
+						ConnInfoKey: "port",
+						OldValue:    "50432",
+						NewValue:    "15432",
 					}},
 				},
 			).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -224,8 +291,10 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 					Options: []*idl.UpdateFileConfOptions{
 						{
 							Path:        filepath.Join("/data/dbfast_mirror2/seg2", c.file),
-							Pattern:     fmt.Sprintf(pattern, 50436),
-							Replacement: fmt.Sprintf(replacement, 25435),
+							Name:        "primary_conninfo",
+							ConnInfoKey: "port",
+							OldValue:    "50436",
+							NewValue:    "25435",
 						}},
 				},
 			).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -237,8 +306,10 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 					Options: []*idl.UpdateFileConfOptions{
 						{
 							Path:        filepath.Join("/data/dbfast_mirror1/seg1", c.file),
-							Pattern:     fmt.Sprintf(pattern, 50434),
-							Replacement: fmt.Sprintf(replacement, 25433),
+							Name:        "primary_conninfo",
+							ConnInfoKey: "port",
+							OldValue:    "50434",
+							NewValue:    "25433",
 						}},
 				},
 			).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -266,8 +337,10 @@ func TestUpdateRecoveryConfOnSegments(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{{
 					Path:        "/data/standby/recovery.conf",
-					Pattern:     fmt.Sprintf(pattern, 50432),
-					Replacement: fmt.Sprintf(replacement, 15432),
+					Name:        "primary_conninfo",
+					ConnInfoKey: "port",
+					OldValue:    "50432",
+					NewValue:    "15432",
 				}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -319,9 +392,6 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
 	})
 
-	pattern := `(^gp_dbid=)%d([^0-9]|$)`
-	replacement := `\1%d\2`
-
 	t.Run("updates internal.auto.conf on mirrors excluding the standby", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -332,9 +402,10 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{
 					{
-						Path:        "/data/dbfast_mirror2/seg.HqtFHX54y0o.2/internal.auto.conf",
-						Pattern:     fmt.Sprintf(pattern, 5),
-						Replacement: fmt.Sprintf(replacement, 6),
+						Path:     "/data/dbfast_mirror2/seg.HqtFHX54y0o.2/internal.auto.conf",
+						Name:     "gp_dbid",
+						OldValue: "5",
+						NewValue: "6",
 					}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -345,9 +416,10 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{
 					{
-						Path:        "/data/dbfast_mirror1/seg.HqtFHX54y0o.1/internal.auto.conf",
-						Pattern:     fmt.Sprintf(pattern, 3),
-						Replacement: fmt.Sprintf(replacement, 4),
+						Path:     "/data/dbfast_mirror1/seg.HqtFHX54y0o.1/internal.auto.conf",
+						Name:     "gp_dbid",
+						OldValue: "3",
+						NewValue: "4",
 					}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -373,9 +445,10 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 			&idl.UpdateConfigurationRequest{
 				Options: []*idl.UpdateFileConfOptions{
 					{
-						Path:        "/data/dbfast_mirror2/seg.HqtFHX54y0o.2/internal.auto.conf",
-						Pattern:     fmt.Sprintf(pattern, 5),
-						Replacement: fmt.Sprintf(replacement, 6),
+						Path:     "/data/dbfast_mirror2/seg.HqtFHX54y0o.2/internal.auto.conf",
+						Name:     "gp_dbid",
+						OldValue: "5",
+						NewValue: "6",
 					}},
 			},
 		).Return(&idl.UpdateConfigurationReply{}, nil)
@@ -399,6 +472,43 @@ func TestUpdateInternalAutoConfOnMirrors(t *testing.T) {
 	})
 }
 
+func TestUpdateInternalAutoConfOnStandby(t *testing.T) {
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	standby := mock_idl.NewMockAgentClient(ctrl)
+	standby.EXPECT().UpdateConfiguration(
+		gomock.Any(),
+		&idl.UpdateConfigurationRequest{
+			Options: []*idl.UpdateFileConfOptions{
+				{
+					Path:     "/data/standby.HqtFHX54y0o/internal.auto.conf",
+					Name:     "gp_dbid",
+					OldValue: "1",
+					NewValue: "2",
+				}},
+		},
+	).Return(&idl.UpdateConfigurationReply{}, nil)
+
+	agentConns := []*idl.Connection{
+		{AgentClient: standby, Hostname: "standby"},
+		{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+		{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"},
+	}
+
+	err := hub.UpdateInternalAutoConfOnStandby(agentConns, intermediate)
+	if err != nil {
+		t.Errorf("unexpected err %#v", err)
+	}
+}
+
 func TestUpdateConfFiles(t *testing.T) {
 	t.Run("UpdateGpperfmonConf", func(t *testing.T) {
 		dir := testutils.GetTempDir(t, "")
@@ -414,9 +524,9 @@ other_log_location = /some/directory
 `)
 
 		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{
-			Path:        filepath.Join(dir, "gpperfmon", "conf", "gpperfmon.conf"),
-			Pattern:     `^log_location = .*$`,
-			Replacement: fmt.Sprintf("log_location = %s", filepath.Join(dir, "gpperfmon", "logs")),
+			Path:     filepath.Join(dir, "gpperfmon", "conf", "gpperfmon.conf"),
+			Name:     "log_location",
+			NewValue: filepath.Join(dir, "gpperfmon", "logs"),
 		}})
 		if err != nil {
 			t.Errorf("UpdateGpperfmonConf() returned error %+v", err)
@@ -450,7 +560,7 @@ port=50000
 #port=5000
 `)
 
-		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Pattern: fmt.Sprintf(`(^port[ \t]*=[ \t]*)%d([^0-9]|$)`, 5000), Replacement: fmt.Sprintf(`\1%d\2`, 6000)}})
+		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Name: "port", OldValue: "5000", NewValue: "6000"}})
 		if err != nil {
 			t.Errorf("UpdatePostgresqlConf() returned error %+v", err)
 		}
@@ -484,7 +594,7 @@ primary_slot_name = 'internal_wal_replication_slot'
 #primary_conninfo = 'user=gpadmin host=sdw1 port=5000 sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver'
 `)
 
-		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Pattern: fmt.Sprintf(`(primary_conninfo .* port[ \t]*=[ \t]*)%d([^0-9]|$)`, 5000), Replacement: fmt.Sprintf(`\1%d\2`, 6000)}})
+		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Name: "primary_conninfo", ConnInfoKey: "port", OldValue: "5000", NewValue: "6000"}})
 		if err != nil {
 			t.Errorf("UpdateRecoveryConf() returned error %+v", err)
 		}
@@ -496,25 +606,72 @@ primary_conninfo = 'user=gpadmin host=sdw1 port=6000 sslmode=disable sslcompress
 primary_slot_name = 'internal_wal_replication_slot'
 
 # should not be replaced
-#primary_conninfo = 'user=gpadmin host=sdw1 port=6000 sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver'
+#primary_conninfo = 'user=gpadmin host=sdw1 port=5000 sslmode=disable sslcompression=1 krbsrvname=postgres application_name=gp_walreceiver'
 `
 		if contents != expected {
 			t.Errorf("replaced contents: %s\nwant: %s", contents, expected)
 		}
 	})
 
+	t.Run("can be run again after the file was updated", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		path := filepath.Join(dir, "postgresql.conf")
+		testutils.MustWriteToFile(t, path, "port = 50432\n")
+
+		opts := []*idl.UpdateFileConfOptions{{Path: path, Name: "port", OldValue: "50432", NewValue: "15432"}}
+		for i := 0; i < 2; i++ {
+			if err := hub.UpdateConfigurationFile(opts); err != nil {
+				t.Fatalf("run %d returned error %+v", i+1, err)
+			}
+		}
+
+		contents := testutils.MustReadFile(t, path)
+		if contents != "port = 15432\n" {
+			t.Errorf("got %q want %q", contents, "port = 15432\n")
+		}
+	})
+
+	t.Run("errors when the setting is not found", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		path := filepath.Join(dir, "postgresql.conf")
+		testutils.MustWriteToFile(t, path, "#port=5000\n")
+
+		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Name: "port", OldValue: "5000", NewValue: "6000"}})
+		if !errors.Is(err, conf.ErrSettingNotFound) {
+			t.Errorf("got error %#v want %#v", err, conf.ErrSettingNotFound)
+		}
+
+		contents := testutils.MustReadFile(t, path)
+		if contents != "#port=5000\n" {
+			t.Errorf("got contents %q want them unchanged", contents)
+		}
+	})
+
+	t.Run("errors when the old value is not found", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		path := filepath.Join(dir, "postgresql.conf")
+		testutils.MustWriteToFile(t, path, "port=50000\n")
+
+		err := hub.UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{Path: path, Name: "port", OldValue: "5000", NewValue: "6000"}})
+		if !errors.Is(err, conf.ErrValueNotFound) {
+			t.Errorf("got error %#v want %#v", err, conf.ErrValueNotFound)
+		}
+	})
+
 	t.Run("returns errors", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
 		opts := []*idl.UpdateFileConfOptions{
-			{
-				Path:        "",
-				Pattern:     "",
-				Replacement: "",
-			},
-			{
-				Path:        "",
-				Pattern:     "",
-				Replacement: "",
-			}}
+			{Path: filepath.Join(dir, "postgresql.conf"), Name: "port", OldValue: "5000", NewValue: "6000"},
+			{Path: filepath.Join(dir, "recovery.conf"), Name: "primary_conninfo", ConnInfoKey: "port", OldValue: "5000", NewValue: "6000"},
+		}
 
 		err := hub.UpdateConfigurationFile(opts)
 		var errs errorlist.Errors
@@ -527,9 +684,8 @@ primary_slot_name = 'internal_wal_replication_slot'
 		}
 
 		for _, err := range errs {
-			expected := `update . using "/usr/bin/sed -E -i.bak s@@@ " failed with "sed:`
-			if !strings.HasPrefix(err.Error(), expected) {
-				t.Errorf("expected error to contain %q got %q", expected, err.Error())
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
 			}
 		}
 	})
@@ -0,0 +1,190 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+const extensionUpgradeFailuresFileName = "extension_upgrade_failures.json"
+
+// OutdatedExtension is an installed extension whose version is older than the
+// default version of the target installation.
+type OutdatedExtension struct {
+	Name             string
+	InstalledVersion string
+	DefaultVersion   string
+}
+
+// ExtensionUpgradeFailuresPath returns the location of the extension update
+// failures saved by UpgradeExtensions.
+func ExtensionUpgradeFailuresPath(stateDir string) string {
+	return filepath.Join(stateDir, extensionUpgradeFailuresFileName)
+}
+
+// UpgradeExtensions runs ALTER EXTENSION UPDATE for each outdated extension in
+// every database of the running target cluster. Extensions that fail to
+// update are saved in the state directory rather than failing finalize, since
+// they typically require manual steps such as running extension specific
+// scripts.
+func UpgradeExtensions(streams step.OutStreams, conn *greenplum.Conn, target *greenplum.Cluster, stateDir string) error {
+	databases, err := listDatabases(conn, greenplum.ToTarget(), greenplum.Port(target.MasterPort()))
+	if err != nil {
+		return err
+	}
+
+	failures := []string{}
+	for _, database := range databases {
+		dbFailures, err := upgradeDatabaseExtensions(streams, conn, target, database)
+		if err != nil {
+			return xerrors.Errorf("database %q: %w", database, err)
+		}
+
+		failures = append(failures, dbFailures...)
+	}
+
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(ExtensionUpgradeFailuresPath(stateDir), data)
+}
+
+// ReadExtensionUpgradeFailures returns the extension update failures saved by
+// UpgradeExtensions, or nothing if extensions were not upgraded.
+func ReadExtensionUpgradeFailures(stateDir string) ([]string, error) {
+	data, err := utils.System.ReadFile(ExtensionUpgradeFailuresPath(stateDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, xerrors.Errorf("read extension upgrade failures: %w", err)
+	}
+
+	var failures []string
+	if err := json.Unmarshal(data, &failures); err != nil {
+		return nil, xerrors.Errorf("parse extension upgrade failures: %w", err)
+	}
+
+	return failures, nil
+}
+
+func upgradeDatabaseExtensions(streams step.OutStreams, conn *greenplum.Conn, target *greenplum.Cluster, database string) (failures []string, err error) {
+	db, err := sql.Open("pgx", conn.URI(greenplum.ToTarget(), greenplum.Port(target.MasterPort()), greenplum.Database(database)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return UpgradeDatabaseExtensions(streams, db, database)
+}
+
+// UpgradeDatabaseExtensions updates the outdated extensions of a single
+// database and returns a description of each extension that failed to update.
+func UpgradeDatabaseExtensions(streams step.OutStreams, db *sql.DB, database string) ([]string, error) {
+	extensions, err := OutdatedExtensions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for _, ext := range extensions {
+		_, err := db.Exec(fmt.Sprintf("ALTER EXTENSION %s UPDATE;", quoteIdentifier(ext.Name)))
+		if err != nil {
+			failure := fmt.Sprintf("database %q: extension %q could not be updated from version %s to %s and requires manual steps: %v",
+				database, ext.Name, ext.InstalledVersion, ext.DefaultVersion, err)
+			fmt.Fprintf(streams.Stdout(), "FAILED: %s\n", failure)
+			failures = append(failures, failure)
+			continue
+		}
+
+		fmt.Fprintf(streams.Stdout(), "database %q: updated extension %q from version %s to %s\n",
+			database, ext.Name, ext.InstalledVersion, ext.DefaultVersion)
+	}
+
+	return failures, nil
+}
+
+// OutdatedExtensions returns the installed extensions whose version is older
+// than the default version available in the installation. Extensions with a
+// newer installed version are left as is since ALTER EXTENSION UPDATE would
+// attempt a downgrade.
+func OutdatedExtensions(db *sql.DB) ([]OutdatedExtension, error) {
+	rows, err := db.Query(`SELECT name, installed_version, default_version
+FROM pg_available_extensions
+WHERE installed_version IS NOT NULL
+    AND default_version IS NOT NULL
+    AND installed_version <> default_version
+ORDER BY name;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying extensions: %w", err)
+	}
+	defer rows.Close()
+
+	var extensions []OutdatedExtension
+	for rows.Next() {
+		var ext OutdatedExtension
+		if err := rows.Scan(&ext.Name, &ext.InstalledVersion, &ext.DefaultVersion); err != nil {
+			return nil, xerrors.Errorf("scanning extensions: %w", err)
+		}
+
+		if extensionVersionOlder(ext.InstalledVersion, ext.DefaultVersion) {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating extensions: %w", err)
+	}
+
+	return extensions, nil
+}
+
+// extensionVersionOlder returns true when version is older than other.
+// Extension versions are arbitrary strings, so they are compared by their dot
+// separated parts, numerically when both parts are numbers such that 1.10 is
+// newer than 1.9, and lexically otherwise.
+func extensionVersionOlder(version string, other string) bool {
+	parts := strings.Split(version, ".")
+	otherParts := strings.Split(other, ".")
+
+	for i := 0; i < len(parts) && i < len(otherParts); i++ {
+		if parts[i] == otherParts[i] {
+			continue
+		}
+
+		number, err := strconv.Atoi(parts[i])
+		otherNumber, otherErr := strconv.Atoi(otherParts[i])
+		if err == nil && otherErr == nil {
+			return number < otherNumber
+		}
+
+		return parts[i] < otherParts[i]
+	}
+
+	return len(parts) < len(otherParts)
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestUpgradeDatabaseExtensions(t *testing.T) {
+	t.Run("updates outdated extensions and reports those that fail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT name, installed_version, default_version FROM pg_available_extensions`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "installed_version", "default_version"}).
+				AddRow("hstore", "1.2", "1.3").
+				AddRow("postgis", "2.1.5", "2.5.4"))
+		mock.ExpectExec(`ALTER EXTENSION "hstore" UPDATE;`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`ALTER EXTENSION "postgis" UPDATE;`).
+			WillReturnError(errors.New(`extension "postgis" has no update path from version "2.1.5" to version "2.5.4"`))
+
+		streams := &step.BufferedStreams{}
+		failures, err := hub.UpgradeDatabaseExtensions(streams, db, "postgres")
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{
+			`database "postgres": extension "postgis" could not be updated from version 2.1.5 to 2.5.4 and requires manual steps: ` +
+				`extension "postgis" has no update path from version "2.1.5" to version "2.5.4"`,
+		}
+		if !reflect.DeepEqual(failures, expected) {
+			t.Errorf("got failures %q want %q", failures, expected)
+		}
+
+		if !strings.Contains(streams.StdoutBuf.String(), `updated extension "hstore" from version 1.2 to 1.3`) {
+			t.Errorf("got output %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("returns an error when querying extensions fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("connection refused")
+		mock.ExpectQuery(`SELECT name, installed_version, default_version`).WillReturnError(expected)
+
+		_, err = hub.UpgradeDatabaseExtensions(step.DevNullStream, db, "postgres")
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestOutdatedExtensions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT name, installed_version, default_version FROM pg_available_extensions`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "installed_version", "default_version"}).
+			AddRow("citext", "1.10", "1.9").
+			AddRow("hstore", "1.9", "1.10").
+			AddRow("pgcrypto", "1.3", "1.3.1").
+			AddRow("postgis", "2.5.4", "2.1.5").
+			AddRow("postgis_topology", "2.1.5dev", "2.1.5"))
+
+	extensions, err := hub.OutdatedExtensions(db)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := []hub.OutdatedExtension{
+		{Name: "hstore", InstalledVersion: "1.9", DefaultVersion: "1.10"},
+		{Name: "pgcrypto", InstalledVersion: "1.3", DefaultVersion: "1.3.1"},
+	}
+	if !reflect.DeepEqual(extensions, expected) {
+		t.Errorf("got %+v want %+v", extensions, expected)
+	}
+}
+
+func TestReadExtensionUpgradeFailures(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	t.Run("returns nothing when extensions were not upgraded", func(t *testing.T) {
+		failures, err := hub.ReadExtensionUpgradeFailures(stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if len(failures) != 0 {
+			t.Errorf("got failures %q want none", failures)
+		}
+	})
+
+	t.Run("returns the saved failures", func(t *testing.T) {
+		testutils.MustWriteToFile(t, hub.ExtensionUpgradeFailuresPath(stateDir), `["failure one", "failure two"]`)
+
+		failures, err := hub.ReadExtensionUpgradeFailures(stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := []string{"failure one", "failure two"}
+		if !reflect.DeepEqual(failures, expected) {
+			t.Errorf("got failures %q want %q", failures, expected)
+		}
+	})
+}
@@ -10,13 +10,21 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/blang/semver/v4"
+
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func UpgradeMirrorsUsingRsync(conn *greenplum.Conn, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+// UpgradeMirrorsUsingRsync creates the mirrors of the intermediate cluster by
+// rsyncing the upgraded primaries. In link mode the source mirrors are
+// overwritten since they cannot be used to revert. In copy mode the source
+// mirrors are left intact and only used as a basis for new mirror data
+// directories.
+func UpgradeMirrorsUsingRsync(conn *greenplum.Conn, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool, useLinkMode bool) error {
 	options := []greenplum.Option{
 		greenplum.ToTarget(),
 		greenplum.Port(intermediate.MasterPort()),
@@ -40,16 +48,30 @@ func UpgradeMirrorsUsingRsync(conn *greenplum.Conn, agentConns []*idl.Connection
 		return err
 	}
 
-	if err := RsyncMirrorDataDirsOnSegments(agentConns, source, intermediate); err != nil {
-		return err
-	}
+	if useLinkMode {
+		if err := RsyncMirrorDataDirsOnSegments(agentConns, source, intermediate); err != nil {
+			return err
+		}
 
-	if err := RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
-		return err
-	}
+		if err := RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+			return err
+		}
 
-	if err := RenameMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
-		return err
+		if err := RenameMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+			return err
+		}
+	} else {
+		if err := RsyncMirrorDataDirsOnSegmentsInCopyMode(agentConns, source, intermediate); err != nil {
+			return err
+		}
+
+		if err := RsyncMirrorTablespacesOnSegmentsInCopyMode(agentConns, source, intermediate); err != nil {
+			return err
+		}
+
+		if err := RenameMirrorTablespacesOnSegmentsInCopyMode(agentConns, source, intermediate); err != nil {
+			return err
+		}
 	}
 
 	if err := CreateRecoveryConfOnSegments(agentConns, intermediate); err != nil {
@@ -83,7 +105,21 @@ func UpgradeMirrorsUsingRsync(conn *greenplum.Conn, agentConns []*idl.Connection
 	return nil
 }
 
+// mirrorRsyncExcludes returns the files of the primary data directory that
+// must not be copied to its mirror. Starting with GPDB 7 a signal file, rather
+// than recovery.conf, determines whether the server starts as a standby, so a
+// stray signal file must not be copied from the primary.
+func mirrorRsyncExcludes(version semver.Version) []string {
+	if version.Major < 7 {
+		return nil
+	}
+
+	return []string{"postmaster.pid", "postmaster.opts", "standby.signal", "recovery.signal"}
+}
+
 func RsyncMirrorDataDirsOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	excludes := mirrorRsyncExcludes(intermediate.Version)
+
 	request := func(conn *idl.Connection) error {
 		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsMaster() && seg.IsPrimary()
@@ -101,6 +137,7 @@ func RsyncMirrorDataDirsOnSegments(agentConns []*idl.Connection, source *greenpl
 				Destination:     filepath.Dir(intermediateMirror.DataDir), // FIXME: Do we really want filepath.Dir here
 				DestinationHost: intermediateMirror.Hostname,
 				Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
+				ExcludedFiles:   excludes,
 			}
 
 			opts = append(opts, opt)
@@ -188,3 +225,140 @@ func RenameMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *gre
 
 	return ExecuteRPC(agentConns, request)
 }
+
+// RsyncMirrorDataDirsOnSegmentsInCopyMode copies the intermediate primaries to
+// new intermediate mirror data directories, leaving the source mirrors intact
+// for revert. The source mirror files are used as the basis of the transfer so
+// that only the data changed by the upgrade is sent over the network. Unlike
+// link mode files are not compared by size only, since the new catalog files
+// can share paths and sizes with unrelated files of the source mirror.
+func RsyncMirrorDataDirsOnSegmentsInCopyMode(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	excludes := mirrorRsyncExcludes(intermediate.Version)
+
+	request := func(conn *idl.Connection) error {
+		intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && !seg.IsMaster() && seg.IsPrimary()
+		})
+
+		var opts []*idl.RsyncRequest_RsyncOptions
+		for _, intermediatePrimary := range intermediatePrimaries {
+			intermediateMirror := intermediate.Mirrors[intermediatePrimary.ContentID]
+			sourceMirror := source.Mirrors[intermediatePrimary.ContentID]
+
+			// On the intermediate primary host rsync to the intermediate mirror
+			// host using the source mirror on that host as a basis.
+			opt := &idl.RsyncRequest_RsyncOptions{
+				Sources:         []string{intermediatePrimary.DataDir + string(os.PathSeparator)},
+				Destination:     intermediateMirror.DataDir,
+				DestinationHost: intermediateMirror.Hostname,
+				Options: []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive",
+					"--copy-dest=" + sourceMirror.DataDir},
+				ExcludedFiles: excludes,
+			}
+
+			opts = append(opts, opt)
+		}
+
+		if len(opts) == 0 {
+			return nil
+		}
+
+		req := &idl.RsyncRequest{Options: opts}
+		_, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req)
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// RsyncMirrorTablespacesOnSegmentsInCopyMode copies only the intermediate
+// primary tablespace directories, <location>/<dbid>/GPDB_<major>_<catalog>, to
+// the source mirror tablespace location. The source mirror tablespaces are
+// neither modified nor deleted.
+func RsyncMirrorTablespacesOnSegmentsInCopyMode(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(conn *idl.Connection) error {
+		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && !seg.IsMaster() && seg.IsPrimary()
+		})
+
+		var opts []*idl.RsyncRequest_RsyncOptions
+		for _, sourcePrimary := range sourcePrimaries {
+			intermediatePrimary := intermediate.Primaries[sourcePrimary.ContentID]
+			intermediateMirror := intermediate.Mirrors[sourcePrimary.ContentID]
+			sourceMirror := source.Mirrors[sourcePrimary.ContentID]
+
+			// the relative path <dbid>/GPDB_<major>_<catalog> of the
+			// intermediate primary tablespace within its location
+			tsDir := upgrade.TablespacePath("", intermediatePrimary.DbID, intermediate.Version.Major, intermediate.CatalogVersion)
+
+			for tsOid, sourcePrimaryTsInfo := range source.Tablespaces[sourcePrimary.DbID] {
+				if !sourcePrimaryTsInfo.IsUserDefined() {
+					continue
+				}
+
+				sourceMirrorTsLocation := source.Tablespaces[sourceMirror.DbID][tsOid].Location
+
+				opt := &idl.RsyncRequest_RsyncOptions{
+					Sources:         []string{sourcePrimaryTsInfo.Location + string(os.PathSeparator)},
+					Destination:     sourceMirrorTsLocation,
+					DestinationHost: intermediateMirror.Hostname,
+					Options: []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive",
+						"--include=/" + filepath.Dir(tsDir) + "/", "--include=/" + tsDir + "/***"},
+					ExcludedFiles: []string{"*"},
+				}
+
+				opts = append(opts, opt)
+			}
+		}
+
+		if len(opts) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), &idl.RsyncRequest{Options: opts})
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
+
+// RenameMirrorTablespacesOnSegmentsInCopyMode moves the copied intermediate
+// primary tablespace directories to the intermediate mirror DbID.
+func RenameMirrorTablespacesOnSegmentsInCopyMode(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(conn *idl.Connection) error {
+		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
+		})
+
+		var pairs []*idl.RenameTablespacesRequest_RenamePair
+		for _, intermediateMirror := range intermediateMirrors {
+			intermediatePrimary := intermediate.Primaries[intermediateMirror.ContentID]
+			sourcePrimary := source.Primaries[intermediateMirror.ContentID]
+			sourceMirror := source.Mirrors[intermediateMirror.ContentID]
+
+			for tsOid, sourcePrimaryTsInfo := range source.Tablespaces[sourcePrimary.DbID] {
+				if !sourcePrimaryTsInfo.IsUserDefined() {
+					continue
+				}
+
+				sourceMirrorTsLocation := source.Tablespaces[sourceMirror.DbID][tsOid].Location
+
+				pair := &idl.RenameTablespacesRequest_RenamePair{
+					Source:      upgrade.TablespacePath(sourceMirrorTsLocation, intermediatePrimary.DbID, intermediate.Version.Major, intermediate.CatalogVersion),
+					Destination: upgrade.TablespacePath(sourcePrimaryTsInfo.Location, intermediateMirror.DbID, intermediate.Version.Major, intermediate.CatalogVersion),
+				}
+
+				pairs = append(pairs, pair)
+			}
+		}
+
+		if len(pairs) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.RenameTablespaces(context.Background(), &idl.RenameTablespacesRequest{RenamePairs: pairs})
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
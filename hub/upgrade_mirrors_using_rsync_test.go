@@ -7,6 +7,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/blang/semver/v4"
 	"github.com/golang/mock/gomock"
 	"golang.org/x/xerrors"
 
@@ -80,6 +81,56 @@ func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
 		}
 	})
 
+	t.Run("excludes the signal files for GPDB 7", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		coordinator := *intermediate
+		coordinator.Version = semver.MustParse("7.0.0")
+
+		excludes := []string{"postmaster.pid", "postmaster.opts", "standby.signal", "recovery.signal"}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/data/dbfast1/seg1", "/data/dbfast1/seg.HqtFHX54y0o.1"},
+						Destination:     "/data/dbfast_mirror1",
+						DestinationHost: "sdw2",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
+						ExcludedFiles:   excludes,
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/data/dbfast2/seg2", "/data/dbfast2/seg.HqtFHX54y0o.2"},
+						Destination:     "/data/dbfast_mirror2",
+						DestinationHost: "sdw1",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"},
+						ExcludedFiles:   excludes,
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.RsyncMirrorDataDirsOnSegments(agentConns, source, &coordinator)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
 	t.Run("returns errors when failing on segments", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -293,3 +344,173 @@ func TestRenameMirrorTablespacesOnSegments(t *testing.T) {
 		}
 	})
 }
+
+func TestUpgradeMirrorsUsingRsyncInCopyMode(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Port: 16432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Port: 25435, Role: greenplum.PrimaryRole},
+		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
+	})
+	source.Tablespaces = testutils.CreateTablespaces()
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.HqtFHX54y0o.2", Port: 50436, Role: greenplum.PrimaryRole},
+		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+	intermediate.CatalogVersion = "301908232"
+
+	t.Run("rsyncs the primaries to new mirror data directories based on the source mirrors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/data/dbfast1/seg.HqtFHX54y0o.1/"},
+						Destination:     "/data/dbfast_mirror1/seg.HqtFHX54y0o.1",
+						DestinationHost: "sdw2",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive", "--copy-dest=/data/dbfast_mirror1/seg1"},
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().RsyncDataDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/data/dbfast2/seg.HqtFHX54y0o.2/"},
+						Destination:     "/data/dbfast_mirror2/seg.HqtFHX54y0o.2",
+						DestinationHost: "sdw1",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive", "--copy-dest=/data/dbfast_mirror2/seg2"},
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.RsyncMirrorDataDirsOnSegmentsInCopyMode(agentConns, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("rsyncs only the intermediate primary tablespaces to the mirror tablespace locations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/tmp/user_ts/p1/16384/"},
+						Destination:     "/tmp/user_ts/m1/16384",
+						DestinationHost: "sdw2",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive", "--include=/3/", "--include=/3/GPDB_6_301908232/***"},
+						ExcludedFiles:   []string{"*"},
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().RsyncTablespaceDirectories(
+			gomock.Any(),
+			&idl.RsyncRequest{
+				Options: []*idl.RsyncRequest_RsyncOptions{
+					{
+						Sources:         []string{"/tmp/user_ts/p2/16384/"},
+						Destination:     "/tmp/user_ts/m2/16384",
+						DestinationHost: "sdw1",
+						Options:         []string{"--archive", "--delete", "--hard-links", "--no-inc-recursive", "--include=/5/", "--include=/5/GPDB_6_301908232/***"},
+						ExcludedFiles:   []string{"*"},
+					}},
+			},
+		).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.RsyncMirrorTablespacesOnSegmentsInCopyMode(agentConns, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("renames the copied tablespaces to the mirror dbid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RenameTablespaces(
+			gomock.Any(),
+			&idl.RenameTablespacesRequest{
+				RenamePairs: []*idl.RenameTablespacesRequest_RenamePair{{
+					Source:      "/tmp/user_ts/m2/16384/5/GPDB_6_301908232",
+					Destination: "/tmp/user_ts/p2/16384/6/GPDB_6_301908232",
+				}},
+			},
+		).Return(&idl.RenameTablespacesReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().RenameTablespaces(
+			gomock.Any(),
+			&idl.RenameTablespacesRequest{
+				RenamePairs: []*idl.RenameTablespacesRequest_RenamePair{{
+					Source:      "/tmp/user_ts/m1/16384/3/GPDB_6_301908232",
+					Destination: "/tmp/user_ts/p1/16384/4/GPDB_6_301908232",
+				}},
+			},
+		).Return(&idl.RenameTablespacesReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.RenameMirrorTablespacesOnSegmentsInCopyMode(agentConns, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns errors when failing on segments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().RsyncDataDirectories(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().RsyncDataDirectories(gomock.Any(), gomock.Any()).Return(&idl.RsyncReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.RsyncMirrorDataDirsOnSegmentsInCopyMode(agentConns, source, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
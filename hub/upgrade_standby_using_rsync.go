@@ -0,0 +1,168 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// UpgradeStandbyUsingRsync creates the standby of the intermediate cluster
+// from the upgraded master rather than with gpinitstandby, which copies the
+// entire master data directory over the network. Files that are unchanged
+// from the source standby are hard linked to it, so only the files changed by
+// the upgrade are sent. Since the source standby is reused this is only done
+// in link mode.
+func UpgradeStandbyUsingRsync(conn *greenplum.Conn, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+	options := []greenplum.Option{
+		greenplum.ToTarget(),
+		greenplum.Port(intermediate.MasterPort()),
+	}
+
+	db, err := sql.Open("pgx", conn.URI(options...))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if err := CreateMasterReplicationSlot(db); err != nil {
+		return err
+	}
+
+	if err := intermediate.Stop(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := RsyncStandbyDataDir(source, intermediate); err != nil {
+		return err
+	}
+
+	if err := RsyncStandbyTablespaces(source, intermediate); err != nil {
+		return err
+	}
+
+	if err := RenameStandbyTablespaces(agentConns, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := CreateRecoveryConfOnStandby(agentConns, intermediate); err != nil {
+		return err
+	}
+
+	if err := AddReplicationEntriesOnMaster(intermediate, useHbaHostnames); err != nil {
+		return err
+	}
+
+	if err := UpdateInternalAutoConfOnStandby(agentConns, intermediate); err != nil {
+		return err
+	}
+
+	if err := intermediate.StartMasterOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := addStandbyToCatalog(conn, intermediate); err != nil {
+		return err
+	}
+
+	if err := intermediate.StopMasterOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := intermediate.Start(step.DevNullStream); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RsyncStandbyDataDir copies the intermediate master data directory from the
+// master host to the intermediate standby data directory, hard linking the
+// files whose contents match the source standby. Files are compared by
+// checksum rather than size, since files such as global/pg_control and the
+// catalog relations of the upgraded master have the same size but different
+// contents than the files at the same path in the source standby.
+func RsyncStandbyDataDir(source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	opts := []rsync.Option{
+		rsync.WithSources(intermediate.MasterDataDir() + string(os.PathSeparator)),
+		rsync.WithDestinationHost(intermediate.StandbyHostname()),
+		rsync.WithDestination(intermediate.StandbyDataDir()),
+		rsync.WithOptions("--archive", "--delete", "--hard-links", "--checksum", "--no-inc-recursive",
+			"--link-dest="+source.StandbyDataDir()),
+		rsync.WithExcludedFiles(mirrorRsyncExcludes(intermediate.Version)...),
+	}
+
+	return rsync.Rsync(opts...)
+}
+
+// RsyncStandbyTablespaces copies the source master tablespaces from the master
+// host to the source standby tablespaces, since in link mode the intermediate
+// master uses the source master tablespaces.
+func RsyncStandbyTablespaces(source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	for tsOid, masterTsInfo := range source.Tablespaces[source.Master().DbID] {
+		if !masterTsInfo.IsUserDefined() {
+			continue
+		}
+
+		opts := []rsync.Option{
+			rsync.WithSources(masterTsInfo.Location + string(os.PathSeparator)),
+			rsync.WithDestinationHost(intermediate.StandbyHostname()),
+			rsync.WithDestination(source.Tablespaces[source.Standby().DbID][tsOid].Location),
+			rsync.WithOptions("--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"),
+		}
+
+		if err := rsync.Rsync(opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenameStandbyTablespaces renames the master DbID of the copied tablespace
+// directories to the standby DbID on the standby host.
+func RenameStandbyTablespaces(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(conn *idl.Connection) error {
+		if intermediate.StandbyHostname() != conn.Hostname {
+			return nil
+		}
+
+		var pairs []*idl.RenameTablespacesRequest_RenamePair
+		for tsOid, masterTsInfo := range source.Tablespaces[source.Master().DbID] {
+			if !masterTsInfo.IsUserDefined() {
+				continue
+			}
+
+			standbyTsLocation := source.Tablespaces[source.Standby().DbID][tsOid].Location
+			pair := &idl.RenameTablespacesRequest_RenamePair{
+				Source:      filepath.Join(standbyTsLocation, strconv.Itoa(intermediate.Master().DbID)),
+				Destination: filepath.Join(masterTsInfo.Location, strconv.Itoa(intermediate.Standby().DbID)),
+			}
+
+			pairs = append(pairs, pair)
+		}
+
+		if len(pairs) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.RenameTablespaces(context.Background(), &idl.RenameTablespacesRequest{RenamePairs: pairs})
+		return err
+	}
+
+	return ExecuteRPC(agentConns, request)
+}
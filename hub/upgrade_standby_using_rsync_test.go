@@ -0,0 +1,186 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestUpgradeStandbyUsingRsync(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Port: 16432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+	})
+	source.Tablespaces = testutils.CreateTablespaces()
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "master", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+
+	t.Run("rsyncs the intermediate master to the standby linking the source standby", func(t *testing.T) {
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			if !strings.HasSuffix(utility, "rsync") {
+				t.Errorf("got %q want rsync", utility)
+			}
+
+			expected := []string{
+				"--archive", "--delete", "--hard-links", "--checksum", "--no-inc-recursive",
+				"--link-dest=/data/standby",
+				"/data/qddir/seg.HqtFHX54y0o.-1/",
+				"standby:/data/standby.HqtFHX54y0o",
+			}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyDataDir(source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("compares contents so that changed files of equal size are sent rather than linked", func(t *testing.T) {
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			// With --size-only a file such as global/pg_control, which is
+			// always 8192 bytes, would be hard linked to the stale file of
+			// the source standby rather than sent.
+			checksum := false
+			for _, arg := range args {
+				switch arg {
+				case "--size-only":
+					t.Errorf("got args %q which link files of equal size regardless of their contents", args)
+				case "--checksum":
+					checksum = true
+				}
+			}
+
+			if !checksum {
+				t.Errorf("got args %q want --checksum", args)
+			}
+		}))
+
+		err := hub.RsyncStandbyDataDir(source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("excludes the recovery files when upgrading to GPDB 7", func(t *testing.T) {
+		intermediate7X := *intermediate
+		intermediate7X.Version = semver.MustParse("7.0.0")
+
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			excludes := strings.Join(args[8:], " ")
+			expected := "--exclude postmaster.pid --exclude postmaster.opts --exclude standby.signal --exclude recovery.signal"
+			if excludes != expected {
+				t.Errorf("got exclusions %q want %q", excludes, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyDataDir(source, &intermediate7X)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns rsync errors", func(t *testing.T) {
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
+
+		err := hub.RsyncStandbyDataDir(source, intermediate)
+		var rsyncErr rsync.RsyncError
+		if !errors.As(err, &rsyncErr) {
+			t.Errorf("got error %#v want %T", err, rsyncErr)
+		}
+	})
+
+	t.Run("rsyncs the master tablespaces to the standby", func(t *testing.T) {
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			expected := []string{
+				"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive",
+				"/tmp/user_ts/m/qddir/16384/",
+				"standby:/tmp/user_ts/m/standby/16384",
+			}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyTablespaces(source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("renames the standby tablespaces on the standby host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().RenameTablespaces(
+			gomock.Any(),
+			&idl.RenameTablespacesRequest{
+				RenamePairs: []*idl.RenameTablespacesRequest_RenamePair{{
+					Source:      "/tmp/user_ts/m/standby/16384/1",
+					Destination: "/tmp/user_ts/m/qddir/16384/2",
+				}},
+			},
+		).Return(&idl.RenameTablespacesReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"},
+		}
+
+		err := hub.RenameStandbyTablespaces(agentConns, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns errors when renaming the standby tablespaces fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().RenameTablespaces(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: standby, Hostname: "standby"}}
+
+		err := hub.RenameStandbyTablespaces(agentConns, source, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
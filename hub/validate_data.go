@@ -0,0 +1,406 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// Data validation modes. In exact mode every row of every user table is
+// counted. In sampled mode at most sampledRowLimit rows are counted per table,
+// which catches empty and truncated tables without scanning large ones.
+const (
+	DataValidationNone    = "none"
+	DataValidationExact   = "exact"
+	DataValidationSampled = "sampled"
+)
+
+const sampledRowLimit = 100000
+
+const sourceDataSnapshotFileName = "source_data_snapshot.json"
+
+// DataSnapshot holds per-database object counts, row counts, and relation
+// sizes used to verify that the upgraded cluster contains the same data as
+// the source cluster.
+type DataSnapshot struct {
+	Mode      string
+	Databases map[string]*DatabaseSnapshot
+}
+
+type DatabaseSnapshot struct {
+	ObjectCounts  map[string]int64
+	RowCounts     map[string]int64
+	RelationSizes map[string]int64
+}
+
+// IsValidDataValidationMode returns true for the empty string or any of the
+// supported data validation modes.
+func IsValidDataValidationMode(mode string) bool {
+	switch mode {
+	case "", DataValidationNone, DataValidationExact, DataValidationSampled:
+		return true
+	}
+
+	return false
+}
+
+func DataValidationEnabled(mode string) bool {
+	return mode == DataValidationExact || mode == DataValidationSampled
+}
+
+func sourceDataSnapshotPath(stateDir string) string {
+	return filepath.Join(stateDir, sourceDataSnapshotFileName)
+}
+
+// SnapshotSourceData collects the data snapshot from the running source
+// cluster and saves it in the state directory.
+func SnapshotSourceData(streams step.OutStreams, conn *greenplum.Conn, source *greenplum.Cluster, mode string, stateDir string) error {
+	snapshot, err := SnapshotCluster(conn, conn.SourceVersion, mode, greenplum.ToSource(), greenplum.Port(source.MasterPort()))
+	if err != nil {
+		return xerrors.Errorf("snapshot source cluster: %w", err)
+	}
+
+	for _, name := range snapshot.databaseNames() {
+		db := snapshot.Databases[name]
+		fmt.Fprintf(streams.Stdout(), "database %q: %d objects, %d tables\n", name, db.totalObjects(), len(db.RowCounts))
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.AtomicallyWrite(sourceDataSnapshotPath(stateDir), data)
+}
+
+// ValidateTargetData compares the saved source snapshot with a snapshot of the
+// running intermediate cluster. A report is written to stdout. Differences in
+// object or row counts are returned as an error, while differences in
+// relation size are only reported as warnings.
+func ValidateTargetData(streams step.OutStreams, conn *greenplum.Conn, intermediate *greenplum.Cluster, stateDir string) error {
+	data, err := utils.System.ReadFile(sourceDataSnapshotPath(stateDir))
+	if err != nil {
+		return xerrors.Errorf("read source data snapshot: %w", err)
+	}
+
+	var source DataSnapshot
+	if err := json.Unmarshal(data, &source); err != nil {
+		return xerrors.Errorf("parse source data snapshot: %w", err)
+	}
+
+	target, err := SnapshotCluster(conn, conn.TargetVersion, source.Mode, greenplum.ToTarget(), greenplum.Port(intermediate.MasterPort()))
+	if err != nil {
+		return xerrors.Errorf("snapshot target cluster: %w", err)
+	}
+
+	mismatches, warnings := CompareDataSnapshots(&source, target)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(streams.Stdout(), "WARNING: %s\n", warning)
+	}
+
+	for _, mismatch := range mismatches {
+		fmt.Fprintf(streams.Stdout(), "MISMATCH: %s\n", mismatch)
+	}
+
+	if len(mismatches) > 0 {
+		return xerrors.Errorf("data validation found %d mismatches between the source and target clusters:\n%s",
+			len(mismatches), strings.Join(mismatches, "\n"))
+	}
+
+	fmt.Fprintf(streams.Stdout(), "data validation passed for %d databases with %d warnings\n", len(source.Databases), len(warnings))
+	return nil
+}
+
+// CompareDataSnapshots returns the differences between a source and target
+// snapshot. Mismatches are missing databases or tables and differing object
+// or row counts. Warnings are differing relation sizes and databases that only
+// exist in the target.
+func CompareDataSnapshots(source, target *DataSnapshot) (mismatches []string, warnings []string) {
+	for _, name := range source.databaseNames() {
+		sourceDB := source.Databases[name]
+		targetDB, ok := target.Databases[name]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("database %q: missing on target", name))
+			continue
+		}
+
+		for _, kind := range sortedKeys(sourceDB.ObjectCounts, targetDB.ObjectCounts) {
+			if sourceDB.ObjectCounts[kind] != targetDB.ObjectCounts[kind] {
+				mismatches = append(mismatches, fmt.Sprintf("database %q: %s count is %d on source and %d on target",
+					name, kind, sourceDB.ObjectCounts[kind], targetDB.ObjectCounts[kind]))
+			}
+		}
+
+		for _, table := range sortedKeys(sourceDB.RowCounts) {
+			targetRows, ok := targetDB.RowCounts[table]
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("database %q: table %s missing on target", name, table))
+				continue
+			}
+
+			if sourceDB.RowCounts[table] != targetRows {
+				mismatches = append(mismatches, fmt.Sprintf("database %q: table %s has %d rows on source and %d rows on target",
+					name, table, sourceDB.RowCounts[table], targetRows))
+			}
+		}
+
+		for _, table := range sortedKeys(sourceDB.RelationSizes) {
+			targetSize, ok := targetDB.RelationSizes[table]
+			if !ok {
+				continue // already reported as a missing table
+			}
+
+			if sourceDB.RelationSizes[table] != targetSize {
+				warnings = append(warnings, fmt.Sprintf("database %q: relation %s is %d bytes on source and %d bytes on target",
+					name, table, sourceDB.RelationSizes[table], targetSize))
+			}
+		}
+	}
+
+	for _, name := range target.databaseNames() {
+		if _, ok := source.Databases[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("database %q: only exists on target", name))
+		}
+	}
+
+	return mismatches, warnings
+}
+
+// SnapshotCluster collects a DataSnapshot from every connectable database of
+// the cluster described by options.
+func SnapshotCluster(conn *greenplum.Conn, version semver.Version, mode string, options ...greenplum.Option) (*DataSnapshot, error) {
+	databases, err := listDatabases(conn, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &DataSnapshot{Mode: mode, Databases: make(map[string]*DatabaseSnapshot)}
+	for _, name := range databases {
+		dbSnapshot, err := snapshotDatabase(conn, name, version, mode, options...)
+		if err != nil {
+			return nil, xerrors.Errorf("database %q: %w", name, err)
+		}
+
+		snapshot.Databases[name] = dbSnapshot
+	}
+
+	return snapshot, nil
+}
+
+func listDatabases(conn *greenplum.Conn, options ...greenplum.Option) (databases []string, err error) {
+	db, err := sql.Open("pgx", conn.URI(options...))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return Databases(db)
+}
+
+// Databases returns the names of all databases that allow connections except
+// template0.
+func Databases(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`SELECT datname FROM pg_database WHERE datallowconn AND datname <> 'template0' ORDER BY datname;`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, xerrors.Errorf("scanning databases: %w", err)
+		}
+
+		databases = append(databases, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating databases: %w", err)
+	}
+
+	return databases, nil
+}
+
+func snapshotDatabase(conn *greenplum.Conn, name string, version semver.Version, mode string, options ...greenplum.Option) (snapshot *DatabaseSnapshot, err error) {
+	options = append(options, greenplum.Database(name))
+
+	db, err := sql.Open("pgx", conn.URI(options...))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return SnapshotDatabase(db, version, mode)
+}
+
+// userRelationFilter excludes system relations, auxiliary AO, toast and
+// bitmap index relations, and temporary relations.
+const userRelationFilter = `c.oid >= 16384
+    AND n.nspname NOT IN ('pg_toast', 'pg_aoseg', 'pg_bitmapindex')
+    AND n.nspname NOT LIKE 'pg_temp%'
+    AND n.nspname NOT LIKE 'pg_toast_temp%'`
+
+// SnapshotDatabase collects object counts, row counts and relation sizes for
+// the user objects in a single database.
+func SnapshotDatabase(db *sql.DB, version semver.Version, mode string) (*DatabaseSnapshot, error) {
+	objects, err := queryCounts(db, objectCountsQuery(version))
+	if err != nil {
+		return nil, xerrors.Errorf("counting objects: %w", err)
+	}
+
+	sizes, err := queryCounts(db, relationSizesQuery(version))
+	if err != nil {
+		return nil, xerrors.Errorf("querying relation sizes: %w", err)
+	}
+
+	rowCounts := make(map[string]int64, len(sizes))
+	for table := range sizes {
+		query := fmt.Sprintf("SELECT count(*) FROM %s;", table)
+		if mode == DataValidationSampled {
+			query = fmt.Sprintf("SELECT count(*) FROM (SELECT 1 FROM %s LIMIT %d) sample;", table, sampledRowLimit)
+		}
+
+		var count int64
+		if err := db.QueryRow(query).Scan(&count); err != nil {
+			return nil, xerrors.Errorf("counting rows of %s: %w", table, err)
+		}
+
+		rowCounts[table] = count
+	}
+
+	return &DatabaseSnapshot{
+		ObjectCounts:  objects,
+		RowCounts:     rowCounts,
+		RelationSizes: sizes,
+	}, nil
+}
+
+// objectCountsQuery counts user objects by kind. External tables are
+// represented as foreign tables starting in 7X, so both are counted together.
+func objectCountsQuery(version semver.Version) string {
+	externalTables := "c.relkind = 'f'"
+	if version.Major < 7 {
+		externalTables = "c.relkind = 'f' OR (c.relkind = 'r' AND c.relstorage = 'x')"
+	}
+
+	return fmt.Sprintf(`
+SELECT kind, count(*) FROM (
+    SELECT CASE
+        WHEN %s THEN 'external and foreign tables'
+        WHEN c.relkind IN ('r', 'p') THEN 'tables'
+        WHEN c.relkind IN ('i', 'I') THEN 'indexes'
+        WHEN c.relkind = 'v' THEN 'views'
+        WHEN c.relkind = 'm' THEN 'materialized views'
+        WHEN c.relkind = 'S' THEN 'sequences'
+    END AS kind
+    FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+    WHERE c.relkind IN ('r', 'p', 'i', 'I', 'v', 'm', 'S', 'f')
+    AND %s
+    UNION ALL
+    SELECT 'schemas' FROM pg_namespace n
+    WHERE n.oid >= 16384 AND n.nspname NOT LIKE 'pg_temp%%' AND n.nspname NOT LIKE 'pg_toast_temp%%'
+    UNION ALL
+    SELECT 'functions' FROM pg_proc WHERE oid >= 16384
+) objects GROUP BY kind;`, externalTables, userRelationFilter)
+}
+
+// relationSizesQuery returns the total size of every user table whose rows
+// can be counted, which excludes external and foreign tables.
+func relationSizesQuery(version semver.Version) string {
+	external := ""
+	if version.Major < 7 {
+		external = "\nAND c.relstorage <> 'x'"
+	}
+
+	return fmt.Sprintf(`
+SELECT quote_ident(n.nspname) || '.' || quote_ident(c.relname), pg_total_relation_size(c.oid)
+FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p')
+AND %s%s;`, userRelationFilter, external)
+}
+
+func queryCounts(db *sql.DB, query string) (map[string]int64, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var count int64
+		if err := rows.Scan(&name, &count); err != nil {
+			return nil, err
+		}
+
+		counts[name] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (s *DataSnapshot) databaseNames() []string {
+	var names []string
+	for name := range s.Databases {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (d *DatabaseSnapshot) totalObjects() int64 {
+	var total int64
+	for _, count := range d.ObjectCounts {
+		total += count
+	}
+
+	return total
+}
+
+// sortedKeys returns the union of the keys of the given maps in sorted order.
+func sortedKeys(maps ...map[string]int64) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestSnapshotDatabase(t *testing.T) {
+	t.Run("collects object counts, relation sizes, and row counts", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT kind, count\(\*\) FROM .* c.relstorage = 'x'`).
+			WillReturnRows(sqlmock.NewRows([]string{"kind", "count"}).
+				AddRow("tables", 1).
+				AddRow("functions", 3))
+		mock.ExpectQuery(`SELECT quote_ident\(n.nspname\) .* AND c.relstorage <> 'x'`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "size"}).
+				AddRow("public.foo", 32768))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM public.foo;`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+		snapshot, err := hub.SnapshotDatabase(db, semver.MustParse("6.20.0"), hub.DataValidationExact)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := &hub.DatabaseSnapshot{
+			ObjectCounts:  map[string]int64{"tables": 1, "functions": 3},
+			RowCounts:     map[string]int64{"public.foo": 10},
+			RelationSizes: map[string]int64{"public.foo": 32768},
+		}
+		if !reflect.DeepEqual(snapshot, expected) {
+			t.Errorf("got %+v want %+v", snapshot, expected)
+		}
+	})
+
+	t.Run("limits the rows counted in sampled mode and does not reference relstorage in 7X", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT kind, count\(\*\) FROM .*WHEN c.relkind = 'f' THEN`).
+			WillReturnRows(sqlmock.NewRows([]string{"kind", "count"}).AddRow("tables", 1))
+		mock.ExpectQuery(`SELECT quote_ident\(n.nspname\) .* LIKE 'pg_toast_temp%';`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "size"}).AddRow("public.foo", 32768))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM \(SELECT 1 FROM public.foo LIMIT 100000\) sample;`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+		_, err = hub.SnapshotDatabase(db, semver.MustParse("7.0.0"), hub.DataValidationSampled)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("errors when counting rows fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("permission denied")
+		mock.ExpectQuery(`SELECT kind`).
+			WillReturnRows(sqlmock.NewRows([]string{"kind", "count"}))
+		mock.ExpectQuery(`SELECT quote_ident`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "size"}).AddRow("public.foo", 32768))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM public.foo;`).
+			WillReturnError(expected)
+
+		_, err = hub.SnapshotDatabase(db, semver.MustParse("6.20.0"), hub.DataValidationExact)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestCompareDataSnapshots(t *testing.T) {
+	source := &hub.DataSnapshot{
+		Mode: hub.DataValidationExact,
+		Databases: map[string]*hub.DatabaseSnapshot{
+			"postgres": {
+				ObjectCounts:  map[string]int64{"tables": 2, "views": 1},
+				RowCounts:     map[string]int64{"public.foo": 10, "public.bar": 5},
+				RelationSizes: map[string]int64{"public.foo": 32768, "public.bar": 8192},
+			},
+			"missing": {},
+		},
+	}
+
+	t.Run("reports no differences for identical snapshots", func(t *testing.T) {
+		mismatches, warnings := hub.CompareDataSnapshots(source, source)
+		if len(mismatches) != 0 || len(warnings) != 0 {
+			t.Errorf("got mismatches %q and warnings %q, want none", mismatches, warnings)
+		}
+	})
+
+	t.Run("reports count differences as mismatches and size differences as warnings", func(t *testing.T) {
+		target := &hub.DataSnapshot{
+			Mode: hub.DataValidationExact,
+			Databases: map[string]*hub.DatabaseSnapshot{
+				"postgres": {
+					ObjectCounts:  map[string]int64{"tables": 1, "views": 1, "functions": 1},
+					RowCounts:     map[string]int64{"public.foo": 9},
+					RelationSizes: map[string]int64{"public.foo": 65536},
+				},
+				"extra": {},
+			},
+		}
+
+		mismatches, warnings := hub.CompareDataSnapshots(source, target)
+
+		expectedMismatches := []string{
+			`database "missing": missing on target`,
+			`database "postgres": functions count is 0 on source and 1 on target`,
+			`database "postgres": tables count is 2 on source and 1 on target`,
+			`database "postgres": table public.bar missing on target`,
+			`database "postgres": table public.foo has 10 rows on source and 9 rows on target`,
+		}
+		if !reflect.DeepEqual(mismatches, expectedMismatches) {
+			t.Errorf("got mismatches %q want %q", mismatches, expectedMismatches)
+		}
+
+		expectedWarnings := []string{
+			`database "postgres": relation public.foo is 32768 bytes on source and 65536 bytes on target`,
+			`database "extra": only exists on target`,
+		}
+		if !reflect.DeepEqual(warnings, expectedWarnings) {
+			t.Errorf("got warnings %q want %q", warnings, expectedWarnings)
+		}
+	})
+}
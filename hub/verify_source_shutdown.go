@@ -0,0 +1,99 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+var getControlData = greenplum.GetControlData
+
+// VerifySourceShutdown ensures pg_controldata reports that the master and
+// every primary of the source cluster were cleanly shut down. pg_upgrade
+// requires a clean shutdown, and otherwise fails part way through after the
+// master has been upgraded.
+func VerifySourceShutdown(agentConns []*idl.Connection, source *greenplum.Cluster) error {
+	master, err := getControlData(source.GPHome, source.MasterDataDir())
+	if err != nil {
+		return xerrors.Errorf("getting control data on master host: %w", err)
+	}
+
+	problems := shutdownProblems(source.MasterHostname(), []*idl.ControlData{master}, source)
+
+	var mu sync.Mutex
+	request := func(conn *idl.Connection) error {
+		segments := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsPrimary() && seg.IsOnHost(conn.Hostname)
+		})
+		if len(segments) == 0 {
+			return nil
+		}
+		sort.Sort(segments)
+
+		var dataDirs []string
+		for _, seg := range segments {
+			dataDirs = append(dataDirs, seg.DataDir)
+		}
+
+		reply, err := conn.AgentClient.GetControlData(context.Background(), &idl.GetControlDataRequest{
+			Gphome:   source.GPHome,
+			DataDirs: dataDirs,
+		})
+		if err != nil {
+			return xerrors.Errorf("getting control data on host %q: %w", conn.Hostname, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		problems = append(problems, shutdownProblems(conn.Hostname, reply.GetControlData(), source)...)
+		return nil
+	}
+
+	if err := ExecuteRPC(agentConns, request); err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return xerrors.Errorf(`The source cluster was not cleanly shut down:
+%s
+
+Start the source cluster and stop it with "gpstop -a -M fast" before rerunning
+execute.`, strings.Join(problems, "\n"))
+}
+
+// shutdownProblems describes each data directory on host whose cluster state
+// is not shut down.
+func shutdownProblems(host string, controlData []*idl.ControlData, source *greenplum.Cluster) []string {
+	dbids := make(map[string]int)
+	for _, seg := range allSegments(source) {
+		if seg.IsOnHost(host) {
+			dbids[seg.DataDir] = seg.DbID
+		}
+	}
+
+	var problems []string
+	for _, data := range controlData {
+		if data.GetClusterState() == greenplum.ClusterStateShutDown {
+			continue
+		}
+
+		problems = append(problems, fmt.Sprintf("host %q dbid %d data directory %q: cluster state is %q",
+			host, dbids[data.GetDataDir()], data.GetDataDir(), data.GetClusterState()))
+	}
+
+	return problems
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestVerifySourceShutdown(t *testing.T) {
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25432, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast2/seg2", Port: 25433, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg2", Port: 25435, Role: greenplum.MirrorRole},
+	})
+	source.GPHome = "/usr/local/gpdb5"
+
+	masterState := greenplum.ClusterStateShutDown
+	hub.SetGetControlData(func(gphome string, dataDir string) (*idl.ControlData, error) {
+		if gphome != "/usr/local/gpdb5" || dataDir != "/data/qddir/seg-1" {
+			t.Errorf("got gphome %q data directory %q want %q and %q", gphome, dataDir, "/usr/local/gpdb5", "/data/qddir/seg-1")
+		}
+
+		return &idl.ControlData{DataDir: dataDir, ClusterState: masterState}, nil
+	})
+	defer hub.ResetGetControlData()
+
+	expectedRequest := &idl.GetControlDataRequest{
+		Gphome:   "/usr/local/gpdb5",
+		DataDirs: []string{"/data/dbfast1/seg1", "/data/dbfast2/seg2"},
+	}
+
+	t.Run("succeeds when every primary is shut down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetControlData(gomock.Any(), expectedRequest).Return(&idl.GetControlDataReply{
+			Hostname: "sdw1",
+			ControlData: []*idl.ControlData{
+				{DataDir: "/data/dbfast1/seg1", ClusterState: greenplum.ClusterStateShutDown},
+				{DataDir: "/data/dbfast2/seg2", ClusterState: greenplum.ClusterStateShutDown},
+			},
+		}, nil)
+
+		// mirrors are not checked
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		err := hub.VerifySourceShutdown(agentConns, source)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+	})
+
+	t.Run("reports every segment that was not shut down", func(t *testing.T) {
+		masterState = "in production"
+		defer func() { masterState = greenplum.ClusterStateShutDown }()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetControlData(gomock.Any(), expectedRequest).Return(&idl.GetControlDataReply{
+			Hostname: "sdw1",
+			ControlData: []*idl.ControlData{
+				{DataDir: "/data/dbfast1/seg1", ClusterState: greenplum.ClusterStateShutDown},
+				{DataDir: "/data/dbfast2/seg2", ClusterState: "shut down in recovery"},
+			},
+		}, nil)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.VerifySourceShutdown(agentConns, source)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		for _, expected := range []string{
+			`host "mdw" dbid 1 data directory "/data/qddir/seg-1": cluster state is "in production"`,
+			`host "sdw1" dbid 3 data directory "/data/dbfast2/seg2": cluster state is "shut down in recovery"`,
+		} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("got error %q want it to contain %q", err, expected)
+			}
+		}
+
+		if strings.Contains(err.Error(), "/data/dbfast1/seg1") {
+			t.Errorf("got error %q want it to exclude the segment that was shut down", err)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetControlData(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		err := hub.VerifySourceShutdown(agentConns, source)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
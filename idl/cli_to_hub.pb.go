@@ -128,6 +128,23 @@ const (
 	Substep_WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG           Substep = 33
 	Substep_STOP_TARGET_CLUSTER                                           Substep = 34
 	Substep_SETTING_DYNAMIC_LIBRARY_PATH_ON_TARGET_CLSUTER                Substep = 35
+	Substep_SNAPSHOT_SOURCE_DATA                                          Substep = 36
+	Substep_VALIDATE_TARGET_DATA                                          Substep = 37
+	Substep_DUMP_SOURCE_SCHEMA                                            Substep = 38
+	Substep_DUMP_TARGET_SCHEMA                                            Substep = 39
+	Substep_RUN_SMOKE_TESTS                                               Substep = 40
+	Substep_CHECK_EXTENSIONS                                              Substep = 41
+	Substep_CHECK_LIBRARIES                                               Substep = 42
+	Substep_UPGRADE_EXTENSIONS                                            Substep = 43
+	Substep_CHECK_LINK_MODE_FILESYSTEMS                                   Substep = 44
+	Substep_CHECK_TARGET_PORTS                                            Substep = 45
+	Substep_CHECK_HOSTS                                                   Substep = 46
+	Substep_CHECK_SOURCE_CLUSTER_HEALTH                                   Substep = 47
+	Substep_QUIESCE_SOURCE_CLUSTER                                        Substep = 48
+	Substep_VERIFY_SOURCE_SHUTDOWN                                        Substep = 49
+	Substep_CHECK_CLUSTER_TOPOLOGY                                        Substep = 50
+	Substep_MIGRATE_SOURCE_SETTINGS                                       Substep = 51
+	Substep_MIGRATE_AUTH_FILES                                            Substep = 52
 )
 
 var Substep_name = map[int32]string{
@@ -167,6 +184,23 @@ var Substep_name = map[int32]string{
 	33: "WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG",
 	34: "STOP_TARGET_CLUSTER",
 	35: "SETTING_DYNAMIC_LIBRARY_PATH_ON_TARGET_CLSUTER",
+	36: "SNAPSHOT_SOURCE_DATA",
+	37: "VALIDATE_TARGET_DATA",
+	38: "DUMP_SOURCE_SCHEMA",
+	39: "DUMP_TARGET_SCHEMA",
+	40: "RUN_SMOKE_TESTS",
+	41: "CHECK_EXTENSIONS",
+	42: "CHECK_LIBRARIES",
+	43: "UPGRADE_EXTENSIONS",
+	44: "CHECK_LINK_MODE_FILESYSTEMS",
+	45: "CHECK_TARGET_PORTS",
+	46: "CHECK_HOSTS",
+	47: "CHECK_SOURCE_CLUSTER_HEALTH",
+	48: "QUIESCE_SOURCE_CLUSTER",
+	49: "VERIFY_SOURCE_SHUTDOWN",
+	50: "CHECK_CLUSTER_TOPOLOGY",
+	51: "MIGRATE_SOURCE_SETTINGS",
+	52: "MIGRATE_AUTH_FILES",
 }
 
 var Substep_value = map[string]int32{
@@ -206,6 +240,23 @@ var Substep_value = map[string]int32{
 	"WAIT_FOR_CLUSTER_TO_BE_READY_AFTER_UPDATING_CATALOG":           33,
 	"STOP_TARGET_CLUSTER":                            34,
 	"SETTING_DYNAMIC_LIBRARY_PATH_ON_TARGET_CLSUTER": 35,
+	"SNAPSHOT_SOURCE_DATA":                           36,
+	"VALIDATE_TARGET_DATA":                           37,
+	"DUMP_SOURCE_SCHEMA":                             38,
+	"DUMP_TARGET_SCHEMA":                             39,
+	"RUN_SMOKE_TESTS":                                40,
+	"CHECK_EXTENSIONS":                               41,
+	"CHECK_LIBRARIES":                                42,
+	"UPGRADE_EXTENSIONS":                             43,
+	"CHECK_LINK_MODE_FILESYSTEMS":                    44,
+	"CHECK_TARGET_PORTS":                             45,
+	"CHECK_HOSTS":                                    46,
+	"CHECK_SOURCE_CLUSTER_HEALTH":                    47,
+	"QUIESCE_SOURCE_CLUSTER":                         48,
+	"VERIFY_SOURCE_SHUTDOWN":                         49,
+	"CHECK_CLUSTER_TOPOLOGY":                         50,
+	"MIGRATE_SOURCE_SETTINGS":                        51,
+	"MIGRATE_AUTH_FILES":                             52,
 }
 
 func (x Substep) String() string {
@@ -279,17 +330,27 @@ func (Chunk_Type) EnumDescriptor() ([]byte, []int) {
 }
 
 type InitializeRequest struct {
-	AgentPort            int32    `protobuf:"varint,1,opt,name=agentPort,proto3" json:"agentPort,omitempty"`
-	SourceGPHome         string   `protobuf:"bytes,2,opt,name=sourceGPHome,proto3" json:"sourceGPHome,omitempty"`
-	TargetGPHome         string   `protobuf:"bytes,3,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
-	SourcePort           int32    `protobuf:"varint,4,opt,name=sourcePort,proto3" json:"sourcePort,omitempty"`
-	UseLinkMode          bool     `protobuf:"varint,5,opt,name=useLinkMode,proto3" json:"useLinkMode,omitempty"`
-	UseHbaHostnames      bool     `protobuf:"varint,6,opt,name=useHbaHostnames,proto3" json:"useHbaHostnames,omitempty"`
-	Ports                []uint32 `protobuf:"varint,7,rep,packed,name=ports,proto3" json:"ports,omitempty"`
-	DiskFreeRatio        float64  `protobuf:"fixed64,8,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AgentPort             int32    `protobuf:"varint,1,opt,name=agentPort,proto3" json:"agentPort,omitempty"`
+	SourceGPHome          string   `protobuf:"bytes,2,opt,name=sourceGPHome,proto3" json:"sourceGPHome,omitempty"`
+	TargetGPHome          string   `protobuf:"bytes,3,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
+	SourcePort            int32    `protobuf:"varint,4,opt,name=sourcePort,proto3" json:"sourcePort,omitempty"`
+	UseLinkMode           bool     `protobuf:"varint,5,opt,name=useLinkMode,proto3" json:"useLinkMode,omitempty"`
+	UseHbaHostnames       bool     `protobuf:"varint,6,opt,name=useHbaHostnames,proto3" json:"useHbaHostnames,omitempty"`
+	Ports                 []uint32 `protobuf:"varint,7,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	DiskFreeRatio         float64  `protobuf:"fixed64,8,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
+	DataValidation        string   `protobuf:"bytes,9,opt,name=dataValidation,proto3" json:"dataValidation,omitempty"`
+	DumpSchemas           bool     `protobuf:"varint,10,opt,name=dumpSchemas,proto3" json:"dumpSchemas,omitempty"`
+	SmokeTestDir          string   `protobuf:"bytes,11,opt,name=smokeTestDir,proto3" json:"smokeTestDir,omitempty"`
+	UpgradeExtensions     bool     `protobuf:"varint,12,opt,name=upgradeExtensions,proto3" json:"upgradeExtensions,omitempty"`
+	EstimateDiskSpace     bool     `protobuf:"varint,13,opt,name=estimateDiskSpace,proto3" json:"estimateDiskSpace,omitempty"`
+	AutoAssignPorts       bool     `protobuf:"varint,14,opt,name=autoAssignPorts,proto3" json:"autoAssignPorts,omitempty"`
+	ClusterReadyTimeout   uint32   `protobuf:"varint,15,opt,name=clusterReadyTimeout,proto3" json:"clusterReadyTimeout,omitempty"`
+	Rebalance             bool     `protobuf:"varint,16,opt,name=rebalance,proto3" json:"rebalance,omitempty"`
+	GpinitsystemOverrides string   `protobuf:"bytes,17,opt,name=gpinitsystemOverrides,proto3" json:"gpinitsystemOverrides,omitempty"`
+	RsyncMirrors          bool     `protobuf:"varint,18,opt,name=rsyncMirrors,proto3" json:"rsyncMirrors,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *InitializeRequest) Reset()         { *m = InitializeRequest{} }
@@ -373,6 +434,76 @@ func (m *InitializeRequest) GetDiskFreeRatio() float64 {
 	return 0
 }
 
+func (m *InitializeRequest) GetDataValidation() string {
+	if m != nil {
+		return m.DataValidation
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetDumpSchemas() bool {
+	if m != nil {
+		return m.DumpSchemas
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetSmokeTestDir() string {
+	if m != nil {
+		return m.SmokeTestDir
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetUpgradeExtensions() bool {
+	if m != nil {
+		return m.UpgradeExtensions
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetEstimateDiskSpace() bool {
+	if m != nil {
+		return m.EstimateDiskSpace
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetAutoAssignPorts() bool {
+	if m != nil {
+		return m.AutoAssignPorts
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetClusterReadyTimeout() uint32 {
+	if m != nil {
+		return m.ClusterReadyTimeout
+	}
+	return 0
+}
+
+func (m *InitializeRequest) GetRebalance() bool {
+	if m != nil {
+		return m.Rebalance
+	}
+	return false
+}
+
+func (m *InitializeRequest) GetGpinitsystemOverrides() string {
+	if m != nil {
+		return m.GpinitsystemOverrides
+	}
+	return ""
+}
+
+func (m *InitializeRequest) GetRsyncMirrors() bool {
+	if m != nil {
+		return m.RsyncMirrors
+	}
+	return false
+}
+
 type InitializeCreateClusterRequest struct {
 	DynamicLibraryPath   string   `protobuf:"bytes,1,opt,name=dynamicLibraryPath,proto3" json:"dynamicLibraryPath,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -413,6 +544,9 @@ func (m *InitializeCreateClusterRequest) GetDynamicLibraryPath() string {
 }
 
 type ExecuteRequest struct {
+	SkipDiskSpaceCheck   bool     `protobuf:"varint,1,opt,name=skipDiskSpaceCheck,proto3" json:"skipDiskSpaceCheck,omitempty"`
+	Quiesce              string   `protobuf:"bytes,2,opt,name=quiesce,proto3" json:"quiesce,omitempty"`
+	QuiesceTimeout       uint32   `protobuf:"varint,3,opt,name=quiesceTimeout,proto3" json:"quiesceTimeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -443,6 +577,27 @@ func (m *ExecuteRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ExecuteRequest proto.InternalMessageInfo
 
+func (m *ExecuteRequest) GetSkipDiskSpaceCheck() bool {
+	if m != nil {
+		return m.SkipDiskSpaceCheck
+	}
+	return false
+}
+
+func (m *ExecuteRequest) GetQuiesce() string {
+	if m != nil {
+		return m.Quiesce
+	}
+	return ""
+}
+
+func (m *ExecuteRequest) GetQuiesceTimeout() uint32 {
+	if m != nil {
+		return m.QuiesceTimeout
+	}
+	return 0
+}
+
 type FinalizeRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1049,6 +1204,7 @@ type Cluster struct {
 	GPHome               string   `protobuf:"bytes,1,opt,name=GPHome,proto3" json:"GPHome,omitempty"`
 	Port                 int32    `protobuf:"varint,2,opt,name=Port,proto3" json:"Port,omitempty"`
 	MasterDataDirectory  string   `protobuf:"bytes,3,opt,name=MasterDataDirectory,proto3" json:"MasterDataDirectory,omitempty"`
+	Version              string   `protobuf:"bytes,4,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1100,6 +1256,13 @@ func (m *Cluster) GetMasterDataDirectory() string {
 	return ""
 }
 
+func (m *Cluster) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 type ExecuteResponse struct {
 	Target               *Cluster `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1145,6 +1308,7 @@ type FinalizeResponse struct {
 	LogArchiveDirectory               string   `protobuf:"bytes,3,opt,name=LogArchiveDirectory,proto3" json:"LogArchiveDirectory,omitempty"`
 	ArchivedSourceMasterDataDirectory string   `protobuf:"bytes,4,opt,name=ArchivedSourceMasterDataDirectory,proto3" json:"ArchivedSourceMasterDataDirectory,omitempty"`
 	UpgradeID                         string   `protobuf:"bytes,5,opt,name=UpgradeID,proto3" json:"UpgradeID,omitempty"`
+	ExtensionUpgradeFailures          []string `protobuf:"bytes,6,rep,name=ExtensionUpgradeFailures,proto3" json:"ExtensionUpgradeFailures,omitempty"`
 	XXX_NoUnkeyedLiteral              struct{} `json:"-"`
 	XXX_unrecognized                  []byte   `json:"-"`
 	XXX_sizecache                     int32    `json:"-"`
@@ -1210,6 +1374,13 @@ func (m *FinalizeResponse) GetUpgradeID() string {
 	return ""
 }
 
+func (m *FinalizeResponse) GetExtensionUpgradeFailures() []string {
+	if m != nil {
+		return m.ExtensionUpgradeFailures
+	}
+	return nil
+}
+
 type RevertResponse struct {
 	Source               *Cluster `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
 	SourceVersion        string   `protobuf:"bytes,2,opt,name=SourceVersion,proto3" json:"SourceVersion,omitempty"`
@@ -1418,114 +1589,143 @@ func init() {
 func init() { proto.RegisterFile("cli_to_hub.proto", fileDescriptor_631e66a01873be02) }
 
 var fileDescriptor_631e66a01873be02 = []byte{
-	// 1711 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x4f, 0x6f, 0xdb, 0xc8,
-	0x15, 0x97, 0x6c, 0x59, 0x92, 0x9f, 0xfe, 0x8d, 0x47, 0xfe, 0x23, 0x3b, 0xd9, 0x54, 0xcb, 0x2c,
-	0x16, 0x46, 0xb6, 0x70, 0x03, 0x6d, 0xd1, 0x45, 0x0f, 0x0b, 0x94, 0x22, 0x47, 0x12, 0x61, 0x89,
-	0x24, 0x86, 0x94, 0x53, 0xf7, 0x42, 0xd0, 0xf2, 0xc4, 0x21, 0xa2, 0x88, 0x5a, 0x92, 0x0a, 0xe2,
-	0x7e, 0x84, 0x1e, 0x7a, 0xea, 0x17, 0xe8, 0xa9, 0x5f, 0xaf, 0xa7, 0x9e, 0x8b, 0x19, 0x0e, 0x65,
-	0x89, 0x56, 0xd0, 0xee, 0x8d, 0xfc, 0xbd, 0xf7, 0x7e, 0xf3, 0xfe, 0xcd, 0x9b, 0x19, 0x40, 0xb3,
-	0x79, 0xe0, 0x25, 0xa1, 0xf7, 0x61, 0x75, 0x77, 0xb5, 0x8c, 0xc2, 0x24, 0xc4, 0xfb, 0xc1, 0xfd,
-	0x5c, 0xf9, 0xe7, 0x1e, 0x1c, 0x19, 0x8b, 0x20, 0x09, 0xfc, 0x79, 0xf0, 0x57, 0x46, 0xd9, 0x2f,
-	0x2b, 0x16, 0x27, 0xf8, 0x25, 0x1c, 0xfa, 0x0f, 0x6c, 0x91, 0xd8, 0x61, 0x94, 0x74, 0x8a, 0xdd,
-	0xe2, 0xe5, 0x01, 0x7d, 0x02, 0xb0, 0x02, 0xf5, 0x38, 0x5c, 0x45, 0x33, 0x36, 0xb4, 0x47, 0xe1,
-	0x27, 0xd6, 0xd9, 0xeb, 0x16, 0x2f, 0x0f, 0xe9, 0x16, 0xc6, 0x75, 0x12, 0x3f, 0x7a, 0x60, 0x89,
-	0xd4, 0xd9, 0x4f, 0x75, 0x36, 0x31, 0xfc, 0x0a, 0x20, 0xb5, 0x11, 0xcb, 0x94, 0xc4, 0x32, 0x1b,
-	0x08, 0xee, 0x42, 0x6d, 0x15, 0xb3, 0x71, 0xb0, 0xf8, 0x38, 0x09, 0xef, 0x59, 0xe7, 0xa0, 0x5b,
-	0xbc, 0xac, 0xd2, 0x4d, 0x08, 0x5f, 0x42, 0x6b, 0x15, 0xb3, 0xd1, 0x9d, 0x3f, 0x0a, 0xe3, 0x64,
-	0xe1, 0x7f, 0x62, 0x71, 0xa7, 0x2c, 0xb4, 0xf2, 0x30, 0x3e, 0x86, 0x83, 0x65, 0x18, 0x25, 0x71,
-	0xa7, 0xd2, 0xdd, 0xbf, 0x6c, 0xd0, 0xf4, 0x07, 0x7f, 0x07, 0x8d, 0xfb, 0x20, 0xfe, 0x38, 0x88,
-	0x18, 0xa3, 0x7e, 0x12, 0x84, 0x9d, 0x6a, 0xb7, 0x78, 0x59, 0xa4, 0xdb, 0xa0, 0x62, 0xc3, 0xab,
-	0xa7, 0x14, 0x69, 0x11, 0xf3, 0x13, 0xa6, 0xcd, 0x57, 0x71, 0xc2, 0xa2, 0x2c, 0x5f, 0x57, 0x80,
-	0xef, 0x1f, 0x17, 0xfe, 0xa7, 0x60, 0x36, 0x0e, 0xee, 0x22, 0x3f, 0x7a, 0xb4, 0xfd, 0xe4, 0x83,
-	0x48, 0xdc, 0x21, 0xdd, 0x21, 0x51, 0x10, 0x34, 0xc9, 0x17, 0x36, 0x5b, 0x25, 0x59, 0xc6, 0x95,
-	0x23, 0x68, 0x0d, 0x82, 0xc5, 0x66, 0x11, 0x94, 0x16, 0x34, 0x28, 0xfb, 0xcc, 0xa2, 0x24, 0x03,
-	0x4e, 0xe1, 0x98, 0xb2, 0x38, 0xf1, 0xa3, 0x44, 0xe5, 0xb5, 0x88, 0x33, 0xfc, 0xf7, 0x80, 0x73,
-	0xf8, 0x72, 0xfe, 0xc8, 0xb3, 0x2b, 0x4a, 0xc6, 0x73, 0x10, 0x77, 0x8a, 0xdd, 0xfd, 0xcb, 0x43,
-	0xba, 0x81, 0x28, 0x27, 0xd0, 0x76, 0x92, 0x70, 0xe9, 0xb0, 0xe8, 0x73, 0x30, 0x63, 0x6b, 0xb2,
-	0x36, 0x1c, 0x6d, 0xc3, 0xcb, 0xf9, 0xa3, 0x72, 0x03, 0x0d, 0x67, 0x75, 0x17, 0x27, 0x6c, 0xe9,
-	0x24, 0x7e, 0xb2, 0x8a, 0x71, 0x17, 0x4a, 0xfc, 0x4f, 0x84, 0xd8, 0xec, 0xd5, 0xaf, 0x82, 0xfb,
-	0xf9, 0x95, 0xd4, 0xa0, 0x42, 0x82, 0x5f, 0x43, 0x39, 0x16, 0xba, 0xa2, 0x3d, 0x9a, 0xbd, 0x5a,
-	0xaa, 0x23, 0x20, 0x2a, 0x45, 0xca, 0x0b, 0x38, 0xb7, 0x23, 0xb6, 0xf4, 0x23, 0xc6, 0x13, 0xbc,
-	0x9d, 0x54, 0xe5, 0x1c, 0xce, 0x76, 0x09, 0xb9, 0x3f, 0xbf, 0xc0, 0x81, 0xf6, 0x61, 0xb5, 0xf8,
-	0x88, 0x4f, 0xa1, 0x7c, 0xb7, 0x7a, 0xff, 0x9e, 0x45, 0xc2, 0x93, 0x3a, 0x95, 0x7f, 0xf8, 0x35,
-	0x94, 0x92, 0xc7, 0x25, 0x93, 0x6b, 0xb7, 0xc4, 0xda, 0xc2, 0xe2, 0xca, 0x7d, 0x5c, 0x32, 0x2a,
-	0x84, 0xca, 0x0f, 0x50, 0xe2, 0x7f, 0xb8, 0x06, 0x95, 0xa9, 0x79, 0x6d, 0x5a, 0xef, 0x4c, 0x54,
-	0xc0, 0x00, 0x65, 0xc7, 0xd5, 0xad, 0xa9, 0x8b, 0x8a, 0xf2, 0x9b, 0x50, 0x8a, 0xf6, 0x94, 0x7f,
-	0x14, 0xa1, 0x32, 0x61, 0x71, 0xec, 0x3f, 0xf0, 0xe6, 0x3e, 0x98, 0x71, 0x32, 0xb1, 0x68, 0xad,
-	0x07, 0x4f, 0xf4, 0xa3, 0x02, 0x4d, 0x45, 0xf8, 0xb7, 0x5b, 0xf1, 0xd7, 0x7a, 0x78, 0x33, 0x47,
-	0x69, 0x1a, 0x46, 0x85, 0x2c, 0x11, 0xf8, 0x07, 0xa8, 0x46, 0x2c, 0x5e, 0x86, 0x8b, 0x38, 0xdd,
-	0x2a, 0xb5, 0x5e, 0x43, 0xe8, 0x53, 0x09, 0x8e, 0x0a, 0x74, 0xad, 0xd0, 0x07, 0xa8, 0xce, 0xc2,
-	0x45, 0xc2, 0x4b, 0xad, 0xfc, 0x6b, 0x0f, 0xaa, 0x99, 0x12, 0x36, 0x00, 0x07, 0x1b, 0x7b, 0x79,
-	0x8b, 0xef, 0x4c, 0xf0, 0x19, 0xcf, 0xc4, 0xa3, 0x02, 0xdd, 0x61, 0x84, 0xff, 0x04, 0x2d, 0x96,
-	0x75, 0xa8, 0xe4, 0x29, 0x09, 0x9e, 0x63, 0xc1, 0x43, 0xb6, 0x65, 0xa3, 0x02, 0xcd, 0xab, 0x63,
-	0x0d, 0xd0, 0xfb, 0x75, 0x47, 0x4b, 0x8a, 0x03, 0x41, 0x71, 0x22, 0x28, 0x06, 0x39, 0xe1, 0xa8,
-	0x40, 0x9f, 0x19, 0xe0, 0x9f, 0xa1, 0x19, 0xc9, 0x3d, 0x20, 0x29, 0xca, 0x82, 0xa2, 0x2d, 0xb3,
-	0xb3, 0x29, 0x1a, 0x15, 0x68, 0x4e, 0x79, 0x2b, 0x53, 0x2e, 0xe0, 0xe7, 0xd1, 0xf3, 0x5d, 0x32,
-	0xf2, 0xe3, 0x49, 0x10, 0x45, 0x61, 0x14, 0x8b, 0x7a, 0x56, 0xe9, 0x06, 0x22, 0xe5, 0x4e, 0xe2,
-	0x2f, 0xee, 0xef, 0x1e, 0x45, 0x29, 0x53, 0xb9, 0x44, 0x94, 0x07, 0xa8, 0xc8, 0xce, 0xe4, 0xbd,
-	0x28, 0x87, 0x5d, 0xba, 0xf1, 0xe5, 0x1f, 0xc6, 0x50, 0x12, 0x03, 0x6e, 0x4f, 0x0c, 0x38, 0xf1,
-	0x8d, 0xdf, 0x42, 0x7b, 0xe2, 0x73, 0x2b, 0xdd, 0x4f, 0x7c, 0x3d, 0x88, 0xd8, 0x2c, 0x09, 0xa3,
-	0x47, 0x39, 0x25, 0x77, 0x89, 0x94, 0x9f, 0xa0, 0x95, 0x4b, 0x3a, 0xfe, 0x0e, 0xca, 0xe9, 0x3c,
-	0x95, 0x7d, 0x98, 0x6e, 0xc3, 0x6c, 0xa3, 0x48, 0x99, 0xf2, 0xb7, 0x3d, 0x40, 0xf9, 0x5c, 0xe3,
-	0x1e, 0x34, 0x5c, 0x21, 0x96, 0xda, 0x3b, 0x19, 0xb6, 0x55, 0xf8, 0xb0, 0x4c, 0x81, 0x1b, 0x16,
-	0xc5, 0x41, 0xb8, 0x90, 0x73, 0x7f, 0x1b, 0xe4, 0x91, 0x8d, 0xc3, 0x07, 0x35, 0x9a, 0x7d, 0x08,
-	0x3e, 0xb3, 0x67, 0x91, 0xed, 0x10, 0xe1, 0x31, 0x7c, 0x2b, 0xb1, 0x7b, 0x47, 0x0c, 0xff, 0x5d,
-	0x99, 0x29, 0x09, 0xfb, 0xff, 0xad, 0xc8, 0x8f, 0xae, 0xe9, 0xf2, 0x21, 0xf2, 0xef, 0x99, 0xa1,
-	0x8b, 0x7e, 0x3b, 0xa4, 0x4f, 0x80, 0xf2, 0xf7, 0x22, 0x34, 0xb7, 0xbb, 0x86, 0x67, 0x31, 0x3d,
-	0x73, 0x76, 0x67, 0x31, 0x95, 0xf1, 0xe0, 0xd3, 0x35, 0x73, 0xc1, 0x6f, 0x81, 0xbf, 0x3e, 0x78,
-	0xe5, 0x7b, 0x40, 0x43, 0x96, 0x68, 0xe1, 0xe2, 0x7d, 0xf0, 0x90, 0x9d, 0x26, 0x18, 0x4a, 0xfc,
-	0xd0, 0x92, 0x6d, 0x24, 0xbe, 0x95, 0xef, 0xa1, 0xb9, 0xa1, 0xc7, 0xe7, 0xfb, 0x31, 0x1c, 0x7c,
-	0xf6, 0xe7, 0xab, 0x4c, 0x2d, 0xfd, 0x51, 0x7e, 0x07, 0x35, 0x93, 0x7d, 0x49, 0xd4, 0x59, 0x12,
-	0x84, 0x0b, 0x3e, 0xa7, 0x6b, 0x8b, 0xa7, 0x5f, 0xa9, 0xba, 0x09, 0xbd, 0x79, 0x07, 0x58, 0xc6,
-	0xaa, 0xb3, 0x38, 0x09, 0x16, 0xfc, 0xc4, 0x5b, 0xe0, 0x33, 0x68, 0xcb, 0x91, 0xe8, 0xe9, 0xc4,
-	0x71, 0x0d, 0x53, 0x75, 0x0d, 0x2b, 0x1b, 0x8f, 0xd6, 0x94, 0x6a, 0x04, 0x15, 0x31, 0x82, 0xba,
-	0x61, 0xba, 0x84, 0x4e, 0x88, 0x6e, 0xa8, 0x2e, 0x41, 0x7b, 0x5c, 0xea, 0xaa, 0x74, 0x48, 0x5c,
-	0xb4, 0xff, 0xc6, 0x82, 0x92, 0xc3, 0x0f, 0x02, 0x04, 0xf5, 0x8c, 0xca, 0x71, 0x89, 0x8d, 0x0a,
-	0xb8, 0x09, 0x60, 0x98, 0x86, 0x6b, 0xa8, 0x63, 0xe3, 0x2f, 0x9c, 0xa7, 0x06, 0x15, 0xf2, 0x67,
-	0xa2, 0x4d, 0x05, 0x45, 0x1d, 0xaa, 0x03, 0xc3, 0x4c, 0x45, 0xfb, 0x9c, 0x90, 0x92, 0x1b, 0x42,
-	0x5d, 0x54, 0x7a, 0xf3, 0x9f, 0x0a, 0x54, 0xe4, 0xfc, 0xc4, 0x6d, 0x68, 0xad, 0x49, 0xa7, 0x7d,
-	0xc9, 0xdb, 0x85, 0x97, 0x8e, 0x7a, 0x63, 0x98, 0x43, 0x2f, 0x75, 0xd1, 0xd3, 0xc6, 0x53, 0xc7,
-	0x25, 0xd4, 0xd3, 0x2c, 0x73, 0x60, 0x0c, 0x51, 0x11, 0x37, 0xe0, 0xd0, 0x71, 0x55, 0xea, 0x7a,
-	0xa3, 0x69, 0x1f, 0xed, 0x71, 0xd7, 0xd2, 0x5f, 0x75, 0x48, 0x4c, 0xd7, 0x41, 0xfb, 0xf8, 0x18,
-	0x90, 0x36, 0x22, 0xda, 0xb5, 0xa7, 0x1b, 0xce, 0xb5, 0xe7, 0xd8, 0xaa, 0x46, 0x50, 0x09, 0x5f,
-	0xc0, 0xe9, 0x90, 0x98, 0x84, 0xaa, 0x2e, 0xf1, 0xd2, 0xf8, 0x32, 0xca, 0x03, 0x9e, 0x29, 0x1e,
-	0xcc, 0x1a, 0x4f, 0x97, 0x44, 0x65, 0xfc, 0x02, 0xce, 0x9c, 0xd1, 0xd4, 0xd5, 0xb9, 0x8f, 0x39,
-	0x61, 0x05, 0x77, 0xe0, 0xb8, 0xaf, 0x6a, 0xd7, 0x53, 0x3b, 0x13, 0x4d, 0x54, 0x21, 0xa9, 0xe2,
-	0x23, 0x68, 0xa4, 0x1e, 0x4c, 0xed, 0x21, 0x55, 0x75, 0x82, 0x0e, 0xb7, 0x98, 0xb6, 0x23, 0x43,
-	0x80, 0x31, 0x34, 0xa5, 0x66, 0xc6, 0x51, 0xc3, 0x2d, 0xa8, 0x69, 0x96, 0x7d, 0x9b, 0x01, 0x75,
-	0x7c, 0x02, 0x47, 0x99, 0x92, 0x4d, 0x8d, 0x89, 0x4a, 0x0d, 0xe2, 0xa0, 0x06, 0xf7, 0x22, 0x8d,
-	0x3f, 0xe7, 0x5f, 0x13, 0x9f, 0xc3, 0xc9, 0xd4, 0xd6, 0x37, 0xe3, 0x55, 0x5d, 0x75, 0x6c, 0x0d,
-	0x51, 0x8b, 0x7b, 0x23, 0x45, 0xba, 0xea, 0xaa, 0x9e, 0x6e, 0x50, 0xa2, 0xb9, 0x96, 0x60, 0x44,
-	0xf8, 0x25, 0x74, 0x72, 0x76, 0x96, 0x39, 0xf0, 0x06, 0xc6, 0x98, 0x38, 0xe8, 0x48, 0x54, 0x4d,
-	0xba, 0xe1, 0xb8, 0xaa, 0xa9, 0xf7, 0x6f, 0x11, 0xde, 0x04, 0x27, 0x06, 0xa5, 0x16, 0x75, 0x50,
-	0x1b, 0x9f, 0x02, 0xd6, 0xc9, 0x98, 0x08, 0x9e, 0xfe, 0x98, 0x88, 0x42, 0x38, 0xe8, 0x18, 0x2b,
-	0xf0, 0x6a, 0x8d, 0x6f, 0xba, 0x2c, 0x7c, 0xd1, 0x0d, 0xea, 0xa0, 0x13, 0xee, 0x83, 0xd4, 0x71,
-	0xc8, 0x70, 0x42, 0x4c, 0x97, 0x2f, 0xe6, 0x12, 0x21, 0x3d, 0xe5, 0xf5, 0x72, 0x5c, 0xcb, 0xe6,
-	0x1d, 0xe0, 0xa9, 0xa6, 0x9e, 0x95, 0xfe, 0x8c, 0x17, 0x59, 0x9a, 0xa5, 0x69, 0x5b, 0x5b, 0xa1,
-	0x0e, 0x8f, 0x59, 0xa5, 0xda, 0xc8, 0xb8, 0x21, 0xde, 0xd8, 0x1a, 0x6e, 0xc5, 0x7c, 0xce, 0x0d,
-	0x29, 0x71, 0x5c, 0x8b, 0x92, 0x7c, 0x75, 0x2e, 0x9e, 0x32, 0x9c, 0x93, 0xbc, 0xe0, 0x25, 0xc9,
-	0xac, 0xec, 0xa1, 0x66, 0x99, 0x2e, 0xb5, 0xc6, 0xe8, 0x25, 0xfe, 0x06, 0xce, 0x29, 0xd1, 0xac,
-	0x1b, 0x42, 0x1d, 0x92, 0xef, 0x63, 0xf4, 0x0d, 0xaf, 0x2c, 0x6f, 0x76, 0xe1, 0xdb, 0xd4, 0x41,
-	0xaf, 0x78, 0xa1, 0x28, 0x99, 0x58, 0x37, 0xeb, 0xb5, 0xb3, 0x1c, 0xfe, 0x06, 0xab, 0xf0, 0xf3,
-	0x3b, 0xd5, 0x70, 0xbd, 0x81, 0x45, 0xd7, 0x69, 0x72, 0x2d, 0xaf, 0x4f, 0x3c, 0x4a, 0x54, 0xfd,
-	0xd6, 0x53, 0x07, 0x1c, 0x51, 0x75, 0x9d, 0xef, 0x18, 0x69, 0x26, 0x52, 0x92, 0xd5, 0xa6, 0x8b,
-	0x7f, 0x82, 0x1f, 0xff, 0x0f, 0x0a, 0x51, 0x71, 0x4e, 0x92, 0x35, 0xc9, 0xb7, 0xeb, 0x2c, 0xe7,
-	0x1a, 0x4b, 0xc1, 0x3d, 0xb8, 0x72, 0x88, 0x2b, 0xb4, 0xf5, 0x5b, 0x53, 0x9d, 0x18, 0x9a, 0x37,
-	0x36, 0xfa, 0x54, 0xa5, 0xb7, 0x9e, 0xad, 0xba, 0x23, 0xcf, 0xda, 0xd8, 0x2c, 0xce, 0x94, 0xdb,
-	0xbc, 0x7e, 0x63, 0x43, 0x59, 0x5e, 0x3b, 0x79, 0xb3, 0xaf, 0x67, 0x89, 0xc8, 0x40, 0x81, 0x4f,
-	0x0f, 0x3a, 0x35, 0x4d, 0xc3, 0xe4, 0x1b, 0xbc, 0x0e, 0x55, 0xcd, 0x9a, 0xd8, 0xbc, 0x8c, 0xe9,
-	0x38, 0x1a, 0xa8, 0xc6, 0x98, 0xe8, 0x68, 0x9f, 0xab, 0x39, 0xd7, 0x86, 0x6d, 0x13, 0x1d, 0x95,
-	0x7a, 0xff, 0xde, 0x87, 0xaa, 0x36, 0x0f, 0xdc, 0x70, 0xb4, 0xba, 0xc3, 0x7f, 0x00, 0x78, 0xba,
-	0x18, 0xe0, 0xd3, 0x67, 0xf7, 0x24, 0x31, 0x94, 0x2f, 0xd2, 0x63, 0x41, 0xde, 0x00, 0x95, 0xc2,
-	0xdb, 0x22, 0xb6, 0xe1, 0xec, 0x2b, 0xcf, 0x02, 0xfc, 0x3a, 0x47, 0xb2, 0xeb, 0xd1, 0xb0, 0x83,
-	0xf1, 0x2d, 0x54, 0xe4, 0x19, 0x8f, 0xdb, 0xdb, 0xd7, 0xac, 0xaf, 0x59, 0xf4, 0xa0, 0x9a, 0x9d,
-	0xed, 0xf8, 0x38, 0x77, 0xad, 0xfa, 0x9a, 0xcd, 0x15, 0x94, 0xd3, 0x23, 0x10, 0xe3, 0xad, 0x5b,
-	0xd4, 0xd7, 0xf4, 0xff, 0x08, 0x87, 0xeb, 0xa3, 0x07, 0xa7, 0x77, 0xb7, 0xfc, 0x91, 0x75, 0xd1,
-	0xce, 0xc3, 0xfc, 0x96, 0x5e, 0xc0, 0x84, 0x3f, 0x61, 0x36, 0x5e, 0x26, 0xf8, 0x3c, 0xbb, 0xd5,
-	0x3e, 0x7b, 0xc5, 0x5c, 0x9c, 0xed, 0x12, 0xa5, 0x34, 0x7d, 0xa8, 0x6f, 0xbe, 0x49, 0x70, 0x47,
-	0xbe, 0x25, 0x9e, 0xbd, 0x5e, 0x2e, 0x4e, 0x77, 0x48, 0x04, 0xc7, 0x5d, 0x59, 0x3c, 0x7a, 0x7f,
-	0xfc, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x90, 0x60, 0xa8, 0xce, 0x08, 0x0f, 0x00, 0x00,
+	// 2162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xdd, 0x72, 0xdb, 0xb8,
+	0x15, 0x96, 0xfc, 0xef, 0x23, 0xff, 0xc0, 0xf0, 0x9f, 0xec, 0x64, 0xb3, 0x5a, 0x26, 0x4d, 0xdd,
+	0x24, 0xf5, 0xa6, 0x4e, 0xa6, 0x3b, 0xed, 0xcc, 0xce, 0x94, 0x26, 0x21, 0x91, 0x63, 0x89, 0x64,
+	0x01, 0xca, 0x59, 0xf7, 0x86, 0x43, 0x4b, 0x88, 0xcd, 0xb1, 0x2c, 0x29, 0x24, 0x95, 0x89, 0x7b,
+	0xd7, 0x3e, 0x40, 0xaf, 0xfa, 0x0c, 0xed, 0x63, 0xf4, 0xae, 0xcf, 0xd3, 0x47, 0xe8, 0x00, 0x04,
+	0xf5, 0x43, 0x2b, 0xd3, 0xee, 0x9d, 0xf0, 0x7d, 0x07, 0x1f, 0x0f, 0xce, 0x39, 0x38, 0x00, 0x04,
+	0xa8, 0xd3, 0x8b, 0x82, 0x74, 0x10, 0xdc, 0x8e, 0xae, 0x4f, 0x87, 0xf1, 0x20, 0x1d, 0xe0, 0xc5,
+	0xa8, 0xdb, 0xd3, 0xfe, 0xb5, 0x0c, 0x3b, 0x76, 0x3f, 0x4a, 0xa3, 0xb0, 0x17, 0xfd, 0x99, 0x53,
+	0xfe, 0x69, 0xc4, 0x93, 0x14, 0x3f, 0x85, 0xf5, 0xf0, 0x86, 0xf7, 0x53, 0x6f, 0x10, 0xa7, 0xd5,
+	0x72, 0xad, 0x7c, 0xb2, 0x4c, 0x27, 0x00, 0xd6, 0x60, 0x23, 0x19, 0x8c, 0xe2, 0x0e, 0x6f, 0x78,
+	0xd6, 0xe0, 0x9e, 0x57, 0x17, 0x6a, 0xe5, 0x93, 0x75, 0x3a, 0x83, 0x09, 0x9b, 0x34, 0x8c, 0x6f,
+	0x78, 0xaa, 0x6c, 0x16, 0x33, 0x9b, 0x69, 0x0c, 0x3f, 0x03, 0xc8, 0xe6, 0xc8, 0xcf, 0x2c, 0xc9,
+	0xcf, 0x4c, 0x21, 0xb8, 0x06, 0x95, 0x51, 0xc2, 0x9b, 0x51, 0xff, 0xae, 0x35, 0xe8, 0xf2, 0xea,
+	0x72, 0xad, 0x7c, 0xb2, 0x46, 0xa7, 0x21, 0x7c, 0x02, 0xdb, 0xa3, 0x84, 0x5b, 0xd7, 0xa1, 0x35,
+	0x48, 0xd2, 0x7e, 0x78, 0xcf, 0x93, 0xea, 0x8a, 0xb4, 0x2a, 0xc2, 0x78, 0x0f, 0x96, 0x87, 0x83,
+	0x38, 0x4d, 0xaa, 0xab, 0xb5, 0xc5, 0x93, 0x4d, 0x9a, 0x0d, 0xf0, 0x0b, 0xd8, 0xec, 0x46, 0xc9,
+	0x5d, 0x3d, 0xe6, 0x9c, 0x86, 0x69, 0x34, 0xa8, 0xae, 0xd5, 0xca, 0x27, 0x65, 0x3a, 0x0b, 0xe2,
+	0x97, 0xb0, 0xd5, 0x0d, 0xd3, 0xf0, 0x32, 0xec, 0x45, 0x5d, 0x01, 0xf4, 0xab, 0xeb, 0x72, 0x35,
+	0x05, 0x54, 0xf8, 0xdb, 0x1d, 0xdd, 0x0f, 0x59, 0xe7, 0x96, 0xdf, 0x87, 0x49, 0x15, 0x32, 0x7f,
+	0xa7, 0x20, 0x19, 0xb9, 0xfb, 0xc1, 0x1d, 0xf7, 0x79, 0x92, 0x9a, 0x51, 0x5c, 0xad, 0xa8, 0xc8,
+	0x4d, 0x61, 0xf8, 0x0d, 0xec, 0x8c, 0x86, 0x37, 0x71, 0xd8, 0xe5, 0xe4, 0x4b, 0xca, 0xfb, 0x49,
+	0x34, 0xe8, 0x27, 0xd5, 0x0d, 0xa9, 0xf5, 0x98, 0x10, 0xd6, 0x3c, 0x49, 0xa3, 0xfb, 0x30, 0xe5,
+	0x66, 0x94, 0xdc, 0xb1, 0x61, 0xd8, 0xe1, 0xd5, 0xcd, 0xcc, 0xfa, 0x11, 0x21, 0xe2, 0x15, 0x8e,
+	0xd2, 0x81, 0x9e, 0x24, 0xd1, 0x4d, 0xdf, 0x93, 0xf1, 0xd8, 0xca, 0xe2, 0x55, 0x80, 0xf1, 0x5b,
+	0xd8, 0xed, 0xf4, 0x46, 0x49, 0xca, 0x63, 0xca, 0xc3, 0xee, 0x83, 0x1f, 0xdd, 0xf3, 0xc1, 0x28,
+	0xad, 0x6e, 0xd7, 0xca, 0x27, 0x9b, 0x74, 0x1e, 0x25, 0x6a, 0x26, 0xe6, 0xd7, 0x61, 0x2f, 0xec,
+	0x77, 0x78, 0x15, 0x49, 0xd5, 0x09, 0x80, 0xdf, 0xc3, 0xfe, 0xcd, 0x30, 0xea, 0x47, 0x69, 0xf2,
+	0x90, 0xa4, 0xfc, 0xde, 0xfd, 0xcc, 0xe3, 0x38, 0xea, 0xf2, 0xa4, 0xba, 0x23, 0x43, 0x30, 0x9f,
+	0x14, 0xf1, 0x8a, 0x93, 0x87, 0x7e, 0xa7, 0x15, 0xc5, 0xf1, 0x20, 0x4e, 0xaa, 0x58, 0xca, 0xce,
+	0x60, 0x9a, 0x07, 0xcf, 0x26, 0x05, 0x6c, 0xc4, 0x3c, 0x4c, 0xb9, 0x91, 0xbb, 0x97, 0x55, 0xf3,
+	0x29, 0xe0, 0xee, 0x43, 0x3f, 0xbc, 0x8f, 0x3a, 0xcd, 0xe8, 0x3a, 0x0e, 0xe3, 0x07, 0x2f, 0x4c,
+	0x6f, 0x65, 0x59, 0xaf, 0xd3, 0x39, 0x8c, 0xf6, 0xd7, 0x32, 0x6c, 0x91, 0x2f, 0xbc, 0x33, 0x4a,
+	0xf9, 0x94, 0x44, 0x72, 0x17, 0x0d, 0xc7, 0x91, 0x34, 0x6e, 0x79, 0xe7, 0x4e, 0x4a, 0xac, 0xd1,
+	0x39, 0x0c, 0xae, 0xc2, 0xea, 0xa7, 0x51, 0xc4, 0x93, 0x4e, 0xbe, 0x3b, 0xf2, 0xa1, 0x28, 0x26,
+	0xf5, 0x33, 0x8f, 0xe9, 0xa2, 0x8c, 0x69, 0x01, 0xd5, 0x76, 0x60, 0xbb, 0x1e, 0xf5, 0xa7, 0x77,
+	0xa5, 0xb6, 0x0d, 0x9b, 0x94, 0x7f, 0xe6, 0x71, 0x9a, 0x03, 0x07, 0xb0, 0x47, 0x79, 0x92, 0x86,
+	0x71, 0xaa, 0x8b, 0xcd, 0x99, 0xe4, 0xf8, 0x7b, 0xc0, 0x05, 0x7c, 0xd8, 0x7b, 0x10, 0xdb, 0x4d,
+	0xee, 0x61, 0xb1, 0x29, 0x92, 0x6a, 0xb9, 0xb6, 0x78, 0xb2, 0x4e, 0xa7, 0x10, 0x6d, 0x1f, 0x76,
+	0x59, 0x3a, 0x18, 0x32, 0x1e, 0x7f, 0x8e, 0x3a, 0x7c, 0x2c, 0xb6, 0x0b, 0x3b, 0xb3, 0xf0, 0xb0,
+	0xf7, 0xa0, 0x5d, 0xc2, 0x26, 0x1b, 0x5d, 0x27, 0x29, 0x1f, 0xb2, 0x34, 0x4c, 0x47, 0x09, 0xae,
+	0xc1, 0x92, 0x18, 0xc9, 0x90, 0x6c, 0x9d, 0x6d, 0x9c, 0x46, 0xdd, 0xde, 0xa9, 0xb2, 0xa0, 0x92,
+	0xc1, 0xcf, 0x61, 0x25, 0x91, 0xb6, 0x32, 0x22, 0x5b, 0x67, 0x95, 0xcc, 0x46, 0x42, 0x54, 0x51,
+	0xda, 0x13, 0x38, 0xf2, 0x62, 0x3e, 0x0c, 0x63, 0x2e, 0x72, 0x3a, 0x9b, 0x47, 0xed, 0x08, 0x0e,
+	0xe7, 0x91, 0xc2, 0x9f, 0x4f, 0xb0, 0x6c, 0xdc, 0x8e, 0xfa, 0x77, 0xf8, 0x00, 0x56, 0xae, 0x47,
+	0x1f, 0x3f, 0xf2, 0x58, 0x7a, 0xb2, 0x41, 0xd5, 0x08, 0x3f, 0x87, 0xa5, 0xf4, 0x61, 0xc8, 0xd5,
+	0xb7, 0xb7, 0xe5, 0xb7, 0xe5, 0x8c, 0x53, 0xff, 0x61, 0xc8, 0xa9, 0x24, 0xb5, 0xd7, 0xb0, 0x24,
+	0x46, 0xb8, 0x02, 0xab, 0x6d, 0xe7, 0xc2, 0x71, 0x3f, 0x38, 0xa8, 0x84, 0x01, 0x56, 0x98, 0x6f,
+	0xba, 0x6d, 0x1f, 0x95, 0xd5, 0x6f, 0x42, 0x29, 0x5a, 0xd0, 0xfe, 0x5e, 0x86, 0xd5, 0x16, 0x4f,
+	0x92, 0xf0, 0x46, 0x74, 0xbb, 0xe5, 0x8e, 0x10, 0x93, 0x1f, 0xad, 0x9c, 0xc1, 0x44, 0xde, 0x2a,
+	0xd1, 0x8c, 0xc2, 0x6f, 0x66, 0xd6, 0x5f, 0x39, 0xc3, 0xd3, 0x31, 0xca, 0xc2, 0x60, 0x95, 0xf2,
+	0x40, 0xe0, 0xd7, 0xb0, 0x16, 0xf3, 0x64, 0x38, 0xe8, 0x27, 0x59, 0xef, 0xac, 0x9c, 0x6d, 0x4a,
+	0x7b, 0xaa, 0x40, 0xab, 0x44, 0xc7, 0x06, 0xe7, 0x00, 0x6b, 0x9d, 0x41, 0x3f, 0x15, 0xa9, 0xd6,
+	0xfe, 0xb9, 0x00, 0x6b, 0xb9, 0x11, 0xb6, 0x01, 0x47, 0x53, 0xcd, 0x7d, 0x46, 0xef, 0x50, 0xea,
+	0xd9, 0x8f, 0x68, 0xab, 0x44, 0xe7, 0x4c, 0xc2, 0x7f, 0x80, 0x6d, 0x9e, 0xef, 0x09, 0xa5, 0xb3,
+	0x24, 0x75, 0xf6, 0xa4, 0x0e, 0x99, 0xe5, 0xac, 0x12, 0x2d, 0x9a, 0x63, 0x03, 0xd0, 0xc7, 0x71,
+	0x45, 0x2b, 0x89, 0x65, 0x29, 0xb1, 0x2f, 0x25, 0xea, 0x05, 0xd2, 0x2a, 0xd1, 0x47, 0x13, 0xf0,
+	0x8f, 0xb0, 0x15, 0xab, 0x3d, 0xa0, 0x24, 0x56, 0xa4, 0xc4, 0xae, 0x8a, 0xce, 0x34, 0x65, 0x95,
+	0x68, 0xc1, 0x78, 0x26, 0x52, 0x3e, 0xe0, 0xc7, 0xab, 0x17, 0xbb, 0xc4, 0x0a, 0x93, 0xbc, 0xe1,
+	0x64, 0x3b, 0x7c, 0x0a, 0x51, 0x3c, 0x4b, 0xc3, 0x7e, 0xf7, 0xfa, 0x41, 0xa6, 0x32, 0xe3, 0x15,
+	0xa2, 0xfd, 0xa5, 0x0c, 0xab, 0xaa, 0x34, 0x45, 0x31, 0xaa, 0xe3, 0x2f, 0x6b, 0x36, 0x6a, 0x84,
+	0x31, 0x2c, 0xc9, 0x23, 0x6f, 0x41, 0x1e, 0x79, 0xf2, 0xb7, 0x68, 0xb8, 0xad, 0x50, 0xcc, 0x32,
+	0xc3, 0x34, 0x34, 0xa3, 0x98, 0x77, 0xd2, 0x41, 0xfc, 0xa0, 0xce, 0xcd, 0x79, 0x94, 0xe8, 0x31,
+	0x97, 0x3c, 0x16, 0xc7, 0x80, 0xcc, 0xc4, 0x3a, 0xcd, 0x87, 0xda, 0x0f, 0xb0, 0x5d, 0xc8, 0x07,
+	0x7e, 0x01, 0x2b, 0xd9, 0xd9, 0xab, 0x4a, 0x34, 0xdb, 0xa1, 0xf9, 0x1e, 0x52, 0x9c, 0xf6, 0xef,
+	0x05, 0x40, 0xc5, 0x34, 0xe0, 0x33, 0xd8, 0xf4, 0x25, 0xad, 0xac, 0xe7, 0x2a, 0xcc, 0x9a, 0x88,
+	0x83, 0x35, 0x03, 0x72, 0x0f, 0xb3, 0x2e, 0x38, 0x0b, 0x8a, 0x35, 0x37, 0x07, 0x37, 0x7a, 0xdc,
+	0xb9, 0x8d, 0x3e, 0xf3, 0x47, 0x6b, 0x9e, 0x43, 0xe1, 0x26, 0x7c, 0xa7, 0xb0, 0x2e, 0x93, 0x17,
+	0x85, 0x79, 0x31, 0xcb, 0xa2, 0xf1, 0xbf, 0x0d, 0xc5, 0x91, 0xd5, 0xce, 0x4e, 0x54, 0xdb, 0x94,
+	0xa5, 0xb8, 0x4e, 0x27, 0x00, 0xfe, 0x3d, 0x54, 0xc7, 0x07, 0xad, 0x42, 0xeb, 0x61, 0xd4, 0x1b,
+	0xc5, 0xf2, 0x96, 0x21, 0xba, 0xe7, 0x57, 0x79, 0xed, 0x6f, 0x65, 0xd8, 0x9a, 0x2d, 0x46, 0x91,
+	0x81, 0xec, 0x6e, 0x33, 0x3f, 0x03, 0x19, 0x27, 0x02, 0x97, 0xf9, 0x5b, 0x08, 0xdc, 0x0c, 0xf8,
+	0xf3, 0x03, 0xa7, 0xbd, 0x04, 0xd4, 0xe0, 0xa9, 0x31, 0xe8, 0x7f, 0x8c, 0x6e, 0xf2, 0x43, 0x0d,
+	0xc3, 0x92, 0xb8, 0x1c, 0xa9, 0xe2, 0x94, 0xbf, 0xb5, 0x97, 0xb0, 0x35, 0x65, 0x27, 0x8e, 0x8d,
+	0x3d, 0x58, 0xfe, 0x1c, 0xf6, 0x46, 0xb9, 0x59, 0x36, 0xd0, 0xbe, 0x87, 0x8a, 0xc3, 0xbf, 0xa4,
+	0x7a, 0x27, 0x95, 0xd7, 0x90, 0x1a, 0x54, 0xfa, 0x93, 0xa1, 0x32, 0x9d, 0x86, 0x5e, 0x7d, 0x00,
+	0xac, 0xd6, 0x6a, 0x8a, 0x7b, 0x49, 0x3f, 0xbb, 0x32, 0x1d, 0xc2, 0xae, 0xea, 0xb4, 0x81, 0x49,
+	0x98, 0x6f, 0x3b, 0xba, 0x6f, 0xbb, 0x79, 0xd7, 0x75, 0xdb, 0xd4, 0x20, 0xa8, 0x8c, 0x11, 0x6c,
+	0xd8, 0x8e, 0x4f, 0x68, 0x8b, 0x98, 0xb6, 0xee, 0x13, 0xb4, 0x20, 0x58, 0x5f, 0xa7, 0x0d, 0xe2,
+	0xa3, 0xc5, 0x57, 0x2e, 0x2c, 0x31, 0x71, 0xbe, 0x20, 0xd8, 0xc8, 0xa5, 0x98, 0x4f, 0x3c, 0x54,
+	0xc2, 0x5b, 0x00, 0xb6, 0x63, 0xfb, 0xb6, 0xde, 0xb4, 0xff, 0x24, 0x74, 0x2a, 0xb0, 0x4a, 0x7e,
+	0x22, 0x46, 0x5b, 0x4a, 0x6c, 0xc0, 0x5a, 0xdd, 0x76, 0x32, 0x6a, 0x51, 0x08, 0x52, 0x72, 0x49,
+	0xa8, 0x8f, 0x96, 0x5e, 0xfd, 0xa3, 0x02, 0xab, 0xaa, 0x2d, 0xe3, 0x5d, 0xd8, 0x1e, 0x8b, 0xb6,
+	0xcf, 0x95, 0x6e, 0x0d, 0x9e, 0x32, 0xfd, 0xd2, 0x76, 0x1a, 0x41, 0xe6, 0x62, 0x60, 0x34, 0xdb,
+	0xcc, 0x27, 0x34, 0x30, 0x5c, 0xa7, 0x6e, 0x37, 0x50, 0x19, 0x6f, 0xc2, 0x3a, 0xf3, 0x75, 0xea,
+	0x07, 0x56, 0xfb, 0x1c, 0x2d, 0x08, 0xd7, 0xb2, 0xa1, 0xde, 0x20, 0x8e, 0xcf, 0xd0, 0x22, 0xde,
+	0x03, 0x64, 0x58, 0xc4, 0xb8, 0x08, 0x4c, 0x9b, 0x5d, 0x04, 0xcc, 0xd3, 0x0d, 0x82, 0x96, 0xf0,
+	0x31, 0x1c, 0x34, 0x88, 0x43, 0xa8, 0xee, 0x93, 0x20, 0x5b, 0x5f, 0x2e, 0xb9, 0x2c, 0x22, 0x25,
+	0x16, 0x33, 0xc6, 0xb3, 0x4f, 0xa2, 0x15, 0xfc, 0x04, 0x0e, 0x99, 0xd5, 0xf6, 0x4d, 0xe1, 0x63,
+	0x81, 0x5c, 0xc5, 0x55, 0xd8, 0x3b, 0xd7, 0x8d, 0x8b, 0xb6, 0x97, 0x53, 0x2d, 0x5d, 0x32, 0x6b,
+	0x78, 0x07, 0x36, 0x33, 0x0f, 0xda, 0x5e, 0x83, 0xea, 0x26, 0x41, 0xeb, 0x33, 0x4a, 0xb3, 0x2b,
+	0x43, 0x80, 0x31, 0x6c, 0x29, 0xcb, 0x5c, 0xa3, 0x82, 0xb7, 0xa1, 0x62, 0xb8, 0xde, 0x55, 0x0e,
+	0x6c, 0xe0, 0x7d, 0xd8, 0xc9, 0x8d, 0x3c, 0x6a, 0xb7, 0x74, 0x6a, 0x13, 0x86, 0x36, 0x85, 0x17,
+	0xd9, 0xfa, 0x0b, 0xfe, 0x6d, 0xe1, 0x23, 0xd8, 0x6f, 0x7b, 0xe6, 0xf4, 0x7a, 0x75, 0x5f, 0x6f,
+	0xba, 0x0d, 0xb4, 0x2d, 0xbc, 0x51, 0x94, 0xa9, 0xfb, 0x7a, 0x60, 0xda, 0x94, 0x18, 0xbe, 0x2b,
+	0x15, 0x11, 0x7e, 0x0a, 0xd5, 0xc2, 0x3c, 0xd7, 0xa9, 0x07, 0x75, 0xbb, 0x49, 0x18, 0xda, 0x91,
+	0x59, 0x53, 0x6e, 0x30, 0x5f, 0x77, 0xcc, 0xf3, 0x2b, 0x84, 0xa7, 0xc1, 0x96, 0x4d, 0xa9, 0x4b,
+	0x19, 0xda, 0xc5, 0x07, 0x80, 0x4d, 0xd2, 0x24, 0x52, 0xe7, 0xbc, 0x49, 0x64, 0x22, 0x18, 0xda,
+	0xc3, 0x1a, 0x3c, 0x1b, 0xe3, 0xd3, 0x2e, 0x4b, 0x5f, 0x4c, 0x9b, 0x32, 0xb4, 0x2f, 0x7c, 0x50,
+	0x36, 0x8c, 0x34, 0x5a, 0xc4, 0xf1, 0xc5, 0xc7, 0x7c, 0x22, 0xd9, 0x03, 0x91, 0x2f, 0xe6, 0xbb,
+	0x9e, 0xa8, 0x80, 0x40, 0x77, 0xcc, 0x3c, 0xf5, 0x87, 0x22, 0xc9, 0x6a, 0x5a, 0x16, 0xb6, 0xf1,
+	0x2c, 0x54, 0x15, 0x6b, 0xd6, 0xa9, 0x61, 0xd9, 0x97, 0x24, 0x68, 0xba, 0x8d, 0x99, 0x35, 0x1f,
+	0x89, 0x89, 0x94, 0x30, 0xdf, 0xa5, 0xa4, 0x98, 0x9d, 0xe3, 0x49, 0x84, 0x0b, 0xcc, 0x13, 0x91,
+	0x92, 0x7c, 0x96, 0xd7, 0x30, 0x5c, 0xc7, 0xa7, 0x6e, 0x13, 0x3d, 0xc5, 0xdf, 0xc0, 0x11, 0x25,
+	0x86, 0x7b, 0x49, 0x28, 0x23, 0xc5, 0x3a, 0x46, 0xdf, 0x88, 0xcc, 0x8a, 0x62, 0x97, 0xbe, 0xb5,
+	0x19, 0x7a, 0x26, 0x12, 0x45, 0x49, 0xcb, 0xbd, 0x1c, 0x7f, 0x3b, 0x8f, 0xe1, 0xb7, 0x58, 0x87,
+	0x1f, 0x3f, 0xe8, 0xb6, 0x1f, 0xd4, 0x5d, 0x3a, 0x0e, 0x93, 0xef, 0x06, 0xe7, 0x24, 0xa0, 0x44,
+	0x37, 0xaf, 0x02, 0xbd, 0x2e, 0x10, 0xdd, 0x34, 0xc5, 0x8e, 0x51, 0xd3, 0x64, 0x48, 0xf2, 0xdc,
+	0xd4, 0xf0, 0x0f, 0xf0, 0xee, 0xff, 0x90, 0x90, 0x19, 0x17, 0x22, 0x79, 0x91, 0x7c, 0x37, 0x8e,
+	0x72, 0xa1, 0xb0, 0x34, 0x7c, 0x06, 0xa7, 0x8c, 0xf8, 0xd2, 0xda, 0xbc, 0x72, 0xf4, 0x96, 0x6d,
+	0x04, 0x4d, 0xfb, 0x9c, 0xea, 0xf4, 0x2a, 0xf0, 0x74, 0xdf, 0x0a, 0xdc, 0xa9, 0xcd, 0xc2, 0xda,
+	0x62, 0xce, 0x73, 0x19, 0x44, 0x47, 0xf7, 0x98, 0xe5, 0x8e, 0xe3, 0x28, 0xd2, 0x8d, 0x5e, 0x08,
+	0xe6, 0x52, 0x6f, 0xda, 0xd3, 0x05, 0x27, 0x99, 0x5f, 0xc8, 0x02, 0x6a, 0xb7, 0xbc, 0xdc, 0x9e,
+	0x19, 0x16, 0x69, 0xe9, 0xe8, 0xe5, 0x18, 0x57, 0xd6, 0x0a, 0xff, 0xa5, 0xa8, 0x42, 0xda, 0x76,
+	0x02, 0xd6, 0x72, 0x2f, 0x48, 0xe0, 0x13, 0xe6, 0x33, 0x74, 0x32, 0xe9, 0x06, 0xe4, 0x27, 0x9f,
+	0x38, 0xcc, 0x76, 0x1d, 0x86, 0x7e, 0x25, 0x4c, 0x33, 0x34, 0x73, 0x5c, 0x14, 0xc1, 0x2b, 0xa1,
+	0x9b, 0x57, 0xf1, 0x94, 0xf1, 0x6b, 0xfc, 0x2d, 0x3c, 0xc9, 0x8d, 0x9d, 0x8b, 0xa0, 0xe5, 0x9a,
+	0x24, 0xdb, 0x0d, 0x57, 0xcc, 0x27, 0x2d, 0x86, 0xde, 0x88, 0x89, 0x99, 0x81, 0xf2, 0xc8, 0x73,
+	0xa9, 0xcf, 0xd0, 0xaf, 0xe5, 0x1e, 0x96, 0xb8, 0xe5, 0x0a, 0x67, 0x4e, 0x27, 0x4a, 0x85, 0xe6,
+	0x66, 0x11, 0xbd, 0xe9, 0x5b, 0xe8, 0x7b, 0x51, 0x87, 0x7f, 0x6c, 0xdb, 0x84, 0x19, 0x8f, 0xea,
+	0xf0, 0xad, 0xe0, 0x2e, 0x09, 0xb5, 0xeb, 0x57, 0xe3, 0x80, 0xa8, 0x86, 0x82, 0x7e, 0x23, 0xb8,
+	0x4c, 0x78, 0x92, 0x61, 0xcf, 0x6d, 0xba, 0x8d, 0x2b, 0x74, 0x26, 0x0a, 0xbf, 0x65, 0x37, 0x64,
+	0xe3, 0xcb, 0x27, 0x66, 0xd9, 0x63, 0xe8, 0x9d, 0x70, 0x3d, 0x27, 0xf5, 0xb6, 0x6f, 0xa9, 0x6d,
+	0xfe, 0xfe, 0x95, 0x07, 0x2b, 0xea, 0xf5, 0x21, 0x9a, 0xd3, 0xb8, 0xf7, 0xcb, 0x8a, 0x2d, 0x89,
+	0x6e, 0x4f, 0xdb, 0x8e, 0x63, 0x3b, 0xa2, 0x21, 0x6f, 0xc0, 0x9a, 0xe1, 0xb6, 0x3c, 0xb1, 0xed,
+	0xb2, 0xe3, 0xa3, 0xae, 0xdb, 0x4d, 0x62, 0xa2, 0x45, 0x61, 0xc6, 0x2e, 0x6c, 0xcf, 0x23, 0x26,
+	0x5a, 0x3a, 0xfb, 0xcf, 0x22, 0xac, 0x19, 0xbd, 0xc8, 0x1f, 0x58, 0xa3, 0x6b, 0xfc, 0x5b, 0x80,
+	0xc9, 0xfd, 0x10, 0x1f, 0x3c, 0xba, 0x2e, 0xcb, 0x43, 0xf4, 0x38, 0x3b, 0xc6, 0xd5, 0x43, 0x40,
+	0x2b, 0xbd, 0x2d, 0x63, 0x0f, 0x0e, 0xbf, 0xf2, 0x20, 0xc5, 0xcf, 0x0b, 0x22, 0xf3, 0x9e, 0xab,
+	0x73, 0x14, 0xdf, 0xc2, 0xaa, 0xba, 0xcf, 0xe1, 0xdd, 0xd9, 0xdb, 0xf6, 0xd7, 0x66, 0x9c, 0xc1,
+	0x5a, 0x7e, 0x8f, 0xc3, 0x7b, 0x85, 0xdb, 0xf5, 0xd7, 0xe6, 0x9c, 0xc2, 0x4a, 0x76, 0x65, 0xc1,
+	0x78, 0xe6, 0x32, 0xfd, 0x35, 0xfb, 0xdf, 0xc1, 0xfa, 0xf8, 0xaa, 0x80, 0xb3, 0x2b, 0x7c, 0xf1,
+	0x8a, 0x71, 0xbc, 0x5b, 0x84, 0xc5, 0x63, 0xad, 0x84, 0x89, 0x78, 0xc9, 0x4e, 0x3d, 0x50, 0xf1,
+	0x51, 0xfe, 0xb8, 0x79, 0xf4, 0x98, 0x3d, 0x3e, 0x9c, 0x47, 0x65, 0x32, 0xe7, 0xb0, 0x31, 0xfd,
+	0x34, 0xc5, 0x55, 0xf5, 0xa4, 0x7c, 0xf4, 0x88, 0x3d, 0x3e, 0x98, 0xc3, 0x48, 0x8d, 0xeb, 0x15,
+	0xf9, 0x67, 0xd8, 0xbb, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x06, 0xfc, 0x8f, 0x2f, 0x20, 0x13,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -736,11 +736,12 @@ func (m *StopAgentReply) XXX_DiscardUnknown() {
 var xxx_messageInfo_StopAgentReply proto.InternalMessageInfo
 
 type CheckSegmentDiskSpaceRequest struct {
-	DiskFreeRatio        float64  `protobuf:"fixed64,1,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
-	Dirs                 []string `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	DiskFreeRatio        float64                                     `protobuf:"fixed64,1,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
+	Dirs                 []string                                    `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	Requirements         []*CheckSegmentDiskSpaceRequest_Requirement `protobuf:"bytes,3,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
 }
 
 func (m *CheckSegmentDiskSpaceRequest) Reset()         { *m = CheckSegmentDiskSpaceRequest{} }
@@ -782,6 +783,90 @@ func (m *CheckSegmentDiskSpaceRequest) GetDirs() []string {
 	return nil
 }
 
+func (m *CheckSegmentDiskSpaceRequest) GetRequirements() []*CheckSegmentDiskSpaceRequest_Requirement {
+	if m != nil {
+		return m.Requirements
+	}
+	return nil
+}
+
+// Requirement is space needed on the filesystem containing path. The
+// space is the size of the sizeOf directory scaled by ratio plus size
+// kilobytes. Likewise, the inodes are the file count of the sizeOf
+// directory scaled by ratio plus files.
+type CheckSegmentDiskSpaceRequest_Requirement struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	SizeOf               string   `protobuf:"bytes,2,opt,name=sizeOf,proto3" json:"sizeOf,omitempty"`
+	Ratio                float64  `protobuf:"fixed64,3,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Size                 uint64   `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Files                uint64   `protobuf:"varint,5,opt,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) Reset() {
+	*m = CheckSegmentDiskSpaceRequest_Requirement{}
+}
+func (m *CheckSegmentDiskSpaceRequest_Requirement) String() string { return proto.CompactTextString(m) }
+func (*CheckSegmentDiskSpaceRequest_Requirement) ProtoMessage()    {}
+func (*CheckSegmentDiskSpaceRequest_Requirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{17, 0}
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement.Unmarshal(m, b)
+}
+func (m *CheckSegmentDiskSpaceRequest_Requirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement.Marshal(b, m, deterministic)
+}
+func (m *CheckSegmentDiskSpaceRequest_Requirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement.Merge(m, src)
+}
+func (m *CheckSegmentDiskSpaceRequest_Requirement) XXX_Size() int {
+	return xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement.Size(m)
+}
+func (m *CheckSegmentDiskSpaceRequest_Requirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckSegmentDiskSpaceRequest_Requirement proto.InternalMessageInfo
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) GetSizeOf() string {
+	if m != nil {
+		return m.SizeOf
+	}
+	return ""
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) GetRatio() float64 {
+	if m != nil {
+		return m.Ratio
+	}
+	return 0
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *CheckSegmentDiskSpaceRequest_Requirement) GetFiles() uint64 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
 type CheckDiskSpaceReply struct {
 	Usage                []*CheckDiskSpaceReply_DiskUsage `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
@@ -826,6 +911,8 @@ type CheckDiskSpaceReply_DiskUsage struct {
 	Host                 string   `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
 	Available            uint64   `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
 	Required             uint64   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	AvailableInodes      uint64   `protobuf:"varint,5,opt,name=availableInodes,proto3" json:"availableInodes,omitempty"`
+	RequiredInodes       uint64   `protobuf:"varint,6,opt,name=requiredInodes,proto3" json:"requiredInodes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -884,6 +971,20 @@ func (m *CheckDiskSpaceReply_DiskUsage) GetRequired() uint64 {
 	return 0
 }
 
+func (m *CheckDiskSpaceReply_DiskUsage) GetAvailableInodes() uint64 {
+	if m != nil {
+		return m.AvailableInodes
+	}
+	return 0
+}
+
+func (m *CheckDiskSpaceReply_DiskUsage) GetRequiredInodes() uint64 {
+	if m != nil {
+		return m.RequiredInodes
+	}
+	return 0
+}
+
 type RsyncRequest struct {
 	Options              []*RsyncRequest_RsyncOptions `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
@@ -1095,10 +1196,16 @@ func (m *RestorePgControlReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RestorePgControlReply proto.InternalMessageInfo
 
+// UpdateFileConfOptions sets the setting name in the configuration file at
+// path from oldValue to newValue. An empty oldValue matches any value. When
+// connInfoKey is set the setting is a connection string such as
+// primary_conninfo, and only that key is updated.
 type UpdateFileConfOptions struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Pattern              string   `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	Replacement          string   `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OldValue             string   `protobuf:"bytes,3,opt,name=oldValue,proto3" json:"oldValue,omitempty"`
+	NewValue             string   `protobuf:"bytes,4,opt,name=newValue,proto3" json:"newValue,omitempty"`
+	ConnInfoKey          string   `protobuf:"bytes,5,opt,name=connInfoKey,proto3" json:"connInfoKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1136,16 +1243,30 @@ func (m *UpdateFileConfOptions) GetPath() string {
 	return ""
 }
 
-func (m *UpdateFileConfOptions) GetPattern() string {
+func (m *UpdateFileConfOptions) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateFileConfOptions) GetOldValue() string {
+	if m != nil {
+		return m.OldValue
+	}
+	return ""
+}
+
+func (m *UpdateFileConfOptions) GetNewValue() string {
 	if m != nil {
-		return m.Pattern
+		return m.NewValue
 	}
 	return ""
 }
 
-func (m *UpdateFileConfOptions) GetReplacement() string {
+func (m *UpdateFileConfOptions) GetConnInfoKey() string {
 	if m != nil {
-		return m.Replacement
+		return m.ConnInfoKey
 	}
 	return ""
 }
@@ -1339,6 +1460,7 @@ var xxx_messageInfo_RenameTablespacesReply proto.InternalMessageInfo
 
 type CreateRecoveryConfRequest struct {
 	Connections          []*CreateRecoveryConfRequest_Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	TargetVersion        string                                  `protobuf:"bytes,2,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
 	XXX_unrecognized     []byte                                  `json:"-"`
 	XXX_sizecache        int32                                   `json:"-"`
@@ -1376,6 +1498,13 @@ func (m *CreateRecoveryConfRequest) GetConnections() []*CreateRecoveryConfReques
 	return nil
 }
 
+func (m *CreateRecoveryConfRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
 type CreateRecoveryConfRequest_Connection struct {
 	MirrorDataDir        string   `protobuf:"bytes,2,opt,name=mirrorDataDir,proto3" json:"mirrorDataDir,omitempty"`
 	User                 string   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -1595,270 +1724,1446 @@ func (m *AddReplicationEntriesReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AddReplicationEntriesReply proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*TablespaceInfo)(nil), "idl.TablespaceInfo")
-	proto.RegisterType((*UpgradePrimariesRequest)(nil), "idl.UpgradePrimariesRequest")
-	proto.RegisterType((*DataDirPair)(nil), "idl.DataDirPair")
-	proto.RegisterMapType((map[int32]*TablespaceInfo)(nil), "idl.DataDirPair.TablespacesEntry")
-	proto.RegisterType((*UpgradePrimariesReply)(nil), "idl.UpgradePrimariesReply")
-	proto.RegisterType((*DeleteDataDirectoriesRequest)(nil), "idl.DeleteDataDirectoriesRequest")
-	proto.RegisterType((*DeleteDataDirectoriesReply)(nil), "idl.DeleteDataDirectoriesReply")
-	proto.RegisterType((*DeleteStateDirectoryRequest)(nil), "idl.DeleteStateDirectoryRequest")
-	proto.RegisterType((*DeleteStateDirectoryReply)(nil), "idl.DeleteStateDirectoryReply")
-	proto.RegisterType((*DeleteTablespaceRequest)(nil), "idl.DeleteTablespaceRequest")
-	proto.RegisterType((*DeleteTablespaceReply)(nil), "idl.DeleteTablespaceReply")
-	proto.RegisterType((*ArchiveLogDirectoryRequest)(nil), "idl.ArchiveLogDirectoryRequest")
-	proto.RegisterType((*ArchiveLogDirectoryReply)(nil), "idl.ArchiveLogDirectoryReply")
-	proto.RegisterType((*RenameDirectories)(nil), "idl.RenameDirectories")
-	proto.RegisterType((*RenameDirectoriesRequest)(nil), "idl.RenameDirectoriesRequest")
-	proto.RegisterType((*RenameDirectoriesReply)(nil), "idl.RenameDirectoriesReply")
-	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
-	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
-	proto.RegisterType((*CheckSegmentDiskSpaceRequest)(nil), "idl.CheckSegmentDiskSpaceRequest")
-	proto.RegisterType((*CheckDiskSpaceReply)(nil), "idl.CheckDiskSpaceReply")
-	proto.RegisterType((*CheckDiskSpaceReply_DiskUsage)(nil), "idl.CheckDiskSpaceReply.DiskUsage")
-	proto.RegisterType((*RsyncRequest)(nil), "idl.RsyncRequest")
-	proto.RegisterType((*RsyncRequest_RsyncOptions)(nil), "idl.RsyncRequest.RsyncOptions")
-	proto.RegisterType((*RsyncReply)(nil), "idl.RsyncReply")
-	proto.RegisterType((*RestorePgControlRequest)(nil), "idl.RestorePgControlRequest")
-	proto.RegisterType((*RestorePgControlReply)(nil), "idl.RestorePgControlReply")
-	proto.RegisterType((*UpdateFileConfOptions)(nil), "idl.UpdateFileConfOptions")
-	proto.RegisterType((*UpdateConfigurationRequest)(nil), "idl.UpdateConfigurationRequest")
-	proto.RegisterType((*UpdateConfigurationReply)(nil), "idl.UpdateConfigurationReply")
-	proto.RegisterType((*RenameTablespacesRequest)(nil), "idl.RenameTablespacesRequest")
-	proto.RegisterType((*RenameTablespacesRequest_RenamePair)(nil), "idl.RenameTablespacesRequest.RenamePair")
-	proto.RegisterType((*RenameTablespacesReply)(nil), "idl.RenameTablespacesReply")
-	proto.RegisterType((*CreateRecoveryConfRequest)(nil), "idl.CreateRecoveryConfRequest")
-	proto.RegisterType((*CreateRecoveryConfRequest_Connection)(nil), "idl.CreateRecoveryConfRequest.Connection")
-	proto.RegisterType((*CreateRecoveryConfReply)(nil), "idl.CreateRecoveryConfReply")
-	proto.RegisterType((*AddReplicationEntriesRequest)(nil), "idl.AddReplicationEntriesRequest")
-	proto.RegisterType((*AddReplicationEntriesRequest_Entry)(nil), "idl.AddReplicationEntriesRequest.Entry")
-	proto.RegisterType((*AddReplicationEntriesReply)(nil), "idl.AddReplicationEntriesReply")
+type CheckExtensionsRequest struct {
+	Gphome               string   `protobuf:"bytes,1,opt,name=gphome,proto3" json:"gphome,omitempty"`
+	Extensions           []string `protobuf:"bytes,2,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("hub_to_agent.proto", fileDescriptor_9e73bb06acc917d8) }
+func (m *CheckExtensionsRequest) Reset()         { *m = CheckExtensionsRequest{} }
+func (m *CheckExtensionsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckExtensionsRequest) ProtoMessage()    {}
+func (*CheckExtensionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{32}
+}
 
-var fileDescriptor_9e73bb06acc917d8 = []byte{
-	// 1401 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0xcb, 0x6e, 0xdb, 0x46,
-	0x17, 0xb6, 0x64, 0x29, 0x8e, 0x8f, 0x1c, 0xc7, 0x19, 0x27, 0x31, 0xcd, 0x28, 0xfe, 0x1d, 0x22,
-	0xc0, 0xef, 0x14, 0xa8, 0x16, 0x6e, 0x0a, 0xa4, 0x41, 0x37, 0xb6, 0x54, 0xa3, 0x69, 0x73, 0x71,
-	0xa9, 0xa4, 0x69, 0x0b, 0x14, 0x01, 0x2d, 0x1e, 0xcb, 0x84, 0x69, 0x52, 0x19, 0x8e, 0xdc, 0xea,
-	0x15, 0xfa, 0x22, 0xdd, 0x75, 0x55, 0x74, 0xd1, 0xb7, 0xc9, 0x43, 0x74, 0xdd, 0xe2, 0xcc, 0x45,
-	0x1c, 0x52, 0xa4, 0x91, 0x1d, 0xcf, 0x75, 0xe6, 0x7c, 0x73, 0x6e, 0x04, 0x76, 0x36, 0x3d, 0x79,
-	0x27, 0xd2, 0x77, 0xc1, 0x18, 0x13, 0xd1, 0x9b, 0xf0, 0x54, 0xa4, 0x6c, 0x39, 0x0a, 0x63, 0xef,
-	0x04, 0xd6, 0x5f, 0x07, 0x27, 0x31, 0x66, 0x93, 0x60, 0x84, 0xcf, 0x92, 0xd3, 0x94, 0x31, 0x68,
-	0xbd, 0x0c, 0x2e, 0xd0, 0x59, 0xde, 0x6d, 0xec, 0xad, 0xfa, 0xf2, 0x9b, 0xb9, 0x70, 0xfd, 0x79,
-	0x3a, 0x0a, 0x44, 0x94, 0x26, 0x4e, 0x4b, 0xf2, 0xe7, 0x34, 0xdb, 0x85, 0xce, 0x9b, 0x0c, 0xf9,
-	0x00, 0x4f, 0xa3, 0x04, 0x43, 0xa7, 0xbd, 0xdb, 0xd8, 0xbb, 0xee, 0xdb, 0x2c, 0xef, 0xf7, 0x26,
-	0x6c, 0xbd, 0x99, 0x8c, 0x79, 0x10, 0xe2, 0x31, 0x8f, 0x2e, 0x02, 0x1e, 0x61, 0xe6, 0xe3, 0xfb,
-	0x29, 0x66, 0x82, 0x79, 0xb0, 0x36, 0x4c, 0xa7, 0x7c, 0x84, 0x87, 0x51, 0x32, 0x88, 0xb8, 0xd3,
-	0x90, 0xde, 0x0b, 0x3c, 0xd2, 0x79, 0x1d, 0xf0, 0x31, 0x0a, 0xad, 0xd3, 0x54, 0x3a, 0x36, 0x8f,
-	0x3d, 0x84, 0x1b, 0x8a, 0xfe, 0x1e, 0x79, 0x46, 0xd7, 0x54, 0xd7, 0x2f, 0x32, 0xd9, 0x63, 0x58,
-	0x1b, 0x04, 0x22, 0x18, 0x44, 0xfc, 0x38, 0x88, 0x78, 0xe6, 0xb4, 0x76, 0x97, 0xf7, 0x3a, 0xfb,
-	0x1b, 0xbd, 0x28, 0x8c, 0x7b, 0x96, 0xc0, 0x2f, 0x68, 0xb1, 0x2e, 0xac, 0xf6, 0xcf, 0x70, 0x74,
-	0xfe, 0x2a, 0x89, 0x67, 0x3a, 0xbe, 0x9c, 0xa1, 0xe3, 0x7f, 0x1e, 0x25, 0xe7, 0x2f, 0xd2, 0x10,
-	0x9d, 0x6b, 0xf3, 0xf8, 0x0d, 0x8b, 0xed, 0xc1, 0xcd, 0x17, 0x41, 0x26, 0x90, 0x1f, 0x06, 0xa3,
-	0xf3, 0xe9, 0x84, 0x42, 0x58, 0x91, 0xb7, 0x2b, 0xb3, 0xbd, 0x0f, 0x4d, 0xe8, 0x58, 0x47, 0x53,
-	0x54, 0x0a, 0x09, 0xcd, 0xd4, 0xf0, 0x14, 0x99, 0x79, 0xec, 0x46, 0xab, 0x69, 0xc7, 0x6e, 0xb4,
-	0x76, 0x00, 0x94, 0xd9, 0x71, 0xca, 0x85, 0x84, 0xa7, 0xed, 0x5b, 0x1c, 0x92, 0x2b, 0x03, 0x29,
-	0x6f, 0x29, 0x79, 0xce, 0x61, 0x0e, 0xac, 0xf4, 0xd3, 0x44, 0x60, 0x22, 0x24, 0x06, 0x6d, 0xdf,
-	0x90, 0x94, 0x31, 0x83, 0xc3, 0x67, 0x03, 0x19, 0x7a, 0xdb, 0x97, 0xdf, 0xac, 0x0f, 0x9d, 0x3c,
-	0xaf, 0x32, 0x67, 0x45, 0x02, 0xfd, 0xa0, 0x0c, 0x74, 0xcf, 0xd2, 0xf9, 0x2a, 0x11, 0x7c, 0xe6,
-	0xdb, 0x56, 0xee, 0x10, 0x36, 0xca, 0x0a, 0x6c, 0x03, 0x96, 0xcf, 0x71, 0x26, 0x81, 0x68, 0xfb,
-	0xf4, 0xc9, 0x1e, 0x41, 0xfb, 0x32, 0x88, 0xa7, 0x28, 0xc3, 0xee, 0xec, 0x6f, 0xca, 0x43, 0x8a,
-	0x49, 0xed, 0x2b, 0x8d, 0xa7, 0xcd, 0x27, 0x0d, 0x6f, 0x0b, 0xee, 0x2c, 0x26, 0xe3, 0x24, 0x9e,
-	0x79, 0x4f, 0xa1, 0x3b, 0xc0, 0x18, 0x85, 0xc1, 0x15, 0x47, 0x22, 0xb5, 0x53, 0xd5, 0x85, 0xeb,
-	0x61, 0x20, 0x82, 0x90, 0x12, 0xa7, 0xb1, 0xbb, 0x4c, 0x45, 0x60, 0x68, 0xaf, 0x0b, 0x6e, 0x8d,
-	0x2d, 0x79, 0xbe, 0x0f, 0xf7, 0x94, 0x74, 0x28, 0x02, 0x81, 0x46, 0x3c, 0xd3, 0x8e, 0xbd, 0x7b,
-	0xb0, 0x5d, 0x2d, 0x26, 0xdb, 0x4f, 0x61, 0x4b, 0x09, 0xf3, 0x88, 0xcc, 0x85, 0x18, 0xb4, 0xac,
-	0xcb, 0xc8, 0x6f, 0x8a, 0x6e, 0x51, 0x9d, 0xfc, 0x3c, 0x06, 0xf7, 0x80, 0x8f, 0xce, 0xa2, 0x4b,
-	0x7c, 0x9e, 0x8e, 0xcb, 0x57, 0x60, 0x77, 0xe1, 0xda, 0x4b, 0xfc, 0x25, 0xcf, 0x30, 0x4d, 0x79,
-	0x2e, 0x38, 0x95, 0x56, 0xe4, 0xb1, 0x0f, 0xb7, 0x7c, 0x4c, 0x82, 0x0b, 0xb4, 0xe2, 0x25, 0x47,
-	0x2a, 0xa7, 0x8c, 0x23, 0x45, 0x11, 0x5f, 0xe5, 0x92, 0x4e, 0x4e, 0x4d, 0x79, 0x47, 0xe0, 0x2c,
-	0x38, 0x31, 0x97, 0xfa, 0x04, 0x5a, 0x03, 0x13, 0x5f, 0x67, 0xff, 0xae, 0x7c, 0xd7, 0x45, 0x65,
-	0xa9, 0xe3, 0x39, 0x70, 0xb7, 0xc2, 0x0f, 0x5d, 0x93, 0xc1, 0xc6, 0x50, 0xa4, 0x93, 0x03, 0xea,
-	0x7c, 0x06, 0xf1, 0x0d, 0x58, 0xb7, 0x78, 0xa4, 0xf5, 0x03, 0x74, 0x65, 0x49, 0x0f, 0x71, 0x7c,
-	0x81, 0x89, 0x18, 0x44, 0xd9, 0xf9, 0xd0, 0xc6, 0xfa, 0x21, 0xdc, 0x08, 0xa3, 0xec, 0xfc, 0x88,
-	0x23, 0xfa, 0xd4, 0xf7, 0x64, 0x78, 0x0d, 0xbf, 0xc8, 0x9c, 0xbf, 0x48, 0xd3, 0x7a, 0x91, 0xbf,
-	0x1b, 0xb0, 0x29, 0x5d, 0x5b, 0x3e, 0x27, 0xf1, 0x8c, 0x3d, 0x81, 0xf6, 0x34, 0x0b, 0xc6, 0xa8,
-	0xc3, 0xf3, 0x64, 0x78, 0x15, 0x8a, 0x3d, 0x22, 0xdf, 0x90, 0xa6, 0xaf, 0x0c, 0xdc, 0x08, 0x56,
-	0xe7, 0x3c, 0xb6, 0x0e, 0xcd, 0xd3, 0x4c, 0x83, 0xdd, 0x3c, 0xcd, 0xe8, 0x0a, 0x67, 0x69, 0x66,
-	0x60, 0x96, 0xdf, 0xd4, 0xc0, 0x82, 0xcb, 0x20, 0x8a, 0x29, 0x25, 0x64, 0xe5, 0xb7, 0xfc, 0x9c,
-	0x41, 0x79, 0xcd, 0xf1, 0xfd, 0x34, 0xe2, 0x18, 0xca, 0xb2, 0x6f, 0xf9, 0x73, 0xda, 0xfb, 0xb7,
-	0x01, 0x6b, 0x7e, 0x36, 0x4b, 0x46, 0x06, 0x87, 0x27, 0xb0, 0x92, 0x4e, 0xa8, 0xef, 0x9b, 0x67,
-	0xd9, 0x51, 0xcf, 0x62, 0xe9, 0x28, 0xe2, 0x95, 0xd2, 0xf2, 0x8d, 0xba, 0xfb, 0xa7, 0x71, 0xa5,
-	0x25, 0xd4, 0x50, 0x32, 0x99, 0x1c, 0x26, 0x83, 0x0d, 0x49, 0x0d, 0x33, 0xc4, 0x4c, 0x44, 0x89,
-	0x9c, 0x30, 0x5f, 0xe7, 0xe1, 0x94, 0xd9, 0xd4, 0x7c, 0x2d, 0x96, 0x6e, 0xfa, 0x36, 0x8b, 0x4e,
-	0x31, 0x17, 0x6e, 0xa9, 0x53, 0x34, 0x49, 0x4f, 0x8a, 0xbf, 0x8e, 0xe2, 0x69, 0x88, 0xe1, 0x51,
-	0x14, 0x63, 0xe6, 0xb4, 0xa5, 0xbc, 0xc8, 0xf4, 0xd6, 0x00, 0x74, 0x70, 0x94, 0x26, 0x9f, 0xc3,
-	0x96, 0x8f, 0x99, 0x48, 0x39, 0x1e, 0x8f, 0xa9, 0xfd, 0xf1, 0x34, 0xfe, 0x98, 0xf6, 0xb0, 0x05,
-	0x77, 0x16, 0xcd, 0xc8, 0xdf, 0x98, 0x9a, 0x51, 0x18, 0x08, 0xa4, 0xc3, 0xfa, 0x69, 0x72, 0x6a,
-	0xc0, 0x61, 0xd0, 0x9a, 0x04, 0xe2, 0x4c, 0x3f, 0xac, 0xfc, 0xa6, 0x50, 0x26, 0x81, 0x10, 0xc8,
-	0x13, 0x0d, 0x87, 0x21, 0x09, 0x06, 0x8e, 0x93, 0x38, 0x18, 0x21, 0x25, 0xaf, 0x81, 0xc1, 0x62,
-	0x79, 0x3e, 0xb8, 0xea, 0x20, 0x3a, 0x24, 0x1a, 0x4f, 0xb9, 0x44, 0xc7, 0xdc, 0xfd, 0x71, 0xf9,
-	0x55, 0x5d, 0xf9, 0xaa, 0x95, 0x57, 0x9b, 0x03, 0x48, 0xcd, 0xa1, 0xd2, 0x27, 0x05, 0xf6, 0x47,
-	0xc3, 0x14, 0xb6, 0xd5, 0xc1, 0xcd, 0x71, 0xdf, 0xd0, 0x75, 0x49, 0xa6, 0xa6, 0xb0, 0x3a, 0x72,
-	0xcf, 0xaa, 0xef, 0x45, 0x1b, 0x2d, 0x90, 0xd3, 0xd9, 0x36, 0x76, 0x8f, 0x00, 0x72, 0x11, 0xb5,
-	0x99, 0xac, 0xd0, 0x7e, 0x14, 0x55, 0xce, 0x93, 0xe6, 0x42, 0x9e, 0xe4, 0x0d, 0xa4, 0x70, 0x36,
-	0x85, 0xf2, 0x4f, 0x03, 0xb6, 0xfb, 0x1c, 0x03, 0x81, 0x3e, 0x8e, 0xd2, 0x4b, 0xe4, 0x33, 0x8a,
-	0xd7, 0xc4, 0xf2, 0x2d, 0x74, 0x46, 0x69, 0x92, 0xe0, 0xc8, 0x86, 0xef, 0x91, 0x2a, 0xe6, 0x3a,
-	0xa3, 0x5e, 0x7f, 0x6e, 0xe1, 0xdb, 0xd6, 0xee, 0x6f, 0x0d, 0x80, 0x5c, 0x46, 0x19, 0x7a, 0x11,
-	0x71, 0x9e, 0xf2, 0xd2, 0x60, 0x2f, 0x30, 0x29, 0x55, 0xa6, 0x19, 0x9a, 0xce, 0x2d, 0xbf, 0x29,
-	0xde, 0x89, 0x9c, 0x6e, 0x33, 0x59, 0x3d, 0x3a, 0x21, 0x2c, 0x96, 0xa5, 0x61, 0xcd, 0x7b, 0x9b,
-	0xe5, 0x6d, 0xc3, 0x56, 0x55, 0x04, 0x04, 0xc9, 0x5f, 0x0d, 0xe8, 0x1e, 0x84, 0x21, 0x11, 0x91,
-	0x5a, 0x03, 0x69, 0x38, 0x5b, 0xad, 0xfb, 0x00, 0x56, 0x50, 0x71, 0x34, 0x22, 0xff, 0x97, 0x88,
-	0x5c, 0x65, 0xd3, 0x53, 0x0b, 0x80, 0xb1, 0x73, 0x87, 0xd0, 0x56, 0x13, 0xdf, 0x81, 0x95, 0xe2,
-	0xfa, 0xb3, 0x62, 0x45, 0x4e, 0x7b, 0xa6, 0xe9, 0x75, 0xf4, 0x4d, 0xbd, 0x8e, 0xe2, 0x3b, 0x08,
-	0x43, 0x9e, 0x39, 0xcb, 0xb2, 0x0e, 0x73, 0x06, 0xcd, 0xe9, 0x9a, 0x3b, 0x4c, 0xe2, 0xd9, 0xfe,
-	0x87, 0x55, 0x68, 0xcb, 0x99, 0xc0, 0x5e, 0xc1, 0x7a, 0xb1, 0x15, 0xb3, 0x07, 0x79, 0x7f, 0xae,
-	0x99, 0x11, 0xae, 0x53, 0xd7, 0xc2, 0xbd, 0x25, 0xf6, 0x12, 0x36, 0xca, 0x5b, 0x07, 0xeb, 0xea,
-	0x22, 0xab, 0xdc, 0x8c, 0x5d, 0xb7, 0x46, 0xaa, 0xfc, 0x7d, 0x57, 0x35, 0x7c, 0xef, 0xd7, 0x8c,
-	0x48, 0xed, 0xf1, 0x5e, 0x9d, 0x58, 0xb9, 0xfc, 0x02, 0x56, 0xe7, 0x43, 0x91, 0xdd, 0x91, 0xba,
-	0xe5, 0xc1, 0xe9, 0x6e, 0x96, 0xd9, 0xca, 0xf4, 0x67, 0xb3, 0x75, 0x94, 0xd6, 0x1f, 0x8d, 0xda,
-	0x55, 0x6b, 0x95, 0xfb, 0xbf, 0xab, 0x54, 0x94, 0xfb, 0x9f, 0xe0, 0x76, 0xd5, 0x82, 0xc4, 0x76,
-	0x2d, 0xd3, 0xca, 0xd5, 0xca, 0xdd, 0xb9, 0x42, 0x43, 0xf9, 0xfe, 0xd1, 0xec, 0x66, 0x79, 0xdd,
-	0xdb, 0x01, 0x74, 0x2d, 0x07, 0x0b, 0x1b, 0x98, 0x7e, 0xa3, 0xea, 0x85, 0x6b, 0x89, 0xbd, 0x85,
-	0xcd, 0x8a, 0xe5, 0x89, 0xa9, 0x80, 0xeb, 0x97, 0x31, 0xf7, 0x7e, 0xbd, 0x82, 0x72, 0xfc, 0x25,
-	0xdc, 0x96, 0x33, 0xa9, 0x8c, 0xf6, 0xad, 0x85, 0x59, 0xec, 0xde, 0xb4, 0x59, 0xca, 0xfa, 0x10,
-	0x5c, 0x49, 0x57, 0x07, 0xfc, 0x71, 0x3e, 0xde, 0xc2, 0xb6, 0x19, 0x68, 0x26, 0x33, 0xe7, 0x93,
-	0x4d, 0x63, 0x56, 0x33, 0x27, 0x35, 0x66, 0xd5, 0xe3, 0x50, 0x62, 0x56, 0x31, 0x53, 0x34, 0x66,
-	0xf5, 0x13, 0x4c, 0x63, 0x56, 0x3b, 0x8e, 0xac, 0x82, 0xb1, 0xfa, 0x7b, 0xa1, 0x60, 0x16, 0x67,
-	0x4e, 0xa1, 0x60, 0x16, 0xc6, 0xc2, 0x12, 0x7b, 0x0d, 0x6c, 0xb1, 0x41, 0xb2, 0x9d, 0xab, 0x7b,
-	0xbf, 0xdb, 0xad, 0x95, 0xcf, 0x6b, 0xa9, 0xb2, 0x45, 0xe9, 0x5a, 0xba, 0xaa, 0x85, 0xea, 0x5a,
-	0xaa, 0xef, 0x70, 0xde, 0xd2, 0xc9, 0x35, 0xf9, 0xf3, 0xff, 0xd9, 0x7f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x10, 0x09, 0x5b, 0x52, 0x12, 0x10, 0x00, 0x00,
+func (m *CheckExtensionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckExtensionsRequest.Unmarshal(m, b)
+}
+func (m *CheckExtensionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckExtensionsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckExtensionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckExtensionsRequest.Merge(m, src)
+}
+func (m *CheckExtensionsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckExtensionsRequest.Size(m)
+}
+func (m *CheckExtensionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckExtensionsRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_CheckExtensionsRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *CheckExtensionsRequest) GetGphome() string {
+	if m != nil {
+		return m.Gphome
+	}
+	return ""
+}
 
-// AgentClient is the client API for Agent service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AgentClient interface {
-	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
-	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
-	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
-	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
-	DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error)
-	DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error)
-	DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
-	ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error)
-	RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
-	RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
-	RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error)
-	UpdateConfiguration(ctx context.Context, in *UpdateConfigurationRequest, opts ...grpc.CallOption) (*UpdateConfigurationReply, error)
-	RenameTablespaces(ctx context.Context, in *RenameTablespacesRequest, opts ...grpc.CallOption) (*RenameTablespacesReply, error)
-	CreateRecoveryConf(ctx context.Context, in *CreateRecoveryConfRequest, opts ...grpc.CallOption) (*CreateRecoveryConfReply, error)
-	AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error)
+func (m *CheckExtensionsRequest) GetExtensions() []string {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
 }
 
-type agentClient struct {
-	cc *grpc.ClientConn
+type CheckExtensionsReply struct {
+	Hostname             string                            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Extensions           []*CheckExtensionsReply_Extension `protobuf:"bytes,2,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
 }
 
-func NewAgentClient(cc *grpc.ClientConn) AgentClient {
-	return &agentClient{cc}
+func (m *CheckExtensionsReply) Reset()         { *m = CheckExtensionsReply{} }
+func (m *CheckExtensionsReply) String() string { return proto.CompactTextString(m) }
+func (*CheckExtensionsReply) ProtoMessage()    {}
+func (*CheckExtensionsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{33}
 }
 
-func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error) {
-	out := new(CheckDiskSpaceReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/CheckDiskSpace", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckExtensionsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckExtensionsReply.Unmarshal(m, b)
+}
+func (m *CheckExtensionsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckExtensionsReply.Marshal(b, m, deterministic)
+}
+func (m *CheckExtensionsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckExtensionsReply.Merge(m, src)
+}
+func (m *CheckExtensionsReply) XXX_Size() int {
+	return xxx_messageInfo_CheckExtensionsReply.Size(m)
+}
+func (m *CheckExtensionsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckExtensionsReply.DiscardUnknown(m)
 }
 
-func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
-	out := new(UpgradePrimariesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradePrimaries", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CheckExtensionsReply proto.InternalMessageInfo
+
+func (m *CheckExtensionsReply) GetHostname() string {
+	if m != nil {
+		return m.Hostname
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error) {
-	out := new(RenameDirectoriesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/RenameDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CheckExtensionsReply) GetExtensions() []*CheckExtensionsReply_Extension {
+	if m != nil {
+		return m.Extensions
 	}
-	return out, nil
+	return nil
 }
 
-func (c *agentClient) StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
-	out := new(StopAgentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/StopAgent", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CheckExtensionsReply_Extension struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ControlFileFound     bool     `protobuf:"varint,2,opt,name=controlFileFound,proto3" json:"controlFileFound,omitempty"`
+	DefaultVersion       string   `protobuf:"bytes,3,opt,name=defaultVersion,proto3" json:"defaultVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *agentClient) DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error) {
-	out := new(DeleteDataDirectoriesReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteDataDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckExtensionsReply_Extension) Reset()         { *m = CheckExtensionsReply_Extension{} }
+func (m *CheckExtensionsReply_Extension) String() string { return proto.CompactTextString(m) }
+func (*CheckExtensionsReply_Extension) ProtoMessage()    {}
+func (*CheckExtensionsReply_Extension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{33, 0}
 }
 
-func (c *agentClient) DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error) {
-	out := new(DeleteStateDirectoryReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteStateDirectory", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CheckExtensionsReply_Extension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckExtensionsReply_Extension.Unmarshal(m, b)
+}
+func (m *CheckExtensionsReply_Extension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckExtensionsReply_Extension.Marshal(b, m, deterministic)
+}
+func (m *CheckExtensionsReply_Extension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckExtensionsReply_Extension.Merge(m, src)
+}
+func (m *CheckExtensionsReply_Extension) XXX_Size() int {
+	return xxx_messageInfo_CheckExtensionsReply_Extension.Size(m)
+}
+func (m *CheckExtensionsReply_Extension) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckExtensionsReply_Extension.DiscardUnknown(m)
 }
 
-func (c *agentClient) DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
-	out := new(DeleteTablespaceReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteTablespaceDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CheckExtensionsReply_Extension proto.InternalMessageInfo
+
+func (m *CheckExtensionsReply_Extension) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error) {
-	out := new(ArchiveLogDirectoryReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/ArchiveLogDirectory", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CheckExtensionsReply_Extension) GetControlFileFound() bool {
+	if m != nil {
+		return m.ControlFileFound
 	}
-	return out, nil
+	return false
 }
 
-func (c *agentClient) RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error) {
-	out := new(RsyncReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/RsyncDataDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CheckExtensionsReply_Extension) GetDefaultVersion() string {
+	if m != nil {
+		return m.DefaultVersion
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error) {
-	out := new(RsyncReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/RsyncTablespaceDirectories", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ResolveLibrariesRequest struct {
+	Gphome               string   `protobuf:"bytes,1,opt,name=gphome,proto3" json:"gphome,omitempty"`
+	DynamicLibraryPath   string   `protobuf:"bytes,2,opt,name=dynamicLibraryPath,proto3" json:"dynamicLibraryPath,omitempty"`
+	Libraries            []string `protobuf:"bytes,3,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *agentClient) RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error) {
-	out := new(RestorePgControlReply)
+func (m *ResolveLibrariesRequest) Reset()         { *m = ResolveLibrariesRequest{} }
+func (m *ResolveLibrariesRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveLibrariesRequest) ProtoMessage()    {}
+func (*ResolveLibrariesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{34}
+}
+
+func (m *ResolveLibrariesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveLibrariesRequest.Unmarshal(m, b)
+}
+func (m *ResolveLibrariesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveLibrariesRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolveLibrariesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveLibrariesRequest.Merge(m, src)
+}
+func (m *ResolveLibrariesRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveLibrariesRequest.Size(m)
+}
+func (m *ResolveLibrariesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveLibrariesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveLibrariesRequest proto.InternalMessageInfo
+
+func (m *ResolveLibrariesRequest) GetGphome() string {
+	if m != nil {
+		return m.Gphome
+	}
+	return ""
+}
+
+func (m *ResolveLibrariesRequest) GetDynamicLibraryPath() string {
+	if m != nil {
+		return m.DynamicLibraryPath
+	}
+	return ""
+}
+
+func (m *ResolveLibrariesRequest) GetLibraries() []string {
+	if m != nil {
+		return m.Libraries
+	}
+	return nil
+}
+
+type ResolveLibrariesReply struct {
+	Hostname             string                           `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Libraries            []*ResolveLibrariesReply_Library `protobuf:"bytes,2,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *ResolveLibrariesReply) Reset()         { *m = ResolveLibrariesReply{} }
+func (m *ResolveLibrariesReply) String() string { return proto.CompactTextString(m) }
+func (*ResolveLibrariesReply) ProtoMessage()    {}
+func (*ResolveLibrariesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{35}
+}
+
+func (m *ResolveLibrariesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveLibrariesReply.Unmarshal(m, b)
+}
+func (m *ResolveLibrariesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveLibrariesReply.Marshal(b, m, deterministic)
+}
+func (m *ResolveLibrariesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveLibrariesReply.Merge(m, src)
+}
+func (m *ResolveLibrariesReply) XXX_Size() int {
+	return xxx_messageInfo_ResolveLibrariesReply.Size(m)
+}
+func (m *ResolveLibrariesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveLibrariesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveLibrariesReply proto.InternalMessageInfo
+
+func (m *ResolveLibrariesReply) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *ResolveLibrariesReply) GetLibraries() []*ResolveLibrariesReply_Library {
+	if m != nil {
+		return m.Libraries
+	}
+	return nil
+}
+
+type ResolveLibrariesReply_Library struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Found                bool     `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveLibrariesReply_Library) Reset()         { *m = ResolveLibrariesReply_Library{} }
+func (m *ResolveLibrariesReply_Library) String() string { return proto.CompactTextString(m) }
+func (*ResolveLibrariesReply_Library) ProtoMessage()    {}
+func (*ResolveLibrariesReply_Library) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{35, 0}
+}
+
+func (m *ResolveLibrariesReply_Library) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveLibrariesReply_Library.Unmarshal(m, b)
+}
+func (m *ResolveLibrariesReply_Library) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveLibrariesReply_Library.Marshal(b, m, deterministic)
+}
+func (m *ResolveLibrariesReply_Library) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveLibrariesReply_Library.Merge(m, src)
+}
+func (m *ResolveLibrariesReply_Library) XXX_Size() int {
+	return xxx_messageInfo_ResolveLibrariesReply_Library.Size(m)
+}
+func (m *ResolveLibrariesReply_Library) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveLibrariesReply_Library.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveLibrariesReply_Library proto.InternalMessageInfo
+
+func (m *ResolveLibrariesReply_Library) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResolveLibrariesReply_Library) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ResolveLibrariesReply_Library) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+type CheckFilesystemsRequest struct {
+	Pairs                []*CheckFilesystemsRequest_DirPair `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *CheckFilesystemsRequest) Reset()         { *m = CheckFilesystemsRequest{} }
+func (m *CheckFilesystemsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckFilesystemsRequest) ProtoMessage()    {}
+func (*CheckFilesystemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{36}
+}
+
+func (m *CheckFilesystemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckFilesystemsRequest.Unmarshal(m, b)
+}
+func (m *CheckFilesystemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckFilesystemsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckFilesystemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckFilesystemsRequest.Merge(m, src)
+}
+func (m *CheckFilesystemsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckFilesystemsRequest.Size(m)
+}
+func (m *CheckFilesystemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckFilesystemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckFilesystemsRequest proto.InternalMessageInfo
+
+func (m *CheckFilesystemsRequest) GetPairs() []*CheckFilesystemsRequest_DirPair {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+type CheckFilesystemsRequest_DirPair struct {
+	Dbid                 int32    `protobuf:"varint,1,opt,name=dbid,proto3" json:"dbid,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Target               string   `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckFilesystemsRequest_DirPair) Reset()         { *m = CheckFilesystemsRequest_DirPair{} }
+func (m *CheckFilesystemsRequest_DirPair) String() string { return proto.CompactTextString(m) }
+func (*CheckFilesystemsRequest_DirPair) ProtoMessage()    {}
+func (*CheckFilesystemsRequest_DirPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{36, 0}
+}
+
+func (m *CheckFilesystemsRequest_DirPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckFilesystemsRequest_DirPair.Unmarshal(m, b)
+}
+func (m *CheckFilesystemsRequest_DirPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckFilesystemsRequest_DirPair.Marshal(b, m, deterministic)
+}
+func (m *CheckFilesystemsRequest_DirPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckFilesystemsRequest_DirPair.Merge(m, src)
+}
+func (m *CheckFilesystemsRequest_DirPair) XXX_Size() int {
+	return xxx_messageInfo_CheckFilesystemsRequest_DirPair.Size(m)
+}
+func (m *CheckFilesystemsRequest_DirPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckFilesystemsRequest_DirPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckFilesystemsRequest_DirPair proto.InternalMessageInfo
+
+func (m *CheckFilesystemsRequest_DirPair) GetDbid() int32 {
+	if m != nil {
+		return m.Dbid
+	}
+	return 0
+}
+
+func (m *CheckFilesystemsRequest_DirPair) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CheckFilesystemsRequest_DirPair) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type CheckFilesystemsReply struct {
+	Hostname             string                           `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Devices              []*CheckFilesystemsReply_Devices `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CheckFilesystemsReply) Reset()         { *m = CheckFilesystemsReply{} }
+func (m *CheckFilesystemsReply) String() string { return proto.CompactTextString(m) }
+func (*CheckFilesystemsReply) ProtoMessage()    {}
+func (*CheckFilesystemsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{37}
+}
+
+func (m *CheckFilesystemsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckFilesystemsReply.Unmarshal(m, b)
+}
+func (m *CheckFilesystemsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckFilesystemsReply.Marshal(b, m, deterministic)
+}
+func (m *CheckFilesystemsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckFilesystemsReply.Merge(m, src)
+}
+func (m *CheckFilesystemsReply) XXX_Size() int {
+	return xxx_messageInfo_CheckFilesystemsReply.Size(m)
+}
+func (m *CheckFilesystemsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckFilesystemsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckFilesystemsReply proto.InternalMessageInfo
+
+func (m *CheckFilesystemsReply) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CheckFilesystemsReply) GetDevices() []*CheckFilesystemsReply_Devices {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type CheckFilesystemsReply_Devices struct {
+	Dbid                 int32    `protobuf:"varint,1,opt,name=dbid,proto3" json:"dbid,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Target               string   `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	SourceDevice         uint64   `protobuf:"varint,4,opt,name=sourceDevice,proto3" json:"sourceDevice,omitempty"`
+	TargetDevice         uint64   `protobuf:"varint,5,opt,name=targetDevice,proto3" json:"targetDevice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckFilesystemsReply_Devices) Reset()         { *m = CheckFilesystemsReply_Devices{} }
+func (m *CheckFilesystemsReply_Devices) String() string { return proto.CompactTextString(m) }
+func (*CheckFilesystemsReply_Devices) ProtoMessage()    {}
+func (*CheckFilesystemsReply_Devices) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{37, 0}
+}
+
+func (m *CheckFilesystemsReply_Devices) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckFilesystemsReply_Devices.Unmarshal(m, b)
+}
+func (m *CheckFilesystemsReply_Devices) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckFilesystemsReply_Devices.Marshal(b, m, deterministic)
+}
+func (m *CheckFilesystemsReply_Devices) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckFilesystemsReply_Devices.Merge(m, src)
+}
+func (m *CheckFilesystemsReply_Devices) XXX_Size() int {
+	return xxx_messageInfo_CheckFilesystemsReply_Devices.Size(m)
+}
+func (m *CheckFilesystemsReply_Devices) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckFilesystemsReply_Devices.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckFilesystemsReply_Devices proto.InternalMessageInfo
+
+func (m *CheckFilesystemsReply_Devices) GetDbid() int32 {
+	if m != nil {
+		return m.Dbid
+	}
+	return 0
+}
+
+func (m *CheckFilesystemsReply_Devices) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CheckFilesystemsReply_Devices) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *CheckFilesystemsReply_Devices) GetSourceDevice() uint64 {
+	if m != nil {
+		return m.SourceDevice
+	}
+	return 0
+}
+
+func (m *CheckFilesystemsReply_Devices) GetTargetDevice() uint64 {
+	if m != nil {
+		return m.TargetDevice
+	}
+	return 0
+}
+
+type CheckPortsRequest struct {
+	Ports                []uint32 `protobuf:"varint,1,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckPortsRequest) Reset()         { *m = CheckPortsRequest{} }
+func (m *CheckPortsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckPortsRequest) ProtoMessage()    {}
+func (*CheckPortsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{38}
+}
+
+func (m *CheckPortsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPortsRequest.Unmarshal(m, b)
+}
+func (m *CheckPortsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPortsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckPortsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPortsRequest.Merge(m, src)
+}
+func (m *CheckPortsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckPortsRequest.Size(m)
+}
+func (m *CheckPortsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPortsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPortsRequest proto.InternalMessageInfo
+
+func (m *CheckPortsRequest) GetPorts() []uint32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type CheckPortsReply struct {
+	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	UnavailablePorts     []uint32 `protobuf:"varint,2,rep,packed,name=unavailablePorts,proto3" json:"unavailablePorts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckPortsReply) Reset()         { *m = CheckPortsReply{} }
+func (m *CheckPortsReply) String() string { return proto.CompactTextString(m) }
+func (*CheckPortsReply) ProtoMessage()    {}
+func (*CheckPortsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{39}
+}
+
+func (m *CheckPortsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPortsReply.Unmarshal(m, b)
+}
+func (m *CheckPortsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPortsReply.Marshal(b, m, deterministic)
+}
+func (m *CheckPortsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPortsReply.Merge(m, src)
+}
+func (m *CheckPortsReply) XXX_Size() int {
+	return xxx_messageInfo_CheckPortsReply.Size(m)
+}
+func (m *CheckPortsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPortsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPortsReply proto.InternalMessageInfo
+
+func (m *CheckPortsReply) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CheckPortsReply) GetUnavailablePorts() []uint32 {
+	if m != nil {
+		return m.UnavailablePorts
+	}
+	return nil
+}
+
+type GetHostInfoRequest struct {
+	SourceGPHome         string   `protobuf:"bytes,1,opt,name=sourceGPHome,proto3" json:"sourceGPHome,omitempty"`
+	TargetGPHome         string   `protobuf:"bytes,2,opt,name=targetGPHome,proto3" json:"targetGPHome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHostInfoRequest) Reset()         { *m = GetHostInfoRequest{} }
+func (m *GetHostInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHostInfoRequest) ProtoMessage()    {}
+func (*GetHostInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{40}
+}
+
+func (m *GetHostInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostInfoRequest.Unmarshal(m, b)
+}
+func (m *GetHostInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHostInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostInfoRequest.Merge(m, src)
+}
+func (m *GetHostInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHostInfoRequest.Size(m)
+}
+func (m *GetHostInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostInfoRequest proto.InternalMessageInfo
+
+func (m *GetHostInfoRequest) GetSourceGPHome() string {
+	if m != nil {
+		return m.SourceGPHome
+	}
+	return ""
+}
+
+func (m *GetHostInfoRequest) GetTargetGPHome() string {
+	if m != nil {
+		return m.TargetGPHome
+	}
+	return ""
+}
+
+type HostInfo struct {
+	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Os                   string   `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Kernel               string   `protobuf:"bytes,3,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	GpupgradeVersion     string   `protobuf:"bytes,4,opt,name=gpupgradeVersion,proto3" json:"gpupgradeVersion,omitempty"`
+	SourceVersion        string   `protobuf:"bytes,5,opt,name=sourceVersion,proto3" json:"sourceVersion,omitempty"`
+	TargetVersion        string   `protobuf:"bytes,6,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
+	RsyncVersion         string   `protobuf:"bytes,7,opt,name=rsyncVersion,proto3" json:"rsyncVersion,omitempty"`
+	FreeMemory           uint64   `protobuf:"varint,8,opt,name=freeMemory,proto3" json:"freeMemory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HostInfo) Reset()         { *m = HostInfo{} }
+func (m *HostInfo) String() string { return proto.CompactTextString(m) }
+func (*HostInfo) ProtoMessage()    {}
+func (*HostInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{41}
+}
+
+func (m *HostInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostInfo.Unmarshal(m, b)
+}
+func (m *HostInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostInfo.Marshal(b, m, deterministic)
+}
+func (m *HostInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostInfo.Merge(m, src)
+}
+func (m *HostInfo) XXX_Size() int {
+	return xxx_messageInfo_HostInfo.Size(m)
+}
+func (m *HostInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostInfo proto.InternalMessageInfo
+
+func (m *HostInfo) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *HostInfo) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *HostInfo) GetKernel() string {
+	if m != nil {
+		return m.Kernel
+	}
+	return ""
+}
+
+func (m *HostInfo) GetGpupgradeVersion() string {
+	if m != nil {
+		return m.GpupgradeVersion
+	}
+	return ""
+}
+
+func (m *HostInfo) GetSourceVersion() string {
+	if m != nil {
+		return m.SourceVersion
+	}
+	return ""
+}
+
+func (m *HostInfo) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *HostInfo) GetRsyncVersion() string {
+	if m != nil {
+		return m.RsyncVersion
+	}
+	return ""
+}
+
+func (m *HostInfo) GetFreeMemory() uint64 {
+	if m != nil {
+		return m.FreeMemory
+	}
+	return 0
+}
+
+type GetHostInfoReply struct {
+	Info                 *HostInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetHostInfoReply) Reset()         { *m = GetHostInfoReply{} }
+func (m *GetHostInfoReply) String() string { return proto.CompactTextString(m) }
+func (*GetHostInfoReply) ProtoMessage()    {}
+func (*GetHostInfoReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{42}
+}
+
+func (m *GetHostInfoReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostInfoReply.Unmarshal(m, b)
+}
+func (m *GetHostInfoReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostInfoReply.Marshal(b, m, deterministic)
+}
+func (m *GetHostInfoReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostInfoReply.Merge(m, src)
+}
+func (m *GetHostInfoReply) XXX_Size() int {
+	return xxx_messageInfo_GetHostInfoReply.Size(m)
+}
+func (m *GetHostInfoReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostInfoReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostInfoReply proto.InternalMessageInfo
+
+func (m *GetHostInfoReply) GetInfo() *HostInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type GetControlDataRequest struct {
+	Gphome               string   `protobuf:"bytes,1,opt,name=gphome,proto3" json:"gphome,omitempty"`
+	DataDirs             []string `protobuf:"bytes,2,rep,name=dataDirs,proto3" json:"dataDirs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetControlDataRequest) Reset()         { *m = GetControlDataRequest{} }
+func (m *GetControlDataRequest) String() string { return proto.CompactTextString(m) }
+func (*GetControlDataRequest) ProtoMessage()    {}
+func (*GetControlDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{43}
+}
+
+func (m *GetControlDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetControlDataRequest.Unmarshal(m, b)
+}
+func (m *GetControlDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetControlDataRequest.Marshal(b, m, deterministic)
+}
+func (m *GetControlDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetControlDataRequest.Merge(m, src)
+}
+func (m *GetControlDataRequest) XXX_Size() int {
+	return xxx_messageInfo_GetControlDataRequest.Size(m)
+}
+func (m *GetControlDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetControlDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetControlDataRequest proto.InternalMessageInfo
+
+func (m *GetControlDataRequest) GetGphome() string {
+	if m != nil {
+		return m.Gphome
+	}
+	return ""
+}
+
+func (m *GetControlDataRequest) GetDataDirs() []string {
+	if m != nil {
+		return m.DataDirs
+	}
+	return nil
+}
+
+type ControlData struct {
+	DataDir              string            `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	ClusterState         string            `protobuf:"bytes,2,opt,name=clusterState,proto3" json:"clusterState,omitempty"`
+	PgControlVersion     string            `protobuf:"bytes,3,opt,name=pgControlVersion,proto3" json:"pgControlVersion,omitempty"`
+	CatalogVersion       string            `protobuf:"bytes,4,opt,name=catalogVersion,proto3" json:"catalogVersion,omitempty"`
+	SystemIdentifier     string            `protobuf:"bytes,5,opt,name=systemIdentifier,proto3" json:"systemIdentifier,omitempty"`
+	CheckpointLocation   string            `protobuf:"bytes,6,opt,name=checkpointLocation,proto3" json:"checkpointLocation,omitempty"`
+	RedoLocation         string            `protobuf:"bytes,7,opt,name=redoLocation,proto3" json:"redoLocation,omitempty"`
+	Timeline             string            `protobuf:"bytes,8,opt,name=timeline,proto3" json:"timeline,omitempty"`
+	NextXid              string            `protobuf:"bytes,9,opt,name=nextXid,proto3" json:"nextXid,omitempty"`
+	DataChecksumVersion  uint32            `protobuf:"varint,10,opt,name=dataChecksumVersion,proto3" json:"dataChecksumVersion,omitempty"`
+	Fields               map[string]string `protobuf:"bytes,11,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ControlData) Reset()         { *m = ControlData{} }
+func (m *ControlData) String() string { return proto.CompactTextString(m) }
+func (*ControlData) ProtoMessage()    {}
+func (*ControlData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{44}
+}
+
+func (m *ControlData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ControlData.Unmarshal(m, b)
+}
+func (m *ControlData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ControlData.Marshal(b, m, deterministic)
+}
+func (m *ControlData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControlData.Merge(m, src)
+}
+func (m *ControlData) XXX_Size() int {
+	return xxx_messageInfo_ControlData.Size(m)
+}
+func (m *ControlData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControlData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControlData proto.InternalMessageInfo
+
+func (m *ControlData) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *ControlData) GetClusterState() string {
+	if m != nil {
+		return m.ClusterState
+	}
+	return ""
+}
+
+func (m *ControlData) GetPgControlVersion() string {
+	if m != nil {
+		return m.PgControlVersion
+	}
+	return ""
+}
+
+func (m *ControlData) GetCatalogVersion() string {
+	if m != nil {
+		return m.CatalogVersion
+	}
+	return ""
+}
+
+func (m *ControlData) GetSystemIdentifier() string {
+	if m != nil {
+		return m.SystemIdentifier
+	}
+	return ""
+}
+
+func (m *ControlData) GetCheckpointLocation() string {
+	if m != nil {
+		return m.CheckpointLocation
+	}
+	return ""
+}
+
+func (m *ControlData) GetRedoLocation() string {
+	if m != nil {
+		return m.RedoLocation
+	}
+	return ""
+}
+
+func (m *ControlData) GetTimeline() string {
+	if m != nil {
+		return m.Timeline
+	}
+	return ""
+}
+
+func (m *ControlData) GetNextXid() string {
+	if m != nil {
+		return m.NextXid
+	}
+	return ""
+}
+
+func (m *ControlData) GetDataChecksumVersion() uint32 {
+	if m != nil {
+		return m.DataChecksumVersion
+	}
+	return 0
+}
+
+func (m *ControlData) GetFields() map[string]string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type GetControlDataReply struct {
+	Hostname             string         `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	ControlData          []*ControlData `protobuf:"bytes,2,rep,name=controlData,proto3" json:"controlData,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetControlDataReply) Reset()         { *m = GetControlDataReply{} }
+func (m *GetControlDataReply) String() string { return proto.CompactTextString(m) }
+func (*GetControlDataReply) ProtoMessage()    {}
+func (*GetControlDataReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{45}
+}
+
+func (m *GetControlDataReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetControlDataReply.Unmarshal(m, b)
+}
+func (m *GetControlDataReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetControlDataReply.Marshal(b, m, deterministic)
+}
+func (m *GetControlDataReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetControlDataReply.Merge(m, src)
+}
+func (m *GetControlDataReply) XXX_Size() int {
+	return xxx_messageInfo_GetControlDataReply.Size(m)
+}
+func (m *GetControlDataReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetControlDataReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetControlDataReply proto.InternalMessageInfo
+
+func (m *GetControlDataReply) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *GetControlDataReply) GetControlData() []*ControlData {
+	if m != nil {
+		return m.ControlData
+	}
+	return nil
+}
+
+type MigrateAuthFilesRequest struct {
+	DataDirPairs         []*DataDirPair `protobuf:"bytes,1,rep,name=dataDirPairs,proto3" json:"dataDirPairs,omitempty"`
+	TargetVersion        string         `protobuf:"bytes,2,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MigrateAuthFilesRequest) Reset()         { *m = MigrateAuthFilesRequest{} }
+func (m *MigrateAuthFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*MigrateAuthFilesRequest) ProtoMessage()    {}
+func (*MigrateAuthFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{46}
+}
+
+func (m *MigrateAuthFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateAuthFilesRequest.Unmarshal(m, b)
+}
+func (m *MigrateAuthFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateAuthFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *MigrateAuthFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateAuthFilesRequest.Merge(m, src)
+}
+func (m *MigrateAuthFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_MigrateAuthFilesRequest.Size(m)
+}
+func (m *MigrateAuthFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateAuthFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateAuthFilesRequest proto.InternalMessageInfo
+
+func (m *MigrateAuthFilesRequest) GetDataDirPairs() []*DataDirPair {
+	if m != nil {
+		return m.DataDirPairs
+	}
+	return nil
+}
+
+func (m *MigrateAuthFilesRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+type MigrateAuthFilesReply struct {
+	Changes              []string `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigrateAuthFilesReply) Reset()         { *m = MigrateAuthFilesReply{} }
+func (m *MigrateAuthFilesReply) String() string { return proto.CompactTextString(m) }
+func (*MigrateAuthFilesReply) ProtoMessage()    {}
+func (*MigrateAuthFilesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e73bb06acc917d8, []int{47}
+}
+
+func (m *MigrateAuthFilesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateAuthFilesReply.Unmarshal(m, b)
+}
+func (m *MigrateAuthFilesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateAuthFilesReply.Marshal(b, m, deterministic)
+}
+func (m *MigrateAuthFilesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateAuthFilesReply.Merge(m, src)
+}
+func (m *MigrateAuthFilesReply) XXX_Size() int {
+	return xxx_messageInfo_MigrateAuthFilesReply.Size(m)
+}
+func (m *MigrateAuthFilesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateAuthFilesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateAuthFilesReply proto.InternalMessageInfo
+
+func (m *MigrateAuthFilesReply) GetChanges() []string {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TablespaceInfo)(nil), "idl.TablespaceInfo")
+	proto.RegisterType((*UpgradePrimariesRequest)(nil), "idl.UpgradePrimariesRequest")
+	proto.RegisterType((*DataDirPair)(nil), "idl.DataDirPair")
+	proto.RegisterMapType((map[int32]*TablespaceInfo)(nil), "idl.DataDirPair.TablespacesEntry")
+	proto.RegisterType((*UpgradePrimariesReply)(nil), "idl.UpgradePrimariesReply")
+	proto.RegisterType((*DeleteDataDirectoriesRequest)(nil), "idl.DeleteDataDirectoriesRequest")
+	proto.RegisterType((*DeleteDataDirectoriesReply)(nil), "idl.DeleteDataDirectoriesReply")
+	proto.RegisterType((*DeleteStateDirectoryRequest)(nil), "idl.DeleteStateDirectoryRequest")
+	proto.RegisterType((*DeleteStateDirectoryReply)(nil), "idl.DeleteStateDirectoryReply")
+	proto.RegisterType((*DeleteTablespaceRequest)(nil), "idl.DeleteTablespaceRequest")
+	proto.RegisterType((*DeleteTablespaceReply)(nil), "idl.DeleteTablespaceReply")
+	proto.RegisterType((*ArchiveLogDirectoryRequest)(nil), "idl.ArchiveLogDirectoryRequest")
+	proto.RegisterType((*ArchiveLogDirectoryReply)(nil), "idl.ArchiveLogDirectoryReply")
+	proto.RegisterType((*RenameDirectories)(nil), "idl.RenameDirectories")
+	proto.RegisterType((*RenameDirectoriesRequest)(nil), "idl.RenameDirectoriesRequest")
+	proto.RegisterType((*RenameDirectoriesReply)(nil), "idl.RenameDirectoriesReply")
+	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
+	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
+	proto.RegisterType((*CheckSegmentDiskSpaceRequest)(nil), "idl.CheckSegmentDiskSpaceRequest")
+	proto.RegisterType((*CheckSegmentDiskSpaceRequest_Requirement)(nil), "idl.CheckSegmentDiskSpaceRequest.Requirement")
+	proto.RegisterType((*CheckDiskSpaceReply)(nil), "idl.CheckDiskSpaceReply")
+	proto.RegisterType((*CheckDiskSpaceReply_DiskUsage)(nil), "idl.CheckDiskSpaceReply.DiskUsage")
+	proto.RegisterType((*RsyncRequest)(nil), "idl.RsyncRequest")
+	proto.RegisterType((*RsyncRequest_RsyncOptions)(nil), "idl.RsyncRequest.RsyncOptions")
+	proto.RegisterType((*RsyncReply)(nil), "idl.RsyncReply")
+	proto.RegisterType((*RestorePgControlRequest)(nil), "idl.RestorePgControlRequest")
+	proto.RegisterType((*RestorePgControlReply)(nil), "idl.RestorePgControlReply")
+	proto.RegisterType((*UpdateFileConfOptions)(nil), "idl.UpdateFileConfOptions")
+	proto.RegisterType((*UpdateConfigurationRequest)(nil), "idl.UpdateConfigurationRequest")
+	proto.RegisterType((*UpdateConfigurationReply)(nil), "idl.UpdateConfigurationReply")
+	proto.RegisterType((*RenameTablespacesRequest)(nil), "idl.RenameTablespacesRequest")
+	proto.RegisterType((*RenameTablespacesRequest_RenamePair)(nil), "idl.RenameTablespacesRequest.RenamePair")
+	proto.RegisterType((*RenameTablespacesReply)(nil), "idl.RenameTablespacesReply")
+	proto.RegisterType((*CreateRecoveryConfRequest)(nil), "idl.CreateRecoveryConfRequest")
+	proto.RegisterType((*CreateRecoveryConfRequest_Connection)(nil), "idl.CreateRecoveryConfRequest.Connection")
+	proto.RegisterType((*CreateRecoveryConfReply)(nil), "idl.CreateRecoveryConfReply")
+	proto.RegisterType((*AddReplicationEntriesRequest)(nil), "idl.AddReplicationEntriesRequest")
+	proto.RegisterType((*AddReplicationEntriesRequest_Entry)(nil), "idl.AddReplicationEntriesRequest.Entry")
+	proto.RegisterType((*AddReplicationEntriesReply)(nil), "idl.AddReplicationEntriesReply")
+	proto.RegisterType((*CheckExtensionsRequest)(nil), "idl.CheckExtensionsRequest")
+	proto.RegisterType((*CheckExtensionsReply)(nil), "idl.CheckExtensionsReply")
+	proto.RegisterType((*CheckExtensionsReply_Extension)(nil), "idl.CheckExtensionsReply.Extension")
+	proto.RegisterType((*ResolveLibrariesRequest)(nil), "idl.ResolveLibrariesRequest")
+	proto.RegisterType((*ResolveLibrariesReply)(nil), "idl.ResolveLibrariesReply")
+	proto.RegisterType((*ResolveLibrariesReply_Library)(nil), "idl.ResolveLibrariesReply.Library")
+	proto.RegisterType((*CheckFilesystemsRequest)(nil), "idl.CheckFilesystemsRequest")
+	proto.RegisterType((*CheckFilesystemsRequest_DirPair)(nil), "idl.CheckFilesystemsRequest.DirPair")
+	proto.RegisterType((*CheckFilesystemsReply)(nil), "idl.CheckFilesystemsReply")
+	proto.RegisterType((*CheckFilesystemsReply_Devices)(nil), "idl.CheckFilesystemsReply.Devices")
+	proto.RegisterType((*CheckPortsRequest)(nil), "idl.CheckPortsRequest")
+	proto.RegisterType((*CheckPortsReply)(nil), "idl.CheckPortsReply")
+	proto.RegisterType((*GetHostInfoRequest)(nil), "idl.GetHostInfoRequest")
+	proto.RegisterType((*HostInfo)(nil), "idl.HostInfo")
+	proto.RegisterType((*GetHostInfoReply)(nil), "idl.GetHostInfoReply")
+	proto.RegisterType((*GetControlDataRequest)(nil), "idl.GetControlDataRequest")
+	proto.RegisterType((*ControlData)(nil), "idl.ControlData")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ControlData.FieldsEntry")
+	proto.RegisterType((*GetControlDataReply)(nil), "idl.GetControlDataReply")
+	proto.RegisterType((*MigrateAuthFilesRequest)(nil), "idl.MigrateAuthFilesRequest")
+	proto.RegisterType((*MigrateAuthFilesReply)(nil), "idl.MigrateAuthFilesReply")
+}
+
+func init() { proto.RegisterFile("hub_to_agent.proto", fileDescriptor_9e73bb06acc917d8) }
+
+var fileDescriptor_9e73bb06acc917d8 = []byte{
+	// 2406 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x8f, 0x64, 0xc9, 0xb2, 0x9e, 0x6c, 0xaf, 0x32, 0xfe, 0x92, 0x19, 0x27, 0x75, 0xd8, 0xa0,
+	0x75, 0x02, 0xac, 0xd0, 0xba, 0x59, 0x20, 0x1b, 0x2c, 0xd0, 0x3a, 0xd6, 0x3a, 0x49, 0x13, 0x27,
+	0x5e, 0x3a, 0xd9, 0xed, 0x16, 0x2d, 0x16, 0x34, 0x39, 0x92, 0x09, 0x53, 0xa4, 0x96, 0xa4, 0xbc,
+	0x51, 0x2f, 0x3d, 0x77, 0x81, 0x1e, 0x7b, 0x28, 0x7a, 0x2d, 0xda, 0x5b, 0x4f, 0x45, 0x2f, 0x05,
+	0xfa, 0xbf, 0xf4, 0xd4, 0x4b, 0xff, 0x80, 0xde, 0x5a, 0xbc, 0x37, 0x33, 0xe4, 0xf0, 0x43, 0x76,
+	0x0e, 0xbd, 0xe9, 0xfd, 0xe6, 0xcd, 0xe3, 0xbc, 0x37, 0x6f, 0xde, 0x97, 0x80, 0x9d, 0x4f, 0xcf,
+	0xbe, 0x4a, 0xc2, 0xaf, 0xec, 0x11, 0x0f, 0x92, 0xfe, 0x24, 0x0a, 0x93, 0x90, 0x2d, 0x78, 0xae,
+	0x6f, 0x9e, 0xc1, 0xea, 0x1b, 0xfb, 0xcc, 0xe7, 0xf1, 0xc4, 0x76, 0xf8, 0xf3, 0x60, 0x18, 0x32,
+	0x06, 0x8d, 0x57, 0xf6, 0x98, 0xf7, 0x16, 0x76, 0x6b, 0x7b, 0x6d, 0x8b, 0x7e, 0x33, 0x03, 0x96,
+	0x5e, 0x86, 0x8e, 0x9d, 0x78, 0x61, 0xd0, 0x6b, 0x10, 0x9e, 0xd2, 0x6c, 0x17, 0x3a, 0x6f, 0x63,
+	0x1e, 0x0d, 0xf8, 0xd0, 0x0b, 0xb8, 0xdb, 0x6b, 0xee, 0xd6, 0xf6, 0x96, 0x2c, 0x1d, 0x32, 0xff,
+	0x5c, 0x87, 0xad, 0xb7, 0x93, 0x51, 0x64, 0xbb, 0xfc, 0x24, 0xf2, 0xc6, 0x76, 0xe4, 0xf1, 0xd8,
+	0xe2, 0x5f, 0x4f, 0x79, 0x9c, 0x30, 0x13, 0x96, 0x4f, 0xc3, 0x69, 0xe4, 0xf0, 0x27, 0x5e, 0x30,
+	0xf0, 0xa2, 0x5e, 0x8d, 0xa4, 0xe7, 0x30, 0xe4, 0x79, 0x63, 0x47, 0x23, 0x9e, 0x48, 0x9e, 0xba,
+	0xe0, 0xd1, 0x31, 0x76, 0x0f, 0x56, 0x04, 0xfd, 0x39, 0x8f, 0x62, 0x3c, 0xa6, 0x38, 0x7e, 0x1e,
+	0x64, 0x0f, 0x61, 0x79, 0x60, 0x27, 0xf6, 0xc0, 0x8b, 0x4e, 0x6c, 0x2f, 0x8a, 0x7b, 0x8d, 0xdd,
+	0x85, 0xbd, 0xce, 0x7e, 0xb7, 0xef, 0xb9, 0x7e, 0x5f, 0x5b, 0xb0, 0x72, 0x5c, 0x6c, 0x07, 0xda,
+	0x87, 0xe7, 0xdc, 0xb9, 0x78, 0x1d, 0xf8, 0x33, 0xa9, 0x5f, 0x06, 0x48, 0xfd, 0x5f, 0x7a, 0xc1,
+	0xc5, 0x71, 0xe8, 0xf2, 0xde, 0x62, 0xaa, 0xbf, 0x82, 0xd8, 0x1e, 0x7c, 0x70, 0x6c, 0xc7, 0x09,
+	0x8f, 0x9e, 0xd8, 0xce, 0xc5, 0x74, 0x82, 0x2a, 0xb4, 0xe8, 0x74, 0x45, 0xd8, 0xfc, 0x67, 0x1d,
+	0x3a, 0xda, 0xa7, 0x51, 0x2b, 0x61, 0x09, 0x09, 0x4a, 0xf3, 0xe4, 0xc1, 0x4c, 0x77, 0xc5, 0x55,
+	0xd7, 0x75, 0x57, 0x5c, 0x77, 0x00, 0xc4, 0xb6, 0x93, 0x30, 0x4a, 0xc8, 0x3c, 0x4d, 0x4b, 0x43,
+	0x70, 0x5d, 0x6c, 0xa0, 0xf5, 0x86, 0x58, 0xcf, 0x10, 0xd6, 0x83, 0xd6, 0x61, 0x18, 0x24, 0x3c,
+	0x48, 0xc8, 0x06, 0x4d, 0x4b, 0x91, 0xe8, 0x31, 0x83, 0x27, 0xcf, 0x07, 0xa4, 0x7a, 0xd3, 0xa2,
+	0xdf, 0xec, 0x10, 0x3a, 0x99, 0x5f, 0xc5, 0xbd, 0x16, 0x19, 0xfa, 0x6e, 0xd1, 0xd0, 0x7d, 0x8d,
+	0xe7, 0xd3, 0x20, 0x89, 0x66, 0x96, 0xbe, 0xcb, 0x38, 0x85, 0x6e, 0x91, 0x81, 0x75, 0x61, 0xe1,
+	0x82, 0xcf, 0xc8, 0x10, 0x4d, 0x0b, 0x7f, 0xb2, 0xfb, 0xd0, 0xbc, 0xb4, 0xfd, 0x29, 0x27, 0xb5,
+	0x3b, 0xfb, 0x6b, 0xf4, 0x91, 0xbc, 0x53, 0x5b, 0x82, 0xe3, 0x71, 0xfd, 0x51, 0xcd, 0xdc, 0x82,
+	0x8d, 0xb2, 0x33, 0x4e, 0xfc, 0x99, 0xf9, 0x18, 0x76, 0x06, 0xdc, 0xe7, 0x89, 0xb2, 0x2b, 0x77,
+	0x92, 0x50, 0x77, 0x55, 0x03, 0x96, 0x5c, 0x3b, 0xb1, 0x5d, 0x74, 0x9c, 0xda, 0xee, 0x02, 0x3e,
+	0x02, 0x45, 0x9b, 0x3b, 0x60, 0xcc, 0xd9, 0x8b, 0x92, 0x6f, 0xc3, 0x2d, 0xb1, 0x7a, 0x9a, 0xd8,
+	0x09, 0x57, 0xcb, 0x33, 0x29, 0xd8, 0xbc, 0x05, 0xdb, 0xd5, 0xcb, 0xb8, 0xf7, 0x43, 0xd8, 0x12,
+	0x8b, 0x99, 0x46, 0xea, 0x40, 0x0c, 0x1a, 0xda, 0x61, 0xe8, 0x37, 0x6a, 0x57, 0x66, 0x47, 0x39,
+	0x0f, 0xc1, 0x38, 0x88, 0x9c, 0x73, 0xef, 0x92, 0xbf, 0x0c, 0x47, 0xc5, 0x23, 0xb0, 0x4d, 0x58,
+	0x7c, 0xc5, 0xbf, 0xc9, 0x3c, 0x4c, 0x52, 0xa6, 0x01, 0xbd, 0xca, 0x5d, 0x28, 0xf1, 0x10, 0x6e,
+	0x5a, 0x3c, 0xb0, 0xc7, 0x5c, 0xd3, 0x17, 0x05, 0x09, 0x9f, 0x52, 0x82, 0x04, 0x85, 0xb8, 0xf0,
+	0x25, 0xe9, 0x9c, 0x92, 0x32, 0x8f, 0xa0, 0x57, 0x12, 0xa2, 0x0e, 0xf5, 0x00, 0x1a, 0x03, 0xa5,
+	0x5f, 0x67, 0x7f, 0x93, 0xee, 0xb5, 0xcc, 0x4c, 0x3c, 0x66, 0x0f, 0x36, 0x2b, 0xe4, 0xe0, 0x31,
+	0x19, 0x74, 0x4f, 0x93, 0x70, 0x72, 0x80, 0x91, 0x4f, 0x59, 0xbc, 0x0b, 0xab, 0x1a, 0x86, 0x5c,
+	0x7f, 0xaa, 0xc3, 0x0e, 0xbd, 0xe9, 0x53, 0x3e, 0x1a, 0xf3, 0x20, 0x19, 0x78, 0xf1, 0xc5, 0xa9,
+	0x6e, 0xec, 0x7b, 0xb0, 0xe2, 0x7a, 0xf1, 0xc5, 0x51, 0xc4, 0xb9, 0x85, 0x81, 0x8f, 0xf4, 0xab,
+	0x59, 0x79, 0x30, 0xbd, 0x92, 0x7a, 0x76, 0x25, 0xec, 0x33, 0x58, 0x8e, 0xf8, 0xd7, 0x53, 0x2f,
+	0xe2, 0x28, 0x38, 0xee, 0x2d, 0x90, 0x3a, 0x1f, 0x92, 0x3a, 0x57, 0x7d, 0xb2, 0x6f, 0x65, 0xbb,
+	0xac, 0x9c, 0x08, 0x63, 0x06, 0x1d, 0x6d, 0x11, 0xbf, 0x3a, 0xb1, 0x93, 0x73, 0x69, 0x72, 0xfa,
+	0x8d, 0x06, 0x8f, 0xbd, 0x5f, 0xf1, 0xd7, 0x43, 0x65, 0x70, 0x41, 0xb1, 0x75, 0x68, 0x46, 0x74,
+	0xfe, 0x05, 0x3a, 0xbf, 0x20, 0x50, 0x02, 0xae, 0xd3, 0xb3, 0x6f, 0x58, 0xf4, 0x1b, 0x39, 0x87,
+	0x9e, 0xcf, 0x63, 0x7a, 0xee, 0x0d, 0x4b, 0x10, 0xe6, 0x6f, 0xeb, 0xb0, 0x46, 0xa7, 0xd6, 0x8e,
+	0x3b, 0xf1, 0x67, 0xec, 0x11, 0x34, 0xa7, 0xb1, 0x3d, 0xe2, 0xf2, 0xb6, 0xcc, 0x4c, 0xbd, 0x3c,
+	0x63, 0x1f, 0xc9, 0xb7, 0xc8, 0x69, 0x89, 0x0d, 0xc6, 0xdf, 0x6b, 0xd0, 0x4e, 0x41, 0xb6, 0x0a,
+	0xf5, 0x61, 0x2c, 0x35, 0xa9, 0x0f, 0x63, 0x3c, 0xd9, 0x79, 0x18, 0x2b, 0xb7, 0xa1, 0xdf, 0x18,
+	0x90, 0xed, 0x4b, 0xdb, 0xf3, 0xd1, 0xc5, 0x49, 0x8f, 0x86, 0x95, 0x01, 0xf8, 0x4e, 0xa5, 0xb1,
+	0x5c, 0xa9, 0x4f, 0x4a, 0x63, 0x28, 0x4e, 0x19, 0x9f, 0x07, 0xa1, 0x9b, 0x6a, 0x57, 0x84, 0xd9,
+	0xf7, 0x60, 0x55, 0xed, 0x92, 0x8c, 0x8b, 0xc4, 0x58, 0x40, 0xcd, 0xff, 0xd6, 0x60, 0xd9, 0x8a,
+	0x67, 0x81, 0xa3, 0x1c, 0xe5, 0x11, 0xb4, 0xc2, 0x09, 0x66, 0x46, 0xe5, 0xb8, 0x77, 0x84, 0xe3,
+	0x6a, 0x3c, 0x82, 0x78, 0x2d, 0xb8, 0x2c, 0xc5, 0x6e, 0xfc, 0x55, 0x89, 0x92, 0x2b, 0x18, 0x72,
+	0x63, 0x7a, 0x3e, 0xea, 0x8d, 0x2b, 0x12, 0xf5, 0x70, 0x79, 0x9c, 0x78, 0x01, 0xe5, 0xe0, 0x67,
+	0x99, 0x81, 0x8a, 0x30, 0xa6, 0x27, 0x0d, 0x92, 0x69, 0x51, 0x87, 0xf0, 0x2b, 0xea, 0xc0, 0x0d,
+	0xf1, 0x15, 0x49, 0xa2, 0xcf, 0xf3, 0x77, 0x8e, 0x3f, 0x75, 0xb9, 0x7b, 0x24, 0x3d, 0x01, 0xd7,
+	0xf3, 0xa0, 0xb9, 0x0c, 0x20, 0x95, 0xc3, 0x87, 0xf4, 0x11, 0x6c, 0x59, 0x3c, 0x4e, 0xc2, 0x88,
+	0x9f, 0x8c, 0x30, 0x41, 0x44, 0xa1, 0xff, 0x3e, 0x01, 0x74, 0x0b, 0x36, 0xca, 0xdb, 0x50, 0xde,
+	0xef, 0x6b, 0x18, 0xaf, 0x5d, 0x3b, 0xe1, 0xf8, 0xb5, 0xc3, 0x30, 0x18, 0x2a, 0xeb, 0x54, 0x79,
+	0x3d, 0x83, 0x06, 0x06, 0x01, 0xe5, 0x2d, 0x81, 0x2c, 0x5e, 0x42, 0xdf, 0xfd, 0x9c, 0x52, 0x84,
+	0x50, 0x3f, 0xa5, 0x71, 0x2d, 0xe0, 0xdf, 0x88, 0x35, 0x59, 0xd8, 0x28, 0x1a, 0x2d, 0xe7, 0x84,
+	0x41, 0x80, 0xf9, 0xe3, 0x05, 0x17, 0x89, 0xbf, 0x6d, 0xe9, 0x90, 0x69, 0x81, 0x21, 0x8e, 0x86,
+	0xc7, 0xf2, 0x46, 0x53, 0x7a, 0x4b, 0x81, 0x52, 0xf7, 0x61, 0xd1, 0x11, 0x0c, 0x72, 0x84, 0x4a,
+	0x65, 0x52, 0x9b, 0x63, 0xc4, 0xad, 0x94, 0x89, 0xb6, 0xf8, 0x4b, 0x4d, 0x45, 0x4b, 0x2d, 0x2d,
+	0xaa, 0xcf, 0xfd, 0x14, 0x3a, 0x11, 0xad, 0x89, 0xd2, 0x46, 0x7c, 0x72, 0x4f, 0x0b, 0x9a, 0xe5,
+	0x3d, 0x72, 0x81, 0x4a, 0x1e, 0x7d, 0xb3, 0x71, 0x04, 0x90, 0x2d, 0x51, 0x28, 0xc9, 0xc5, 0x74,
+	0x41, 0x15, 0x5d, 0xab, 0x5e, 0x72, 0xad, 0x2c, 0x2a, 0xe7, 0xbe, 0x8d, 0xaa, 0xfc, 0xa1, 0x0e,
+	0xdb, 0x87, 0x11, 0xb7, 0x13, 0x6e, 0x71, 0x27, 0xbc, 0xe4, 0xd1, 0x0c, 0xf5, 0x55, 0xba, 0xbc,
+	0x10, 0xa6, 0xe7, 0x8e, 0x6e, 0xbe, 0xfb, 0x22, 0xa4, 0xcc, 0xdb, 0xd4, 0x3f, 0x4c, 0x77, 0x58,
+	0xfa, 0x6e, 0xf4, 0xe2, 0x24, 0x57, 0x1a, 0xca, 0xf2, 0x28, 0x07, 0x1a, 0xdf, 0xd6, 0x00, 0x32,
+	0x09, 0xb8, 0x69, 0xec, 0x45, 0x51, 0x18, 0x15, 0x6a, 0xaa, 0x1c, 0x88, 0xee, 0x36, 0x8d, 0xb9,
+	0x4a, 0x9a, 0xf4, 0x1b, 0xad, 0x32, 0xa1, 0xc2, 0x62, 0x46, 0xcf, 0x52, 0x3e, 0x38, 0x0d, 0xd2,
+	0x38, 0xb4, 0x52, 0x4b, 0x87, 0xcc, 0x6d, 0xd8, 0xaa, 0xd2, 0x13, 0x0d, 0xf7, 0xb7, 0x1a, 0xec,
+	0x1c, 0xb8, 0x2e, 0x12, 0x9e, 0xa8, 0xc0, 0xb1, 0x2e, 0xd2, 0xb2, 0xe6, 0x01, 0xb4, 0xb8, 0x40,
+	0xa4, 0xdd, 0xbe, 0x4f, 0x76, 0xbb, 0x6a, 0x4f, 0x5f, 0xd4, 0x5e, 0x6a, 0x9f, 0x71, 0x0a, 0x4d,
+	0x51, 0x6c, 0xf5, 0xa0, 0x95, 0xaf, 0x3c, 0x5b, 0x9a, 0xe6, 0x58, 0xe2, 0xab, 0x87, 0x86, 0xbf,
+	0x31, 0x2c, 0xa3, 0x7e, 0x07, 0xae, 0x1b, 0x89, 0x2c, 0xd7, 0xb6, 0x32, 0x00, 0x4b, 0xa4, 0x39,
+	0x67, 0x40, 0xb5, 0x4e, 0x60, 0x93, 0x92, 0xc5, 0xa7, 0xef, 0x12, 0x1e, 0xc4, 0xf4, 0x24, 0xb2,
+	0xd2, 0x64, 0x34, 0x39, 0x0f, 0xc7, 0xa9, 0xf7, 0x09, 0x0a, 0xeb, 0x55, 0x9e, 0x32, 0xcb, 0x84,
+	0xab, 0x21, 0xe6, 0xbf, 0x6b, 0xb0, 0x5e, 0x12, 0x89, 0x99, 0xca, 0x80, 0x25, 0xcc, 0x22, 0x14,
+	0x27, 0x84, 0xc8, 0x94, 0x66, 0x87, 0x25, 0xa1, 0x9d, 0xfd, 0xef, 0x66, 0xa9, 0xac, 0x20, 0xaa,
+	0x9f, 0xd2, 0xfa, 0x97, 0x8d, 0x18, 0xda, 0xe9, 0x42, 0x1a, 0x91, 0x6a, 0x5a, 0x44, 0x7a, 0x00,
+	0x5d, 0x47, 0xc4, 0x38, 0x0c, 0x03, 0x47, 0xe1, 0x34, 0x70, 0xc9, 0x90, 0x4b, 0x56, 0x09, 0xc7,
+	0x3c, 0xe4, 0xf2, 0xa1, 0x3d, 0xf5, 0x0b, 0x9d, 0x4d, 0x01, 0x35, 0x7f, 0x4d, 0x71, 0x37, 0xf4,
+	0x2f, 0xf9, 0x4b, 0xef, 0x2c, 0xca, 0xf5, 0x58, 0xf3, 0x2c, 0xd8, 0x07, 0xe6, 0xce, 0x02, 0x7b,
+	0xec, 0x39, 0x62, 0xcb, 0xec, 0x04, 0xc3, 0xa9, 0xb8, 0xd1, 0x8a, 0x15, 0xbc, 0x5f, 0x5f, 0xc9,
+	0x56, 0xf7, 0x9b, 0x02, 0xe6, 0x3f, 0x6a, 0x14, 0xc2, 0x0b, 0x27, 0xb8, 0xce, 0xe0, 0x3f, 0xd1,
+	0x65, 0xd6, 0xb5, 0xd2, 0xa1, 0x52, 0x54, 0x5f, 0x1e, 0x47, 0xfb, 0xae, 0xf1, 0x14, 0x5a, 0x12,
+	0xad, 0xb4, 0xb5, 0xca, 0x12, 0x75, 0x2d, 0x4b, 0x60, 0x65, 0x43, 0x46, 0x5f, 0x20, 0xa3, 0x0b,
+	0xc2, 0xfc, 0x63, 0x0d, 0xb6, 0xe8, 0x96, 0x29, 0xad, 0xcd, 0xe2, 0x84, 0x8f, 0x53, 0x13, 0x3e,
+	0x86, 0xe6, 0x44, 0x0b, 0xab, 0xf7, 0x32, 0x97, 0x28, 0x33, 0xf7, 0x55, 0x17, 0x29, 0xb6, 0x18,
+	0xc7, 0xd0, 0x52, 0xfd, 0x1c, 0x96, 0x87, 0x67, 0x9e, 0x2b, 0xbb, 0x17, 0xfa, 0xad, 0x45, 0xd7,
+	0x7a, 0x2e, 0xba, 0x6e, 0xc2, 0xa2, 0x88, 0x50, 0xf2, 0xc2, 0x25, 0x65, 0xfe, 0xa6, 0x0e, 0x1b,
+	0xe5, 0x2f, 0x5f, 0x67, 0xe7, 0x4f, 0xa0, 0xe5, 0xf2, 0x4b, 0xcf, 0x29, 0x58, 0xb9, 0x52, 0x50,
+	0x7f, 0x20, 0x38, 0x2d, 0xb5, 0xc5, 0xf8, 0x5d, 0x0d, 0x5a, 0x12, 0xfc, 0x7f, 0xe8, 0x80, 0x1d,
+	0xbd, 0xe0, 0x10, 0x42, 0x65, 0x99, 0x96, 0xc3, 0x90, 0x47, 0x70, 0x4b, 0x1e, 0x51, 0xa7, 0xe5,
+	0x30, 0xf3, 0x3e, 0xdc, 0x24, 0x0d, 0x30, 0x68, 0xa6, 0x77, 0xb5, 0x0e, 0xcd, 0x09, 0xd2, 0x74,
+	0x57, 0x2b, 0x96, 0x20, 0xcc, 0x2f, 0xe1, 0x03, 0x9d, 0xf5, 0x3a, 0x7b, 0x3d, 0x80, 0xee, 0x34,
+	0x48, 0x6b, 0x42, 0xda, 0x44, 0x86, 0x5b, 0xb1, 0x4a, 0xb8, 0xf9, 0x0b, 0x60, 0x4f, 0x79, 0x82,
+	0x91, 0x8e, 0x7a, 0xcd, 0x6c, 0xb2, 0x21, 0xf4, 0x79, 0x7a, 0xf2, 0x2c, 0x7b, 0x7b, 0x39, 0x2c,
+	0xd3, 0x51, 0xf2, 0xc8, 0xc9, 0x86, 0x8e, 0x99, 0xdf, 0xd6, 0x61, 0x49, 0xc9, 0xbe, 0xf2, 0xc8,
+	0xab, 0x50, 0x0f, 0x63, 0x29, 0xa2, 0x1e, 0x52, 0x2b, 0x76, 0xc1, 0xa3, 0x80, 0xfb, 0xca, 0xf8,
+	0x82, 0x42, 0xd5, 0x46, 0x93, 0xa9, 0x68, 0x81, 0x55, 0x4c, 0x11, 0xb5, 0x4f, 0x09, 0xc7, 0x34,
+	0x28, 0x0e, 0xac, 0x18, 0x45, 0x15, 0x94, 0x07, 0xcb, 0x19, 0x76, 0xb1, 0x22, 0xc3, 0xa2, 0xb2,
+	0x11, 0xd6, 0x89, 0x8a, 0x49, 0xcc, 0x40, 0x72, 0x18, 0x06, 0xf5, 0x61, 0xc4, 0xf9, 0x31, 0x1f,
+	0x87, 0xd1, 0xac, 0xb7, 0x44, 0x57, 0xae, 0x21, 0xe6, 0x47, 0xd0, 0xcd, 0x99, 0x1a, 0xaf, 0xf1,
+	0x2e, 0x34, 0xbc, 0x60, 0x28, 0x1a, 0xb2, 0xce, 0xfe, 0x0a, 0xf9, 0x75, 0xca, 0x41, 0x4b, 0xe6,
+	0x0b, 0xd8, 0x78, 0xca, 0x13, 0x59, 0x57, 0x62, 0x0a, 0xbb, 0x2e, 0x34, 0xca, 0x52, 0x75, 0x90,
+	0xf5, 0x72, 0x29, 0x6d, 0xfe, 0x67, 0x01, 0x3a, 0x9a, 0x28, 0x4c, 0x92, 0x6e, 0x3e, 0x49, 0x4a,
+	0x12, 0x35, 0x76, 0xfc, 0x69, 0x9c, 0xf0, 0x88, 0x3a, 0x7b, 0x75, 0xbd, 0x3a, 0x86, 0xb7, 0x31,
+	0x51, 0x15, 0x6f, 0x3e, 0xc2, 0x97, 0x70, 0xcc, 0x05, 0x8e, 0x9d, 0xd8, 0x7e, 0x38, 0xca, 0xdf,
+	0x5b, 0x01, 0x45, 0x99, 0xe2, 0x3d, 0x3f, 0x77, 0x79, 0x90, 0x78, 0x43, 0x8f, 0x47, 0xf2, 0xe2,
+	0x4a, 0x38, 0x26, 0x01, 0x07, 0xdf, 0xc5, 0x24, 0xf4, 0x82, 0x24, 0x1d, 0xf2, 0x89, 0x0b, 0xac,
+	0x58, 0xa1, 0x5b, 0xe4, 0x6e, 0x98, 0x72, 0xaa, 0x5b, 0xd4, 0x30, 0xb4, 0x5e, 0xe2, 0x8d, 0xb9,
+	0xef, 0x05, 0x9c, 0xee, 0xb0, 0x6d, 0xa5, 0x34, 0x5a, 0x2b, 0xe0, 0xef, 0x92, 0x9f, 0x79, 0x6e,
+	0xaf, 0x2d, 0xac, 0x25, 0x49, 0xf6, 0x03, 0x58, 0x43, 0xc3, 0xd1, 0x2b, 0x8d, 0xa7, 0x63, 0xa5,
+	0x22, 0xec, 0xd6, 0xf6, 0x56, 0xac, 0xaa, 0x25, 0xf6, 0x10, 0x16, 0x87, 0x1e, 0xf7, 0xdd, 0xb8,
+	0xd7, 0xa1, 0x98, 0xb6, 0x23, 0x62, 0x5a, 0x76, 0x37, 0xfd, 0x23, 0x5a, 0x16, 0xe5, 0x8d, 0xe4,
+	0x35, 0x3e, 0x86, 0x8e, 0x06, 0xeb, 0x03, 0xa5, 0xb6, 0x18, 0x28, 0xad, 0xeb, 0x03, 0xa5, 0xb6,
+	0x3e, 0x3b, 0xe2, 0xb0, 0x56, 0xf4, 0xa3, 0xeb, 0x02, 0xc9, 0x3e, 0x95, 0xb2, 0x8a, 0x5f, 0x06,
+	0xdf, 0x6e, 0xf1, 0xa0, 0x96, 0xce, 0x64, 0x4e, 0x61, 0xeb, 0xd8, 0x1b, 0x45, 0x76, 0xc2, 0x0f,
+	0xa6, 0xc9, 0x39, 0x85, 0xe7, 0xac, 0xa9, 0x58, 0x76, 0xf5, 0x09, 0x66, 0x6d, 0xde, 0x04, 0x53,
+	0xe7, 0x7a, 0xbf, 0x12, 0xd8, 0xfc, 0x21, 0x6c, 0x94, 0x3f, 0x8b, 0xfa, 0xf5, 0xa0, 0xe5, 0x9c,
+	0xdb, 0xc1, 0x28, 0xeb, 0x43, 0x25, 0xb9, 0xff, 0xaf, 0x15, 0x68, 0xd2, 0x14, 0x85, 0xbd, 0x86,
+	0xd5, 0x7c, 0xb7, 0xcf, 0xee, 0x5e, 0x3b, 0xe1, 0x30, 0x7a, 0xf3, 0xa6, 0x04, 0xe6, 0x0d, 0xf6,
+	0x0a, 0xba, 0xc5, 0x39, 0x1d, 0xdb, 0x91, 0x1d, 0x54, 0xe5, 0x2c, 0xd9, 0x30, 0xe6, 0xac, 0x0a,
+	0x79, 0x9f, 0x55, 0x8d, 0xab, 0x6e, 0xcf, 0x19, 0x2a, 0x49, 0x89, 0xb7, 0xe6, 0x2d, 0x0b, 0x91,
+	0x1f, 0x43, 0x3b, 0x1d, 0x23, 0xb1, 0x0d, 0xe2, 0x2d, 0x8e, 0x9a, 0x8c, 0xb5, 0x22, 0x2c, 0xb6,
+	0xfe, 0x52, 0xcd, 0xe9, 0x0a, 0x03, 0x43, 0x69, 0xb5, 0xab, 0x06, 0x91, 0xc6, 0x77, 0xae, 0x62,
+	0x11, 0xe2, 0x7f, 0x0e, 0xeb, 0x55, 0x23, 0x45, 0xb6, 0xab, 0x6d, 0xad, 0x1c, 0x46, 0x1a, 0x77,
+	0xae, 0xe0, 0x10, 0xb2, 0xbf, 0x54, 0xd3, 0xcc, 0xac, 0xa9, 0xd3, 0x15, 0xd8, 0xd1, 0x04, 0x94,
+	0x66, 0x96, 0xf2, 0x8e, 0xaa, 0x47, 0x94, 0x37, 0xd8, 0x17, 0xb0, 0x56, 0x31, 0x6e, 0x64, 0x42,
+	0xe1, 0xf9, 0xe3, 0x4b, 0xe3, 0xf6, 0x7c, 0x06, 0x21, 0xf8, 0x13, 0x58, 0xa7, 0x19, 0x45, 0xd1,
+	0xda, 0x37, 0x4b, 0xb3, 0x19, 0xe3, 0x03, 0x1d, 0x12, 0xbb, 0x9f, 0x80, 0x41, 0x74, 0xb5, 0xc2,
+	0xef, 0x27, 0xe3, 0x0b, 0xd8, 0x56, 0x03, 0x0e, 0xe5, 0x99, 0xe9, 0xa4, 0x43, 0xda, 0x6c, 0xce,
+	0xdc, 0x44, 0xda, 0xac, 0x7a, 0x3c, 0x42, 0x36, 0xab, 0x18, 0x18, 0x48, 0x9b, 0xcd, 0x1f, 0x4f,
+	0x48, 0x9b, 0xcd, 0x9d, 0x35, 0x68, 0x0f, 0x46, 0x6b, 0xde, 0x73, 0x0f, 0xa6, 0x3c, 0x50, 0xc8,
+	0x3d, 0x98, 0x52, 0xcf, 0x7f, 0x83, 0xbd, 0x01, 0x56, 0xee, 0x6b, 0xd9, 0x9d, 0xab, 0x1b, 0x7b,
+	0x63, 0x67, 0xee, 0x7a, 0xfa, 0x96, 0x2a, 0x3b, 0x4b, 0xf9, 0x96, 0xae, 0xea, 0x7c, 0xe5, 0x5b,
+	0xba, 0xa2, 0x31, 0xbd, 0xc1, 0x5e, 0xc8, 0xca, 0x31, 0x6b, 0xfe, 0xd8, 0xad, 0xea, 0x96, 0x50,
+	0x88, 0xdc, 0x9e, 0xdb, 0x2f, 0x8a, 0xa8, 0x56, 0xec, 0x6c, 0xb2, 0xdb, 0xaf, 0xea, 0xde, 0xb2,
+	0xdb, 0x2f, 0xb7, 0x43, 0x42, 0x5e, 0xb1, 0x86, 0x97, 0xf2, 0xe6, 0x74, 0x27, 0x52, 0x5e, 0x65,
+	0xe1, 0x4f, 0x0f, 0x05, 0xb2, 0x32, 0x99, 0x6d, 0x66, 0xbc, 0x7a, 0x89, 0x6d, 0xac, 0x97, 0x70,
+	0xb1, 0xfb, 0xc7, 0xd0, 0xd1, 0xca, 0x33, 0xb6, 0x45, 0x6c, 0xe5, 0xda, 0xd8, 0xd8, 0x28, 0x2f,
+	0x08, 0x01, 0xcf, 0x60, 0x35, 0x9f, 0x60, 0x99, 0xa1, 0x58, 0xcb, 0xd5, 0x9b, 0x4c, 0x1f, 0x15,
+	0x19, 0x59, 0x18, 0xa6, 0x98, 0xcc, 0xa4, 0x61, 0xe6, 0xa4, 0x56, 0x69, 0x98, 0xca, 0x0c, 0x68,
+	0xde, 0x38, 0x5b, 0xa4, 0x3f, 0x4d, 0x7f, 0xf4, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x79, 0xf5,
+	0x81, 0xcc, 0x4a, 0x1d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AgentClient is the client API for Agent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AgentClient interface {
+	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
+	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
+	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
+	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
+	DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error)
+	DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error)
+	DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error)
+	ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error)
+	RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
+	RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error)
+	RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error)
+	UpdateConfiguration(ctx context.Context, in *UpdateConfigurationRequest, opts ...grpc.CallOption) (*UpdateConfigurationReply, error)
+	RenameTablespaces(ctx context.Context, in *RenameTablespacesRequest, opts ...grpc.CallOption) (*RenameTablespacesReply, error)
+	CreateRecoveryConf(ctx context.Context, in *CreateRecoveryConfRequest, opts ...grpc.CallOption) (*CreateRecoveryConfReply, error)
+	AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error)
+	CheckExtensions(ctx context.Context, in *CheckExtensionsRequest, opts ...grpc.CallOption) (*CheckExtensionsReply, error)
+	ResolveLibraries(ctx context.Context, in *ResolveLibrariesRequest, opts ...grpc.CallOption) (*ResolveLibrariesReply, error)
+	CheckFilesystems(ctx context.Context, in *CheckFilesystemsRequest, opts ...grpc.CallOption) (*CheckFilesystemsReply, error)
+	CheckPorts(ctx context.Context, in *CheckPortsRequest, opts ...grpc.CallOption) (*CheckPortsReply, error)
+	GetHostInfo(ctx context.Context, in *GetHostInfoRequest, opts ...grpc.CallOption) (*GetHostInfoReply, error)
+	GetControlData(ctx context.Context, in *GetControlDataRequest, opts ...grpc.CallOption) (*GetControlDataReply, error)
+	MigrateAuthFiles(ctx context.Context, in *MigrateAuthFilesRequest, opts ...grpc.CallOption) (*MigrateAuthFilesReply, error)
+}
+
+type agentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAgentClient(cc *grpc.ClientConn) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error) {
+	out := new(CheckDiskSpaceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckDiskSpace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
+	out := new(UpgradePrimariesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpgradePrimaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error) {
+	out := new(RenameDirectoriesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/RenameDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
+	out := new(StopAgentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StopAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteDataDirectories(ctx context.Context, in *DeleteDataDirectoriesRequest, opts ...grpc.CallOption) (*DeleteDataDirectoriesReply, error) {
+	out := new(DeleteDataDirectoriesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteDataDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteStateDirectory(ctx context.Context, in *DeleteStateDirectoryRequest, opts ...grpc.CallOption) (*DeleteStateDirectoryReply, error) {
+	out := new(DeleteStateDirectoryReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteStateDirectory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteTablespaceDirectories(ctx context.Context, in *DeleteTablespaceRequest, opts ...grpc.CallOption) (*DeleteTablespaceReply, error) {
+	out := new(DeleteTablespaceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/DeleteTablespaceDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ArchiveLogDirectory(ctx context.Context, in *ArchiveLogDirectoryRequest, opts ...grpc.CallOption) (*ArchiveLogDirectoryReply, error) {
+	out := new(ArchiveLogDirectoryReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ArchiveLogDirectory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RsyncDataDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error) {
+	out := new(RsyncReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/RsyncDataDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RsyncTablespaceDirectories(ctx context.Context, in *RsyncRequest, opts ...grpc.CallOption) (*RsyncReply, error) {
+	out := new(RsyncReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/RsyncTablespaceDirectories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RestorePrimariesPgControl(ctx context.Context, in *RestorePgControlRequest, opts ...grpc.CallOption) (*RestorePgControlReply, error) {
+	out := new(RestorePgControlReply)
 	err := c.cc.Invoke(ctx, "/idl.Agent/RestorePrimariesPgControl", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -1902,6 +3207,69 @@ func (c *agentClient) AddReplicationEntries(ctx context.Context, in *AddReplicat
 	return out, nil
 }
 
+func (c *agentClient) CheckExtensions(ctx context.Context, in *CheckExtensionsRequest, opts ...grpc.CallOption) (*CheckExtensionsReply, error) {
+	out := new(CheckExtensionsReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckExtensions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ResolveLibraries(ctx context.Context, in *ResolveLibrariesRequest, opts ...grpc.CallOption) (*ResolveLibrariesReply, error) {
+	out := new(ResolveLibrariesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ResolveLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CheckFilesystems(ctx context.Context, in *CheckFilesystemsRequest, opts ...grpc.CallOption) (*CheckFilesystemsReply, error) {
+	out := new(CheckFilesystemsReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckFilesystems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CheckPorts(ctx context.Context, in *CheckPortsRequest, opts ...grpc.CallOption) (*CheckPortsReply, error) {
+	out := new(CheckPortsReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/CheckPorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetHostInfo(ctx context.Context, in *GetHostInfoRequest, opts ...grpc.CallOption) (*GetHostInfoReply, error) {
+	out := new(GetHostInfoReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetHostInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetControlData(ctx context.Context, in *GetControlDataRequest, opts ...grpc.CallOption) (*GetControlDataReply, error) {
+	out := new(GetControlDataReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetControlData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) MigrateAuthFiles(ctx context.Context, in *MigrateAuthFilesRequest, opts ...grpc.CallOption) (*MigrateAuthFilesReply, error) {
+	out := new(MigrateAuthFilesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/MigrateAuthFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error)
@@ -1919,6 +3287,13 @@ type AgentServer interface {
 	RenameTablespaces(context.Context, *RenameTablespacesRequest) (*RenameTablespacesReply, error)
 	CreateRecoveryConf(context.Context, *CreateRecoveryConfRequest) (*CreateRecoveryConfReply, error)
 	AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error)
+	CheckExtensions(context.Context, *CheckExtensionsRequest) (*CheckExtensionsReply, error)
+	ResolveLibraries(context.Context, *ResolveLibrariesRequest) (*ResolveLibrariesReply, error)
+	CheckFilesystems(context.Context, *CheckFilesystemsRequest) (*CheckFilesystemsReply, error)
+	CheckPorts(context.Context, *CheckPortsRequest) (*CheckPortsReply, error)
+	GetHostInfo(context.Context, *GetHostInfoRequest) (*GetHostInfoReply, error)
+	GetControlData(context.Context, *GetControlDataRequest) (*GetControlDataReply, error)
+	MigrateAuthFiles(context.Context, *MigrateAuthFilesRequest) (*MigrateAuthFilesReply, error)
 }
 
 // UnimplementedAgentServer can be embedded to have forward compatible implementations.
@@ -1970,6 +3345,27 @@ func (*UnimplementedAgentServer) CreateRecoveryConf(ctx context.Context, req *Cr
 func (*UnimplementedAgentServer) AddReplicationEntries(ctx context.Context, req *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReplicationEntries not implemented")
 }
+func (*UnimplementedAgentServer) CheckExtensions(ctx context.Context, req *CheckExtensionsRequest) (*CheckExtensionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckExtensions not implemented")
+}
+func (*UnimplementedAgentServer) ResolveLibraries(ctx context.Context, req *ResolveLibrariesRequest) (*ResolveLibrariesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveLibraries not implemented")
+}
+func (*UnimplementedAgentServer) CheckFilesystems(ctx context.Context, req *CheckFilesystemsRequest) (*CheckFilesystemsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckFilesystems not implemented")
+}
+func (*UnimplementedAgentServer) CheckPorts(ctx context.Context, req *CheckPortsRequest) (*CheckPortsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPorts not implemented")
+}
+func (*UnimplementedAgentServer) GetHostInfo(ctx context.Context, req *GetHostInfoRequest) (*GetHostInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostInfo not implemented")
+}
+func (*UnimplementedAgentServer) GetControlData(ctx context.Context, req *GetControlDataRequest) (*GetControlDataReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetControlData not implemented")
+}
+func (*UnimplementedAgentServer) MigrateAuthFiles(ctx context.Context, req *MigrateAuthFilesRequest) (*MigrateAuthFilesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateAuthFiles not implemented")
+}
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
 	s.RegisterService(&_Agent_serviceDesc, srv)
@@ -2245,6 +3641,132 @@ func _Agent_AddReplicationEntries_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_CheckExtensions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckExtensionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CheckExtensions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/CheckExtensions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CheckExtensions(ctx, req.(*CheckExtensionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ResolveLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResolveLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/ResolveLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResolveLibraries(ctx, req.(*ResolveLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CheckFilesystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFilesystemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CheckFilesystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/CheckFilesystems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CheckFilesystems(ctx, req.(*CheckFilesystemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CheckPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CheckPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/CheckPorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CheckPorts(ctx, req.(*CheckPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetHostInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetHostInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetHostInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetHostInfo(ctx, req.(*GetHostInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetControlData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetControlDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetControlData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetControlData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetControlData(ctx, req.(*GetControlDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_MigrateAuthFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateAuthFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).MigrateAuthFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/MigrateAuthFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).MigrateAuthFiles(ctx, req.(*MigrateAuthFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -2309,6 +3831,34 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddReplicationEntries",
 			Handler:    _Agent_AddReplicationEntries_Handler,
 		},
+		{
+			MethodName: "CheckExtensions",
+			Handler:    _Agent_CheckExtensions_Handler,
+		},
+		{
+			MethodName: "ResolveLibraries",
+			Handler:    _Agent_ResolveLibraries_Handler,
+		},
+		{
+			MethodName: "CheckFilesystems",
+			Handler:    _Agent_CheckFilesystems_Handler,
+		},
+		{
+			MethodName: "CheckPorts",
+			Handler:    _Agent_CheckPorts_Handler,
+		},
+		{
+			MethodName: "GetHostInfo",
+			Handler:    _Agent_GetHostInfo_Handler,
+		},
+		{
+			MethodName: "GetControlData",
+			Handler:    _Agent_GetControlData_Handler,
+		},
+		{
+			MethodName: "MigrateAuthFiles",
+			Handler:    _Agent_MigrateAuthFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "hub_to_agent.proto",
@@ -335,6 +335,146 @@ func (mr *MockAgentClientMockRecorder) AddReplicationEntries(ctx, in interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplicationEntries", reflect.TypeOf((*MockAgentClient)(nil).AddReplicationEntries), varargs...)
 }
 
+// CheckExtensions mocks base method
+func (m *MockAgentClient) CheckExtensions(ctx context.Context, in *idl.CheckExtensionsRequest, opts ...grpc.CallOption) (*idl.CheckExtensionsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckExtensions", varargs...)
+	ret0, _ := ret[0].(*idl.CheckExtensionsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckExtensions indicates an expected call of CheckExtensions
+func (mr *MockAgentClientMockRecorder) CheckExtensions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckExtensions", reflect.TypeOf((*MockAgentClient)(nil).CheckExtensions), varargs...)
+}
+
+// ResolveLibraries mocks base method
+func (m *MockAgentClient) ResolveLibraries(ctx context.Context, in *idl.ResolveLibrariesRequest, opts ...grpc.CallOption) (*idl.ResolveLibrariesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResolveLibraries", varargs...)
+	ret0, _ := ret[0].(*idl.ResolveLibrariesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLibraries indicates an expected call of ResolveLibraries
+func (mr *MockAgentClientMockRecorder) ResolveLibraries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLibraries", reflect.TypeOf((*MockAgentClient)(nil).ResolveLibraries), varargs...)
+}
+
+// CheckFilesystems mocks base method
+func (m *MockAgentClient) CheckFilesystems(ctx context.Context, in *idl.CheckFilesystemsRequest, opts ...grpc.CallOption) (*idl.CheckFilesystemsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckFilesystems", varargs...)
+	ret0, _ := ret[0].(*idl.CheckFilesystemsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckFilesystems indicates an expected call of CheckFilesystems
+func (mr *MockAgentClientMockRecorder) CheckFilesystems(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFilesystems", reflect.TypeOf((*MockAgentClient)(nil).CheckFilesystems), varargs...)
+}
+
+// CheckPorts mocks base method
+func (m *MockAgentClient) CheckPorts(ctx context.Context, in *idl.CheckPortsRequest, opts ...grpc.CallOption) (*idl.CheckPortsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPorts", varargs...)
+	ret0, _ := ret[0].(*idl.CheckPortsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPorts indicates an expected call of CheckPorts
+func (mr *MockAgentClientMockRecorder) CheckPorts(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPorts", reflect.TypeOf((*MockAgentClient)(nil).CheckPorts), varargs...)
+}
+
+// GetHostInfo mocks base method
+func (m *MockAgentClient) GetHostInfo(ctx context.Context, in *idl.GetHostInfoRequest, opts ...grpc.CallOption) (*idl.GetHostInfoReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostInfo", varargs...)
+	ret0, _ := ret[0].(*idl.GetHostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostInfo indicates an expected call of GetHostInfo
+func (mr *MockAgentClientMockRecorder) GetHostInfo(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostInfo", reflect.TypeOf((*MockAgentClient)(nil).GetHostInfo), varargs...)
+}
+
+// GetControlData mocks base method
+func (m *MockAgentClient) GetControlData(ctx context.Context, in *idl.GetControlDataRequest, opts ...grpc.CallOption) (*idl.GetControlDataReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetControlData", varargs...)
+	ret0, _ := ret[0].(*idl.GetControlDataReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControlData indicates an expected call of GetControlData
+func (mr *MockAgentClientMockRecorder) GetControlData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlData", reflect.TypeOf((*MockAgentClient)(nil).GetControlData), varargs...)
+}
+
+// MigrateAuthFiles mocks base method
+func (m *MockAgentClient) MigrateAuthFiles(ctx context.Context, in *idl.MigrateAuthFilesRequest, opts ...grpc.CallOption) (*idl.MigrateAuthFilesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MigrateAuthFiles", varargs...)
+	ret0, _ := ret[0].(*idl.MigrateAuthFilesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateAuthFiles indicates an expected call of MigrateAuthFiles
+func (mr *MockAgentClientMockRecorder) MigrateAuthFiles(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateAuthFiles", reflect.TypeOf((*MockAgentClient)(nil).MigrateAuthFiles), varargs...)
+}
+
 // MockAgentServer is a mock of AgentServer interface
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -582,3 +722,108 @@ func (mr *MockAgentServerMockRecorder) AddReplicationEntries(arg0, arg1 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplicationEntries", reflect.TypeOf((*MockAgentServer)(nil).AddReplicationEntries), arg0, arg1)
 }
+
+// CheckExtensions mocks base method
+func (m *MockAgentServer) CheckExtensions(arg0 context.Context, arg1 *idl.CheckExtensionsRequest) (*idl.CheckExtensionsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckExtensions", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckExtensionsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckExtensions indicates an expected call of CheckExtensions
+func (mr *MockAgentServerMockRecorder) CheckExtensions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckExtensions", reflect.TypeOf((*MockAgentServer)(nil).CheckExtensions), arg0, arg1)
+}
+
+// ResolveLibraries mocks base method
+func (m *MockAgentServer) ResolveLibraries(arg0 context.Context, arg1 *idl.ResolveLibrariesRequest) (*idl.ResolveLibrariesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLibraries", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ResolveLibrariesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLibraries indicates an expected call of ResolveLibraries
+func (mr *MockAgentServerMockRecorder) ResolveLibraries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLibraries", reflect.TypeOf((*MockAgentServer)(nil).ResolveLibraries), arg0, arg1)
+}
+
+// CheckFilesystems mocks base method
+func (m *MockAgentServer) CheckFilesystems(arg0 context.Context, arg1 *idl.CheckFilesystemsRequest) (*idl.CheckFilesystemsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckFilesystems", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckFilesystemsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckFilesystems indicates an expected call of CheckFilesystems
+func (mr *MockAgentServerMockRecorder) CheckFilesystems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFilesystems", reflect.TypeOf((*MockAgentServer)(nil).CheckFilesystems), arg0, arg1)
+}
+
+// CheckPorts mocks base method
+func (m *MockAgentServer) CheckPorts(arg0 context.Context, arg1 *idl.CheckPortsRequest) (*idl.CheckPortsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPorts", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckPortsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPorts indicates an expected call of CheckPorts
+func (mr *MockAgentServerMockRecorder) CheckPorts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPorts", reflect.TypeOf((*MockAgentServer)(nil).CheckPorts), arg0, arg1)
+}
+
+// GetHostInfo mocks base method
+func (m *MockAgentServer) GetHostInfo(arg0 context.Context, arg1 *idl.GetHostInfoRequest) (*idl.GetHostInfoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostInfo", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetHostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostInfo indicates an expected call of GetHostInfo
+func (mr *MockAgentServerMockRecorder) GetHostInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostInfo", reflect.TypeOf((*MockAgentServer)(nil).GetHostInfo), arg0, arg1)
+}
+
+// GetControlData mocks base method
+func (m *MockAgentServer) GetControlData(arg0 context.Context, arg1 *idl.GetControlDataRequest) (*idl.GetControlDataReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControlData", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetControlDataReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControlData indicates an expected call of GetControlData
+func (mr *MockAgentServerMockRecorder) GetControlData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlData", reflect.TypeOf((*MockAgentServer)(nil).GetControlData), arg0, arg1)
+}
+
+// MigrateAuthFiles mocks base method
+func (m *MockAgentServer) MigrateAuthFiles(arg0 context.Context, arg1 *idl.MigrateAuthFilesRequest) (*idl.MigrateAuthFilesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateAuthFiles", arg0, arg1)
+	ret0, _ := ret[0].(*idl.MigrateAuthFilesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateAuthFiles indicates an expected call of MigrateAuthFiles
+func (mr *MockAgentServerMockRecorder) MigrateAuthFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateAuthFiles", reflect.TypeOf((*MockAgentServer)(nil).MigrateAuthFiles), arg0, arg1)
+}
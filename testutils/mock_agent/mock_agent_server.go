@@ -160,3 +160,31 @@ func (m *MockAgentServer) CreateRecoveryConf(context context.Context, in *idl.Cr
 func (m *MockAgentServer) AddReplicationEntries(context context.Context, in *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
 	return &idl.AddReplicationEntriesReply{}, nil
 }
+
+func (m *MockAgentServer) CheckExtensions(context context.Context, in *idl.CheckExtensionsRequest) (*idl.CheckExtensionsReply, error) {
+	return &idl.CheckExtensionsReply{}, nil
+}
+
+func (m *MockAgentServer) ResolveLibraries(context context.Context, in *idl.ResolveLibrariesRequest) (*idl.ResolveLibrariesReply, error) {
+	return &idl.ResolveLibrariesReply{}, nil
+}
+
+func (m *MockAgentServer) CheckFilesystems(context context.Context, in *idl.CheckFilesystemsRequest) (*idl.CheckFilesystemsReply, error) {
+	return &idl.CheckFilesystemsReply{}, nil
+}
+
+func (m *MockAgentServer) CheckPorts(context context.Context, in *idl.CheckPortsRequest) (*idl.CheckPortsReply, error) {
+	return &idl.CheckPortsReply{}, nil
+}
+
+func (m *MockAgentServer) GetHostInfo(context context.Context, in *idl.GetHostInfoRequest) (*idl.GetHostInfoReply, error) {
+	return &idl.GetHostInfoReply{}, nil
+}
+
+func (m *MockAgentServer) GetControlData(context context.Context, in *idl.GetControlDataRequest) (*idl.GetControlDataReply, error) {
+	return &idl.GetControlDataReply{}, nil
+}
+
+func (m *MockAgentServer) MigrateAuthFiles(context context.Context, in *idl.MigrateAuthFilesRequest) (*idl.MigrateAuthFilesReply, error) {
+	return &idl.MigrateAuthFilesReply{}, nil
+}
@@ -100,7 +100,8 @@ func DeleteTablespaceDirectories(streams step.OutStreams, dirs []string) error {
 		// If the parent directory is not empty it contains files for the 5X
 		// tablespace. For example, the oid for template1 is 1 which can conflict
 		// with the 6X tablespace directory which uses segment dbid's which is
-		// also 1. Thus, we do not want to delete the directory.
+		// also 1. When upgrading from 6X it contains the GPDB_6 directory of
+		// the source cluster. Thus, we do not want to delete the directory.
 		if len(entries) > 0 {
 			return nil
 		}
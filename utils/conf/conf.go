@@ -0,0 +1,324 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conf edits postgresql.conf style configuration files such as
+// postgresql.conf, recovery.conf, internal.auto.conf, and gpperfmon.conf.
+// Comments, blank lines, and the formatting of untouched settings are
+// preserved.
+package conf
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/renameio"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+var ErrSettingNotFound = errors.New("setting not found")
+var ErrValueNotFound = errors.New("value not found")
+
+// BackupSuffix is appended to the path of the backup written before a file is
+// replaced.
+const BackupSuffix = ".bak"
+
+// settingPattern matches "name = value # comment" where the equals sign is
+// optional and the value may be single quoted.
+var settingPattern = regexp.MustCompile(`^(\s*)([A-Za-z_][A-Za-z0-9_.\-]*)(\s*=\s*|\s+)('(?:[^'\\]|\\.|'')*'|[^\s#']*)(.*)$`)
+
+// simpleValuePattern matches values that the server accepts without quotes.
+var simpleValuePattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+type line struct {
+	text string
+
+	// the following are only set for settings
+	setting bool
+	changed bool
+	indent  string
+	name    string
+	sep     string
+	value   string
+	quoted  bool
+	rest    string
+}
+
+func (l *line) String() string {
+	if !l.changed {
+		return l.text
+	}
+
+	// Keep the quoting of the original line unless the new value cannot be
+	// written without quotes.
+	value := l.value
+	if l.quoted || value == "" || strings.ContainsAny(value, " \t#'\\") {
+		value = quote(value)
+	}
+
+	return l.indent + l.name + l.sep + value + l.rest
+}
+
+// File is a parsed configuration file.
+type File struct {
+	path  string
+	lines []*line
+}
+
+// Read parses the configuration file at path.
+func Read(path string) (*File, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(path, string(contents)), nil
+}
+
+// Parse parses contents as the configuration file at path.
+func Parse(path string, contents string) *File {
+	f := &File{path: path}
+	for _, text := range strings.SplitAfter(contents, "\n") {
+		if text == "" {
+			continue
+		}
+
+		f.lines = append(f.lines, parseLine(text))
+	}
+
+	return f
+}
+
+func parseLine(text string) *line {
+	l := &line{text: text}
+
+	matches := settingPattern.FindStringSubmatch(strings.TrimSuffix(text, "\n"))
+	if matches == nil {
+		return l
+	}
+
+	l.setting = true
+	l.indent, l.name, l.sep, l.value, l.rest = matches[1], matches[2], matches[3], matches[4], matches[5]
+	if strings.HasSuffix(text, "\n") {
+		l.rest += "\n"
+	}
+
+	if strings.HasPrefix(l.value, "'") {
+		l.quoted = true
+		l.value = unquote(l.value)
+	}
+
+	return l
+}
+
+// FormatValue returns value as it is written in a configuration file, quoting
+// it when it is not a simple identifier or number.
+func FormatValue(value string) string {
+	if simpleValuePattern.MatchString(value) {
+		return value
+	}
+
+	return quote(value)
+}
+
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func unquote(value string) string {
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "'"), "'")
+	value = strings.ReplaceAll(value, "''", "'")
+	return strings.ReplaceAll(value, `\'`, "'")
+}
+
+// Path returns the location of the file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Get returns the value of the setting. As with the server, the last
+// occurrence of a setting takes effect.
+func (f *File) Get(name string) (string, bool) {
+	var value string
+	var found bool
+	for _, l := range f.lines {
+		if l.setting && l.name == name {
+			value, found = l.value, true
+		}
+	}
+
+	return value, found
+}
+
+// Set replaces the value of every occurrence of the setting, or appends the
+// setting when it is not present.
+func (f *File) Set(name string, value string) {
+	found := false
+	for _, l := range f.lines {
+		if l.setting && l.name == name {
+			l.value = value
+			l.changed = true
+			found = true
+		}
+	}
+
+	if found {
+		return
+	}
+
+	if len(f.lines) > 0 {
+		last := f.lines[len(f.lines)-1]
+		if !strings.HasSuffix(last.String(), "\n") {
+			last.text += "\n"
+			last.rest += "\n"
+		}
+	}
+
+	f.lines = append(f.lines, &line{
+		setting: true,
+		changed: true,
+		name:    name,
+		sep:     " = ",
+		value:   value,
+		quoted:  !simpleValuePattern.MatchString(value),
+		rest:    "\n",
+	})
+}
+
+// Replace sets every occurrence of the setting with the value oldValue to
+// newValue. An empty oldValue matches any value. An error is returned when
+// the setting is not present or none of its occurrences had oldValue, so that
+// callers notice when an expected update did not happen. An occurrence that
+// already has newValue is not an error, so that a partially applied update can
+// be run again.
+func (f *File) Replace(name string, oldValue string, newValue string) error {
+	return f.replace(name, oldValue, func(value string) (string, bool) {
+		if oldValue != "" && value != oldValue && value != newValue {
+			return value, false
+		}
+
+		return newValue, true
+	})
+}
+
+// ReplaceConnInfo updates a key of a libpq connection string setting such as
+// primary_conninfo, leaving the remaining keys untouched. An empty oldValue
+// matches any value, and as with Replace a key that already has newValue is
+// not an error.
+func (f *File) ReplaceConnInfo(name string, key string, oldValue string, newValue string) error {
+	return f.replace(name, fmt.Sprintf("%s=%s", key, oldValue), func(value string) (string, bool) {
+		return replaceConnInfoKey(value, key, oldValue, newValue)
+	})
+}
+
+func (f *File) replace(name string, expected string, update func(string) (string, bool)) error {
+	var found []string
+	replaced := false
+	for _, l := range f.lines {
+		if !l.setting || l.name != name {
+			continue
+		}
+
+		found = append(found, l.value)
+
+		value, ok := update(l.value)
+		if !ok {
+			continue
+		}
+
+		replaced = true
+		if value != l.value {
+			l.value = value
+			l.changed = true
+		}
+	}
+
+	if len(found) == 0 {
+		return xerrors.Errorf("%q in %q: %w", name, f.path, ErrSettingNotFound)
+	}
+
+	if !replaced {
+		return xerrors.Errorf("%q in %q has values %q but expected %q: %w", name, f.path, found, expected, ErrValueNotFound)
+	}
+
+	return nil
+}
+
+// replaceConnInfoKey replaces the value of key in a connection string of
+// whitespace separated key=value pairs.
+func replaceConnInfoKey(connInfo string, key string, oldValue string, newValue string) (string, bool) {
+	fields := strings.Fields(connInfo)
+
+	replaced := false
+	for i, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] != key {
+			continue
+		}
+
+		if oldValue != "" && parts[1] != oldValue && parts[1] != newValue {
+			continue
+		}
+
+		fields[i] = key + "=" + newValue
+		replaced = true
+	}
+
+	if !replaced {
+		return connInfo, false
+	}
+
+	return strings.Join(fields, " "), true
+}
+
+// String returns the contents of the file.
+func (f *File) String() string {
+	var b strings.Builder
+	for _, l := range f.lines {
+		b.WriteString(l.String())
+	}
+
+	return b.String()
+}
+
+// Write copies the original file to a backup with BackupSuffix and then
+// atomically replaces it, preserving its permissions.
+func (f *File) Write() (err error) {
+	info, err := utils.System.Stat(f.path)
+	if err != nil {
+		return err
+	}
+
+	original, err := utils.System.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.System.WriteFile(f.path+BackupSuffix, original, info.Mode().Perm()); err != nil {
+		return xerrors.Errorf("backing up %q: %w", f.path, err)
+	}
+
+	file, err := renameio.TempFile("", f.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := file.Cleanup(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if err := file.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if _, err := file.WriteString(f.String()); err != nil {
+		return err
+	}
+
+	return file.CloseAtomicallyReplace()
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package conf_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils/conf"
+)
+
+const postgresqlConf = `# comment
+port=5000 # comment
+	listen_addresses = '*'
+#port = 7000
+search_path = 'it''s'
+max_connections 100
+`
+
+func TestGet(t *testing.T) {
+	file := conf.Parse("postgresql.conf", postgresqlConf+"port = 5001\n")
+
+	cases := []struct {
+		name  string
+		value string
+		found bool
+	}{
+		{"port", "5001", true},
+		{"listen_addresses", "*", true},
+		{"search_path", "it's", true},
+		{"max_connections", "100", true},
+		{"shared_buffers", "", false},
+	}
+
+	for _, c := range cases {
+		value, found := file.Get(c.name)
+		if value != c.value || found != c.found {
+			t.Errorf("Get(%q) returned %q, %t want %q, %t", c.name, value, found, c.value, c.found)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	t.Run("replaces the value and preserves formatting", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", postgresqlConf)
+
+		if err := file.Replace("port", "5000", "6000"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if err := file.Replace("listen_addresses", "*", "mdw, sdw1"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `# comment
+port=6000 # comment
+	listen_addresses = 'mdw, sdw1'
+#port = 7000
+search_path = 'it''s'
+max_connections 100
+`
+		if file.String() != expected {
+			t.Errorf("got contents %q want %q", file.String(), expected)
+		}
+	})
+
+	t.Run("matches any value when the old value is empty", func(t *testing.T) {
+		file := conf.Parse("gpperfmon.conf", "log_location = /some/directory\n")
+
+		if err := file.Replace("log_location", "", "/other/directory"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if file.String() != "log_location = /other/directory\n" {
+			t.Errorf("got contents %q", file.String())
+		}
+	})
+
+	t.Run("succeeds without changes when the setting already has the new value", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", postgresqlConf)
+
+		if err := file.Replace("port", "6000", "5000"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if file.String() != postgresqlConf {
+			t.Errorf("got contents %q want them unchanged", file.String())
+		}
+	})
+
+	t.Run("errors when the setting is only commented out", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", "#port = 5000\n")
+
+		err := file.Replace("port", "5000", "6000")
+		if !errors.Is(err, conf.ErrSettingNotFound) {
+			t.Errorf("got error %#v want %#v", err, conf.ErrSettingNotFound)
+		}
+	})
+
+	t.Run("errors when the old value does not match", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", postgresqlConf)
+
+		err := file.Replace("port", "50000", "6000")
+		if !errors.Is(err, conf.ErrValueNotFound) {
+			t.Errorf("got error %#v want %#v", err, conf.ErrValueNotFound)
+		}
+
+		if file.String() != postgresqlConf {
+			t.Errorf("got contents %q want them unchanged", file.String())
+		}
+	})
+}
+
+func TestReplaceConnInfo(t *testing.T) {
+	recoveryConf := `standby_mode = 'on'
+primary_conninfo = 'user=gpadmin host=sdw1 port=5000 application_name=gp_walreceiver'
+`
+
+	t.Run("replaces the key", func(t *testing.T) {
+		file := conf.Parse("recovery.conf", recoveryConf)
+
+		if err := file.ReplaceConnInfo("primary_conninfo", "port", "5000", "6000"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		expected := `standby_mode = 'on'
+primary_conninfo = 'user=gpadmin host=sdw1 port=6000 application_name=gp_walreceiver'
+`
+		if file.String() != expected {
+			t.Errorf("got contents %q want %q", file.String(), expected)
+		}
+	})
+
+	t.Run("succeeds without changes when the key already has the new value", func(t *testing.T) {
+		file := conf.Parse("recovery.conf", recoveryConf)
+
+		if err := file.ReplaceConnInfo("primary_conninfo", "port", "6000", "5000"); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if file.String() != recoveryConf {
+			t.Errorf("got contents %q want them unchanged", file.String())
+		}
+	})
+
+	t.Run("errors when the key does not have the old value", func(t *testing.T) {
+		file := conf.Parse("recovery.conf", recoveryConf)
+
+		err := file.ReplaceConnInfo("primary_conninfo", "port", "7000", "6000")
+		if !errors.Is(err, conf.ErrValueNotFound) {
+			t.Errorf("got error %#v want %#v", err, conf.ErrValueNotFound)
+		}
+	})
+}
+
+func TestSet(t *testing.T) {
+	t.Run("replaces an existing setting", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", "port=5000\n")
+		file.Set("port", "6000")
+
+		if file.String() != "port=6000\n" {
+			t.Errorf("got contents %q", file.String())
+		}
+	})
+
+	t.Run("appends a missing setting", func(t *testing.T) {
+		file := conf.Parse("postgresql.conf", "port=5000")
+		file.Set("log_directory", "/data/logs")
+		file.Set("max_connections", "100")
+
+		expected := "port=5000\nlog_directory = '/data/logs'\nmax_connections = 100\n"
+		if file.String() != expected {
+			t.Errorf("got contents %q want %q", file.String(), expected)
+		}
+	})
+}
+
+func TestWrite(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, "postgresql.conf")
+	testutils.MustWriteToFile(t, path, "port=5000\n")
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := conf.Read(path)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if err := file.Replace("port", "5000", "6000"); err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if err := file.Write(); err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if contents := testutils.MustReadFile(t, path); contents != "port=6000\n" {
+		t.Errorf("got contents %q want %q", contents, "port=6000\n")
+	}
+
+	if backup := testutils.MustReadFile(t, path+conf.BackupSuffix); backup != "port=5000\n" {
+		t.Errorf("got backup %q want %q", backup, "port=5000\n")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("got permissions %o want %o", info.Mode().Perm(), 0640)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{"100", "100"},
+		{"128MB", "128MB"},
+		{"on", "on"},
+		{"", "''"},
+		{"/data/logs", "'/data/logs'"},
+		{"$user, public", "'$user, public'"},
+		{"it's", "'it''s'"},
+	}
+
+	for _, c := range cases {
+		if got := conf.FormatValue(c.value); got != c.expected {
+			t.Errorf("FormatValue(%q) returned %q want %q", c.value, got, c.expected)
+		}
+	}
+}
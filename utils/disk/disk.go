@@ -5,6 +5,8 @@ package disk
 
 import (
 	"os"
+	"path/filepath"
+	"sort"
 
 	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
@@ -21,6 +23,13 @@ type Disk interface {
 	Filesystems() (sigar.FileSystemList, error)
 	Usage(string) (sigar.FileSystemUsage, error)
 	Stat(string) (*unix.Stat_t, error)
+	DirUsage(string) (DirUsage, error)
+}
+
+// DirUsage is the space and number of inodes used by a directory tree.
+type DirUsage struct {
+	Size  uint64 // in kilobytes
+	Files uint64
 }
 
 type FilesystemHost struct {
@@ -50,9 +59,11 @@ func (f FileSystemDiskUsage) Swap(i, j int) {
 type CheckUsageType func(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ...string) (FileSystemDiskUsage, error)
 
 // CheckUsage uses the given Disk to look up filesystem usage for each path, and
-// compares the available space to the required disk ratio. Any filesystems that
-// don't have enough space will be given an entry in the returned SpaceFailures
-// map. Note that this is one entry per filesystem, not one entry per path.
+// compares the available space and inodes to the required disk ratio. Any
+// filesystems that don't have enough space or inodes will be given an entry in
+// the returned SpaceFailures map. Note that this is one entry per filesystem,
+// not one entry per path. Filesystems that do not report an inode total are
+// not checked for inodes.
 //
 // This function ignores space that has been reserved for the superuser (i.e.
 // the difference between "free" and "avail" in statfs(2)). It does not consider
@@ -67,26 +78,9 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 
 	failures := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
 
-	// Find the device ID for every filesystem. We'll use these to map data
-	// directories to filesystems later.
-	fs, err := d.Filesystems()
+	fsByID, err := filesystemsByID(d, hostname)
 	if err != nil {
-		return nil, xerrors.Errorf("enumerating filesystems: %w", err)
-	}
-
-	fsByID := make(map[uint64]string)
-	for _, f := range fs.List {
-		stat, err := d.Stat(f.DirName)
-		if os.IsPermission(err) {
-			gplog.Warn("Ignoring filesystem %s on host %s when checking disk space. Unable to stat filesystem due to %v.", f.DirName, hostname, err)
-			continue
-		}
-
-		if err != nil {
-			return nil, xerrors.Errorf("stat'ing %s: %w", f.DirName, err)
-		}
-
-		fsByID[uint64(stat.Dev)] = f.DirName
+		return nil, err
 	}
 
 	for _, path := range paths {
@@ -98,11 +92,12 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 		// Exclude superuser-reserved space.
 		total := usage.Used + usage.Avail
 		required := uint64(diskFreeRatio * float64(total))
+		requiredInodes := uint64(diskFreeRatio * float64(usage.Files))
 
-		gplog.Debug("%s: %d avail of %d required (%d used, %d total)",
-			path, usage.Avail, required, usage.Used, usage.Total)
+		gplog.Debug("%s: %d avail of %d required (%d used, %d total), %d inodes avail of %d required",
+			path, usage.Avail, required, usage.Used, usage.Total, usage.FreeFiles, requiredInodes)
 
-		if usage.Avail < required {
+		if usage.Avail < required || usage.FreeFiles < requiredInodes {
 			// Get the filesystem that this path belongs to.
 			stat, err := d.Stat(path)
 			if err != nil {
@@ -117,10 +112,12 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 			}
 
 			failures[fs] = &idl.CheckDiskSpaceReply_DiskUsage{
-				Fs:        fs,
-				Host:      hostname,
-				Required:  required,
-				Available: usage.Avail,
+				Fs:              fs,
+				Host:            hostname,
+				Required:        required,
+				Available:       usage.Avail,
+				RequiredInodes:  requiredInodes,
+				AvailableInodes: usage.FreeFiles,
 			}
 		}
 	}
@@ -136,6 +133,162 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 	return usage, nil
 }
 
+type EstimateUsageType func(streams step.OutStreams, d Disk, requirements ...*idl.CheckSegmentDiskSpaceRequest_Requirement) (FileSystemDiskUsage, error)
+
+// EstimateUsage sums the space and inodes needed by each requirement per
+// filesystem, and compares them to the space and inodes available on that
+// filesystem. Directory sizes and file counts are measured using the given
+// Disk. Any filesystems that don't have enough space or inodes will be given an
+// entry in the returned usage. Like CheckUsage, space reserved for the
+// superuser is not considered available. Filesystems that do not report an
+// inode total, such as those that allocate inodes dynamically, are not checked
+// for inodes.
+func EstimateUsage(streams step.OutStreams, d Disk, requirements ...*idl.CheckSegmentDiskSpaceRequest_Requirement) (FileSystemDiskUsage, error) {
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		return nil, xerrors.Errorf("determining hostname: %w", err)
+	}
+
+	fsByID, err := filesystemsByID(d, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
+	available := make(map[string]sigar.FileSystemUsage)
+	for _, req := range requirements {
+		size := req.GetSize()
+		files := req.GetFiles()
+		if req.GetSizeOf() != "" {
+			dirUsage, err := d.DirUsage(req.GetSizeOf())
+			if err != nil {
+				return nil, xerrors.Errorf("getting size of %s: %w", req.GetSizeOf(), err)
+			}
+
+			size += uint64(req.GetRatio() * float64(dirUsage.Size))
+			files += uint64(req.GetRatio() * float64(dirUsage.Files))
+		}
+
+		// The path may not exist yet, such as the state directory on a
+		// segment host, so use the closest existing parent directory.
+		path, stat, err := statExisting(d, req.GetPath())
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", req.GetPath(), err)
+		}
+
+		fs, ok := fsByID[uint64(stat.Dev)]
+		if !ok {
+			fs = path
+		}
+
+		if _, ok := available[fs]; !ok {
+			usage, err := d.Usage(path)
+			if err != nil {
+				return nil, xerrors.Errorf("getting fs usage for %s: %w", path, err)
+			}
+
+			available[fs] = usage
+			required[fs] = &idl.CheckDiskSpaceReply_DiskUsage{
+				Fs:              fs,
+				Host:            hostname,
+				Available:       usage.Avail,
+				AvailableInodes: usage.FreeFiles,
+			}
+		}
+
+		required[fs].Required += size
+		if available[fs].Files > 0 {
+			required[fs].RequiredInodes += files
+		}
+	}
+
+	var usage FileSystemDiskUsage
+	for fs, req := range required {
+		gplog.Debug("%s: %d avail of %d required, %d inodes avail of %d required",
+			fs, req.GetAvailable(), req.GetRequired(), req.GetAvailableInodes(), req.GetRequiredInodes())
+
+		if req.GetAvailable() < req.GetRequired() || req.GetAvailableInodes() < req.GetRequiredInodes() {
+			usage = append(usage, req)
+		}
+	}
+
+	sort.Sort(usage)
+	return usage, nil
+}
+
+// filesystemsByID finds the device ID for every filesystem. These are used to
+// map data directories to filesystems.
+func filesystemsByID(d Disk, hostname string) (map[uint64]string, error) {
+	fs, err := d.Filesystems()
+	if err != nil {
+		return nil, xerrors.Errorf("enumerating filesystems: %w", err)
+	}
+
+	fsByID := make(map[uint64]string)
+	for _, f := range fs.List {
+		stat, err := d.Stat(f.DirName)
+		if os.IsPermission(err) {
+			gplog.Warn("Ignoring filesystem %s on host %s when checking disk space. Unable to stat filesystem due to %v.", f.DirName, hostname, err)
+			continue
+		}
+
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", f.DirName, err)
+		}
+
+		fsByID[uint64(stat.Dev)] = f.DirName
+	}
+
+	return fsByID, nil
+}
+
+// statExisting stats path, or its closest existing parent when path does not
+// exist, and returns the path that was stat'ed.
+func statExisting(d Disk, path string) (string, *unix.Stat_t, error) {
+	for {
+		stat, err := d.Stat(path)
+		if err == nil {
+			return path, stat, nil
+		}
+
+		parent := filepath.Dir(path)
+		if !os.IsNotExist(err) || parent == path {
+			return "", nil, err
+		}
+
+		path = parent
+	}
+}
+
+// CheckFilesystems returns the device IDs of each source directory and of the
+// filesystem where each target directory will be created. Since the target
+// may not exist yet its closest existing parent is used. Hard links, as used
+// by link mode, require both device IDs to be the same.
+func CheckFilesystems(d Disk, pairs ...*idl.CheckFilesystemsRequest_DirPair) ([]*idl.CheckFilesystemsReply_Devices, error) {
+	var devices []*idl.CheckFilesystemsReply_Devices
+	for _, pair := range pairs {
+		sourceStat, err := d.Stat(pair.GetSource())
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", pair.GetSource(), err)
+		}
+
+		_, targetStat, err := statExisting(d, filepath.Dir(filepath.Clean(pair.GetTarget())))
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing parent of %s: %w", pair.GetTarget(), err)
+		}
+
+		devices = append(devices, &idl.CheckFilesystemsReply_Devices{
+			Dbid:         pair.GetDbid(),
+			Source:       pair.GetSource(),
+			Target:       pair.GetTarget(),
+			SourceDevice: uint64(sourceStat.Dev),
+			TargetDevice: uint64(targetStat.Dev),
+		})
+	}
+
+	return devices, nil
+}
+
 // Local is a standard implementation of the Disk interface that uses gosigar
 // and unix.Stat to obtain statistics for the local machine.
 var Local = local{}
@@ -159,3 +312,33 @@ func (_ local) Stat(path string) (*unix.Stat_t, error) {
 	err := unix.Stat(path, stat)
 	return stat, err
 }
+
+// DirUsage returns the total size in kilobytes of the regular files under
+// path, and the number of files, directories, and links which each use an
+// inode. Symlinks are not followed. Files removed during the walk, such as
+// WAL segments recycled by a running cluster, are skipped.
+func (_ local) DirUsage(path string) (DirUsage, error) {
+	var size int64
+	var files uint64
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file != path {
+				return nil
+			}
+
+			return err
+		}
+
+		files++
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return DirUsage{}, err
+	}
+
+	return DirUsage{Size: uint64((size + 1023) / 1024), Files: files}, nil
+}
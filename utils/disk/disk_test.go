@@ -6,6 +6,7 @@ package disk_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils/disk"
 )
@@ -199,6 +201,39 @@ func TestCheckUsage(t *testing.T) {
 		}
 	})
 
+	t.Run("returns failures with inadequate inodes", func(t *testing.T) {
+		d.usage = func(path string) (sigar.FileSystemUsage, error) {
+			u := sigar.FileSystemUsage{Total: size, Files: 1000}
+
+			// Plenty of space is available but only 10% of the inodes.
+			u.Avail = scale(u.Total, 0.75)
+			u.Free = u.Avail
+			u.Used = u.Total - u.Free
+			u.FreeFiles = 100
+
+			return u, nil
+		}
+
+		actual, err := disk.CheckUsage(step.DevNullStream, d, 0.6, "/path")
+		if err != nil {
+			t.Errorf("returned error %#v", err)
+		}
+
+		expected := disk.FileSystemDiskUsage{
+			&idl.CheckDiskSpaceReply_DiskUsage{
+				Fs:              "/",
+				Host:            host,
+				Required:        scale(size, 0.6),
+				Available:       scale(size, 0.75),
+				RequiredInodes:  600,
+				AvailableInodes: 100,
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("returned %v want %v", actual, expected)
+		}
+	})
+
 	// regression test to catch float representation errors
 	t.Run("does floating point math correctly", func(t *testing.T) {
 		d := testDisk{
@@ -347,6 +382,158 @@ func TestCheckUsage(t *testing.T) {
 	})
 }
 
+func TestEstimateUsage(t *testing.T) {
+	testlog.SetupLogger()
+
+	host := "localhost"
+	utils.System.Hostname = func() (string, error) {
+		return host, nil
+	}
+	defer func() {
+		utils.System.Hostname = os.Hostname
+	}()
+
+	// This test disk has two filesystems with 1000 KB and 100 inodes
+	// available each:
+	//  - /, holding the state directory
+	//  - /data, holding the data directories
+	d := testDisk{
+		err: errors.New("should never happen"),
+
+		filesystems: func() (sigar.FileSystemList, error) {
+			return sigar.FileSystemList{List: []sigar.FileSystem{
+				{DirName: "/"},
+				{DirName: "/data"},
+			}}, nil
+		},
+
+		usage: func(path string) (sigar.FileSystemUsage, error) {
+			return sigar.FileSystemUsage{Total: 4000, Avail: 1000, Free: 1000, Used: 3000, Files: 400, FreeFiles: 100}, nil
+		},
+
+		stat: func(path string) (*unix.Stat_t, error) {
+			stat := new(unix.Stat_t)
+			switch {
+			case path == "/home/gpadmin/.gpupgrade":
+				return nil, os.ErrNotExist
+			case strings.HasPrefix(path, "/data"):
+				stat.Dev = 2
+			default:
+				stat.Dev = 1
+			}
+
+			return stat, nil
+		},
+
+		dirUsage: func(path string) (disk.DirUsage, error) {
+			return disk.DirUsage{Size: 400, Files: 40}, nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		requirements []*idl.CheckSegmentDiskSpaceRequest_Requirement
+		expected     disk.FileSystemDiskUsage
+	}{
+		{
+			name: "returns no failures with adequate space",
+			requirements: []*idl.CheckSegmentDiskSpaceRequest_Requirement{
+				{Path: "/data/seg1", SizeOf: "/data/seg1", Ratio: 1},
+				{Path: "/home/gpadmin/.gpupgrade", Size: 900},
+			},
+			expected: nil,
+		},
+		{
+			name: "sums requirements per filesystem",
+			requirements: []*idl.CheckSegmentDiskSpaceRequest_Requirement{
+				{Path: "/data/seg1", SizeOf: "/data/seg1", Ratio: 1},
+				{Path: "/data/seg2", SizeOf: "/data/seg2", Ratio: 1},
+				{Path: "/data/seg3", SizeOf: "/data/seg3", Ratio: 0.75},
+				{Path: "/home/gpadmin/.gpupgrade", Size: 600},
+				{Path: "/home/gpadmin/gpAdminLogs", Size: 500},
+			},
+			expected: disk.FileSystemDiskUsage{
+				{Fs: "/", Host: host, Required: 1100, Available: 1000, AvailableInodes: 100},
+				{Fs: "/data", Host: host, Required: 1100, Available: 1000, RequiredInodes: 110, AvailableInodes: 100},
+			},
+		},
+		{
+			name: "returns failures with inadequate inodes",
+			requirements: []*idl.CheckSegmentDiskSpaceRequest_Requirement{
+				{Path: "/data/seg1", SizeOf: "/data/seg1", Ratio: 1},
+				{Path: "/home/gpadmin/.gpupgrade", Files: 101},
+			},
+			expected: disk.FileSystemDiskUsage{
+				{Fs: "/", Host: host, Required: 0, Available: 1000, RequiredInodes: 101, AvailableInodes: 100},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := disk.EstimateUsage(step.DevNullStream, d, c.requirements...)
+			if err != nil {
+				t.Fatalf("unexpected error %+v", err)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("returned %v want %v", actual, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when measuring a directory fails", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		failingDisk := d
+		failingDisk.dirUsage = func(path string) (disk.DirUsage, error) {
+			return disk.DirUsage{}, expected
+		}
+
+		_, err := disk.EstimateUsage(step.DevNullStream, failingDisk, &idl.CheckSegmentDiskSpaceRequest_Requirement{
+			Path: "/data/seg1", SizeOf: "/data/seg1", Ratio: 1,
+		})
+		if !errors.Is(err, expected) {
+			t.Errorf("returned error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestCheckFilesystems(t *testing.T) {
+	d := testDisk{
+		err: errors.New("should never happen"),
+
+		stat: func(path string) (*unix.Stat_t, error) {
+			stat := new(unix.Stat_t)
+			switch {
+			case path == "/data/seg.AAAAAAAAAAA.1":
+				return nil, os.ErrNotExist
+			case strings.HasPrefix(path, "/bind"):
+				stat.Dev = 2
+			default:
+				stat.Dev = 1
+			}
+
+			return stat, nil
+		},
+	}
+
+	devices, err := disk.CheckFilesystems(d,
+		&idl.CheckFilesystemsRequest_DirPair{Dbid: 2, Source: "/data/seg1", Target: "/data/seg.AAAAAAAAAAA.1"},
+		&idl.CheckFilesystemsRequest_DirPair{Dbid: 3, Source: "/data/seg2", Target: "/bind/seg.AAAAAAAAAAA.2"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	expected := []*idl.CheckFilesystemsReply_Devices{
+		{Dbid: 2, Source: "/data/seg1", Target: "/data/seg.AAAAAAAAAAA.1", SourceDevice: 1, TargetDevice: 1},
+		{Dbid: 3, Source: "/data/seg2", Target: "/bind/seg.AAAAAAAAAAA.2", SourceDevice: 1, TargetDevice: 2},
+	}
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("returned %v want %v", devices, expected)
+	}
+}
+
 func TestLocal(t *testing.T) {
 	// disk.Local is a passthrough to more complicated implementations. Rather
 	// than duplicate the tests for those implementations, just verify simple
@@ -377,6 +564,28 @@ func TestLocal(t *testing.T) {
 	if (stat.Mode & unix.S_IFDIR) == 0 {
 		t.Errorf("Local.Stat(%q) did not stat a directory: %+v", dir, stat)
 	}
+
+	sizeDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, sizeDir)
+
+	testutils.MustWriteToFile(t, filepath.Join(sizeDir, "file"), strings.Repeat("a", 2048))
+	testutils.MustCreateDir(t, filepath.Join(sizeDir, "subdir"))
+	testutils.MustWriteToFile(t, filepath.Join(sizeDir, "subdir", "file"), "a")
+
+	dirUsage, err := disk.Local.DirUsage(sizeDir)
+	if err != nil {
+		t.Errorf("Local.DirUsage(%q) returned error %#v", sizeDir, err)
+	}
+	expected := disk.DirUsage{Size: 3, Files: 4}
+	if dirUsage != expected {
+		t.Errorf("Local.DirUsage(%q) returned %+v want %+v", sizeDir, dirUsage, expected)
+	}
+
+	missingDir := filepath.Join(sizeDir, "missing")
+	_, err = disk.Local.DirUsage(missingDir)
+	if !os.IsNotExist(err) {
+		t.Errorf("Local.DirUsage(%q) returned error %#v want not exist", missingDir, err)
+	}
 }
 
 // testDisk is a stub implementation of disk.Disk.
@@ -386,6 +595,7 @@ type testDisk struct {
 	filesystems func() (sigar.FileSystemList, error)
 	usage       func(string) (sigar.FileSystemUsage, error)
 	stat        func(string) (*unix.Stat_t, error)
+	dirUsage    func(string) (disk.DirUsage, error)
 }
 
 func (t testDisk) Filesystems() (sigar.FileSystemList, error) {
@@ -409,6 +619,13 @@ func (t testDisk) Stat(path string) (*unix.Stat_t, error) {
 	return t.stat(path)
 }
 
+func (t testDisk) DirUsage(path string) (disk.DirUsage, error) {
+	if t.dirUsage == nil {
+		return disk.DirUsage{}, t.err
+	}
+	return t.dirUsage(path)
+}
+
 func scale(n uint64, f float64) uint64 {
 	return uint64(float64(n) * f)
 }
@@ -6,6 +6,7 @@ package disk
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -50,22 +51,52 @@ func (d SpaceUsageErr) Error() string {
 }
 
 func (d SpaceUsageErr) Table() [][]string {
+	// Only show inodes when they were checked, since the ratio based check
+	// does not consider them.
+	withInodes := false
+	for _, usage := range d.usage {
+		if usage.GetRequiredInodes() > 0 {
+			withInodes = true
+		}
+	}
+
 	var rows [][]string
 
 	for _, usage := range d.usage {
 		available := FormatBytes(usage.GetAvailable())
 		required := FormatBytes(usage.GetRequired())
-		needed := FormatBytes(usage.GetRequired() - usage.GetAvailable())
+		needed := FormatBytes(shortfall(usage.GetAvailable(), usage.GetRequired()))
+
+		row := []string{usage.GetHost(), usage.GetFs(), needed, available, required}
+		if withInodes {
+			row = append(row,
+				strconv.FormatUint(shortfall(usage.GetAvailableInodes(), usage.GetRequiredInodes()), 10),
+				strconv.FormatUint(usage.GetAvailableInodes(), 10),
+				strconv.FormatUint(usage.GetRequiredInodes(), 10))
+		}
 
-		rows = append(rows, []string{usage.GetHost(), usage.GetFs(), needed, available, required})
+		rows = append(rows, row)
 	}
 
 	sort.Sort(tableRows(rows))
-	rows = append([][]string{{"Hostname", "Filesystem", "Shortfall", "Available", "Required"}}, rows...)
+
+	header := []string{"Hostname", "Filesystem", "Shortfall", "Available", "Required"}
+	if withInodes {
+		header = append(header, "Inode Shortfall", "Inodes Available", "Inodes Required")
+	}
+	rows = append([][]string{header}, rows...)
 
 	return rows
 }
 
+func shortfall(available uint64, required uint64) uint64 {
+	if available >= required {
+		return 0
+	}
+
+	return required - available
+}
+
 func FormatBytes(kb uint64) string {
 	bytes := float64(kb)
 	units := []string{"KB", "MB", "GB", "TB", "PB"}
@@ -74,3 +74,35 @@ func TestDiskSpaceError(t *testing.T) {
 		t.Errorf("got table %q, want %q", rows, expected)
 	}
 }
+
+func TestDiskSpaceErrorWithInodes(t *testing.T) {
+	err := disk.NewSpaceUsageError(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage{
+		disk.FilesystemHost{Filesystem: "/data", Host: "sdw1"}: {
+			Fs:              "/data",
+			Host:            "sdw1",
+			Available:       2048,
+			Required:        1024,
+			AvailableInodes: 100,
+			RequiredInodes:  250,
+		},
+		disk.FilesystemHost{Filesystem: "/", Host: "mdw"}: {
+			Fs:              "/",
+			Host:            "mdw",
+			Available:       1024,
+			Required:        2048,
+			AvailableInodes: 100,
+			RequiredInodes:  50,
+		},
+	})
+
+	rows := err.Table()
+
+	expected := [][]string{
+		{"Hostname", "Filesystem", "Shortfall", "Available", "Required", "Inode Shortfall", "Inodes Available", "Inodes Required"},
+		{"mdw", "/", disk.FormatBytes(1024), disk.FormatBytes(1024), disk.FormatBytes(2048), "0", "100", "50"},
+		{"sdw1", "/data", disk.FormatBytes(0), disk.FormatBytes(2048), disk.FormatBytes(1024), "150", "100", "250"},
+	}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("got table %q, want %q", rows, expected)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+	Package dumpfilter massages SQL dumps by removing known differences between
+	Greenplum major versions. Different sets of rules are applied for dumps
+	from Greenplum 5 and 6. In general, the below set of rules are applied on
+	the dump.
+
+	- Line rules are regular expressions that will cause any matching lines to
+	be removed immediately.
+
+	- Block rules are regular expressions that cause any matching lines, and any
+	preceding comments or blank lines, to be removed.
+
+	- Formatting rules are a set of functions that can format the sql statement tokens
+	into a desired format
+*/
+package dumpfilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+func newRules(version int) *rules {
+	if version == 5 {
+		return rules5x()
+	}
+
+	return rules6x()
+}
+
+func writeBufAndLine(out io.Writer, buf []string, line string) ([]string, error) {
+	// We want to keep this line. Flush and empty our buffer first.
+	if len(buf) > 0 {
+		if err := write(out, buf...); err != nil {
+			return nil, err
+		}
+		buf = (buf)[:0]
+	}
+
+	return buf, write(out, line)
+}
+
+func write(out io.Writer, lines ...string) error {
+	for _, line := range lines {
+		_, err := fmt.Fprintln(out, line)
+		if err != nil {
+			return xerrors.Errorf("writing output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Filter reads a dump taken from a cluster of the given major version and
+// writes the normalized dump to out. Dumps from 5X use the 5X rules; all
+// other versions use the 6X rules.
+func Filter(version int, in io.Reader, out io.Writer) error {
+	versionRules := newRules(version)
+
+	scanner := bufio.NewScanner(in)
+	// there are lines in icw regression suite requiring buffer
+	// to be atleast 10000000, so keeping it a little higher for now.
+	scanner.Buffer(nil, 9800*4024)
+
+	var buf []string // lines buffered for look-ahead
+	var err error
+
+	var formattingContext = NewFormattingContext()
+
+nextline:
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		formattingContext.Find(versionRules.formatters, buf, line)
+		if formattingContext.Formatting() {
+			formattingContext.AddTokens(line)
+			if EndFormatting(line) {
+				stmt, err := formattingContext.Format(buf)
+				if err != nil {
+					return xerrors.Errorf("formatting statement: %w", err)
+				}
+
+				buf, err = writeBufAndLine(out, buf, stmt)
+				if err != nil {
+					return err
+				}
+				formattingContext = NewFormattingContext()
+			}
+			continue nextline
+		}
+
+		// First filter on a line-by-line basis.
+		for _, r := range versionRules.lineRegexes {
+			if r.MatchString(line) {
+				continue nextline
+			}
+		}
+
+		if strings.HasPrefix(line, "--") || len(line) == 0 {
+			// A comment or an empty line. We only want to output this section
+			// if the SQL it's attached to isn't filtered.
+			buf = append(buf, line)
+			continue nextline
+		}
+
+		for _, r := range versionRules.blockRegexes {
+			if r.MatchString(line) {
+				// Discard this line and any buffered comment block.
+				buf = buf[:0]
+				continue nextline
+			}
+		}
+
+		for _, replacementFunc := range versionRules.replacementFuncs {
+			line = replacementFunc(line)
+		}
+
+		buf, err = writeBufAndLine(out, buf, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	if scanner.Err() != nil {
+		return xerrors.Errorf("scanning input: %w", scanner.Err())
+	}
+
+	// Flush our buffer.
+	return write(out, buf...)
+}
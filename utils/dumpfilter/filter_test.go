@@ -1,7 +1,7 @@
 // Copyright (c) 2017-2021 VMware, Inc. or its affiliates
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package dumpfilter
 
 import (
 	"bytes"
@@ -15,7 +15,9 @@ func TestFilter(t *testing.T) {
 		expected := "hello\n"
 		in.WriteString(expected)
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
 		}
@@ -34,7 +36,9 @@ RESET allow_system_table_mods;
 ALTER DATABASE test SET gp_use_legacy_hashops TO 'on';
 `)
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		expected := `
 GRANT ALL ON DATABASE template1 TO gpadmin;
@@ -50,6 +54,26 @@ RESET allow_system_table_mods;
 		}
 	})
 
+	t.Run("does not apply the rules of a previous call", func(t *testing.T) {
+		line := "ALTER DATABASE test SET gp_use_legacy_hashops TO 'on';\n"
+
+		var in6x, out6x bytes.Buffer
+		in6x.WriteString(line)
+		if err := Filter(6, &in6x, &out6x); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		var in5x, out5x bytes.Buffer
+		in5x.WriteString(line)
+		if err := Filter(5, &in5x, &out5x); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if out5x.String() != line {
+			t.Errorf("wrote %q want %q", out5x.String(), line)
+		}
+	})
+
 	t.Run("filters out empty and commented lines attached to filtered SQL", func(t *testing.T) {
 		var in, out bytes.Buffer
 
@@ -66,7 +90,9 @@ CREATE EXTENSION IF NOT EXISTS plpgsql WITH SCHEMA pg_catalog;
 RESET allow_system_table_mods;
 `)
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		expected := `
 GRANT ALL ON DATABASE template1 TO gpadmin;
@@ -96,7 +122,9 @@ RESET allow_system_table_mods;
 
 `)
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		expected := `
 
@@ -127,7 +155,9 @@ START ('2005-12-01 00:00:00'::timestamp without time zone) END ('2006-01-01 00:0
 START ('2005-12-01 00:00:00'::timestamp without time zone) END ('2006-01-01 00:00:00'::timestamp without time zone) EVERY ('1 mon'::interval) WITH (tablename='order_lineitems_1_prt_2', appendonly=true, compresstype=quicklz, orientation=column )
 `
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -147,7 +177,9 @@ START (0::double precision) END (1.89999999999999991::double precision) EVERY (2
 START (0::double precision) END (1.XX::double precision) EVERY (2::double precision) WITH (tablename='multivarblock_parttab_1_prt_p1_2_prt_2', checksum=true, appendonly=true, orientation=column ) 
 `
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -162,7 +194,9 @@ START (0::double precision) END (1.XX::double precision) EVERY (2::double precis
 		expected := "WITH (appendonly='true', compresstype=quicklz, orientation='column'\n"
 		in.WriteString(expected)
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -192,7 +226,9 @@ CREATE VIEW public.t3 AS
 SELECT t1.s2, foo.s2_xform FROM (public.t1 JOIN (SELECT t2.s2, COALESCE((avg(t2.r) - 0.020000), (0)::numeric) AS s2_xform FROM public.t2 GROUP BY t2.s2) foo ON ((t1.s2 = foo.s2)));
 `
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -214,7 +250,9 @@ CREATE RULE two AS
 CREATE RULE two AS ON INSERT TO public.oid_consistency_bar2 DO INSTEAD INSERT INTO public.oid_consistency_foo2 (a) VALUES (1);
 `
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -239,7 +277,9 @@ CREATE TRIGGER after_trigger
     EXECUTE PROCEDURE public.bfv_dml_error_func();
 `
 
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
@@ -252,7 +292,9 @@ CREATE TRIGGER after_trigger
 		in.WriteString(".23	abd	8902342	127.0.0.1	.23	22.42\n")
 
 		expected := ".XX	abd	8902342	127.0.0.1	.XX	22.XX\n"
-		Filter(version6, &in, &out)
+		if err := Filter(6, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
 		}
@@ -264,7 +306,9 @@ CREATE TRIGGER after_trigger
 		in.WriteString(".23	abd	8902342	127.0.0.1	.23	22.42\n")
 
 		expected := ".XX	abd	8902342	127.0.0.1	.XX	22.XX\n"
-		Filter(version5, &in, &out)
+		if err := Filter(5, &in, &out); err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
 		if out.String() != expected {
 			t.Errorf("wrote %q want %q", out.String(), expected)
 		}
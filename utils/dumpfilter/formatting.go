@@ -1,19 +1,20 @@
 // Copyright (c) 2017-2021 VMware, Inc. or its affiliates
 // SPDX-License-Identifier: Apache-2.0
 
-package filters
+package dumpfilter
 
 import (
 	"regexp"
 	"strings"
 )
 
-var (
-	Formatters       []formatter
-	LineRegexes      []*regexp.Regexp
-	BlockRegexes     []*regexp.Regexp
-	ReplacementFuncs []ReplacementFunc
-)
+// rules are the filtering rules for dumps from a Greenplum major version.
+type rules struct {
+	formatters       []formatter
+	lineRegexes      []*regexp.Regexp
+	blockRegexes     []*regexp.Regexp
+	replacementFuncs []ReplacementFunc
+}
 
 // function to identify if the line matches a pattern
 type shouldFormatFunc func(buf []string, line string) bool
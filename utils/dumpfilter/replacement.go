@@ -1,7 +1,7 @@
 // Copyright (c) 2017-2021 VMware, Inc. or its affiliates
 // SPDX-License-Identifier: Apache-2.0
 
-package filters
+package dumpfilter
 
 import "regexp"
 
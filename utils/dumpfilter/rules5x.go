@@ -0,0 +1,18 @@
+// Copyright (c) 2017-2021 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package dumpfilter
+
+func rules5x() *rules {
+	return &rules{
+		replacementFuncs: []ReplacementFunc{
+			ReplacePrecision,
+			Replacements5X,
+		},
+
+		// patten matching functions and corresponding formatting functions
+		formatters: []formatter{
+			{shouldFormat: IsViewOrRuleDdl, format: FormatViewOrRuleDdl},
+		},
+	}
+}
@@ -1,13 +1,13 @@
 // Copyright (c) 2017-2021 VMware, Inc. or its affiliates
 // SPDX-License-Identifier: Apache-2.0
 
-package filters
+package dumpfilter
 
 import (
 	"regexp"
 )
 
-func Init6x() {
+func rules6x() *rules {
 	// linePatterns remove exactly what is matched, on a line-by-line basis.
 	linePatterns := []string{
 		`ALTER DATABASE .+ SET gp_use_legacy_hashops TO 'on';`,
@@ -25,22 +25,26 @@ func Init6x() {
 		"COMMENT ON DATABASE postgres IS",
 	}
 
-	ReplacementFuncs = []ReplacementFunc{
-		FormatWithClause,
-		ReplacePrecision,
-		Replacements6X,
-	}
+	r := &rules{
+		replacementFuncs: []ReplacementFunc{
+			FormatWithClause,
+			ReplacePrecision,
+			Replacements6X,
+		},
 
-	// patten matching functions and corresponding formatting functions
-	Formatters = []formatter{
-		{shouldFormat: IsViewOrRuleDdl, format: FormatViewOrRuleDdl},
-		{shouldFormat: IsTriggerDdl, format: FormatTriggerDdl},
+		// patten matching functions and corresponding formatting functions
+		formatters: []formatter{
+			{shouldFormat: IsViewOrRuleDdl, format: FormatViewOrRuleDdl},
+			{shouldFormat: IsTriggerDdl, format: FormatTriggerDdl},
+		},
 	}
 
 	for _, pattern := range linePatterns {
-		LineRegexes = append(LineRegexes, regexp.MustCompile(pattern))
+		r.lineRegexes = append(r.lineRegexes, regexp.MustCompile(pattern))
 	}
 	for _, pattern := range blockPatterns {
-		BlockRegexes = append(BlockRegexes, regexp.MustCompile(pattern))
+		r.blockRegexes = append(r.blockRegexes, regexp.MustCompile(pattern))
 	}
+
+	return r
 }
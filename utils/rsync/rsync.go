@@ -5,6 +5,7 @@ package rsync
 
 import (
 	"os/exec"
+	"regexp"
 	"runtime"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
@@ -51,16 +52,7 @@ func Rsync(options ...Option) error {
 	args = append(args, dstPath)
 	args = append(args, opts.excludedFiles...)
 
-	utility := "rsync"
-	if runtime.GOOS == "darwin" {
-		// Darwin ships with an older rsync that does not support certain options.
-		// Rather than changing the PATH on the agent for all utilities and
-		// commands, specify the full rsync command here. We also don't want to
-		// affect customer environments.
-		utility = "/usr/local/bin/rsync"
-	}
-
-	cmd := rsyncCommand(utility, args...)
+	cmd := rsyncCommand(utility(), args...)
 
 	// when no streams are specified, capture stderr for the error message
 	stream := step.BufferedStreams{}
@@ -87,6 +79,38 @@ func Rsync(options ...Option) error {
 	return nil
 }
 
+// Version returns the version of the rsync used by Rsync, such as "3.1.3".
+func Version() (string, error) {
+	cmd := rsyncCommand(utility(), "--version")
+
+	gplog.Debug(cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "%q failed with %q", cmd.String(), string(output))
+	}
+
+	matches := versionPattern.FindStringSubmatch(string(output))
+	if len(matches) < 2 {
+		return "", errors.Errorf("parsing rsync version %q", string(output))
+	}
+
+	return matches[1], nil
+}
+
+var versionPattern = regexp.MustCompile(`rsync\s+version\s+v?(\S+)`)
+
+func utility() string {
+	if runtime.GOOS == "darwin" {
+		// Darwin ships with an older rsync that does not support certain options.
+		// Rather than changing the PATH on the agent for all utilities and
+		// commands, specify the full rsync command here. We also don't want to
+		// affect customer environments.
+		return "/usr/local/bin/rsync"
+	}
+
+	return "rsync"
+}
+
 // XXX: for internal testing only
 func SetRsyncCommand(command exectest.Command) {
 	rsyncCommand = command
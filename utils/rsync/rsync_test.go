@@ -5,6 +5,7 @@ package rsync_test
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -20,9 +21,20 @@ import (
 
 func Success() {}
 
+func RsyncVersion() {
+	fmt.Println("rsync  version 3.1.3  protocol version 31")
+	fmt.Println("Copyright (C) 1996-2018 by Andrew Tridgell, Wayne Davison, and others.")
+}
+
+func GarbledVersion() {
+	fmt.Println("not rsync")
+}
+
 func init() {
 	exectest.RegisterMains(
 		Success,
+		RsyncVersion,
+		GarbledVersion,
 	)
 }
 
@@ -395,3 +407,31 @@ func TestRsync(t *testing.T) {
 		}
 	})
 }
+
+func TestVersion(t *testing.T) {
+	testlog.SetupLogger()
+
+	t.Run("parses the rsync version", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(RsyncVersion))
+		defer rsync.ResetRsyncCommand()
+
+		version, err := rsync.Version()
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		if version != "3.1.3" {
+			t.Errorf("got version %q want %q", version, "3.1.3")
+		}
+	})
+
+	t.Run("errors when the output has no version", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(GarbledVersion))
+		defer rsync.ResetRsyncCommand()
+
+		_, err := rsync.Version()
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
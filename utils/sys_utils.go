@@ -124,6 +124,24 @@ func GetTablespaceMappingFile() string {
 	return filepath.Join(GetTablespaceDir(), "tablespaces.txt")
 }
 
+// GetSourceSchemaDump returns the path of the schema-only dump of the source
+// cluster taken during initialize.
+func GetSourceSchemaDump() string {
+	return filepath.Join(GetStateDir(), "source_schema.sql")
+}
+
+// GetTargetSchemaDump returns the path of the schema-only dump of the target
+// cluster taken during execute.
+func GetTargetSchemaDump() string {
+	return filepath.Join(GetStateDir(), "target_schema.sql")
+}
+
+// GetSmokeTestResultsDir returns the directory where the output of each smoke
+// test run against the target cluster is stored.
+func GetSmokeTestResultsDir() string {
+	return filepath.Join(GetStateDir(), "smoke_tests")
+}
+
 func GetAddMirrorsConfig() string {
 	return filepath.Join(GetStateDir(), "add_mirrors_config")
 }
@@ -216,6 +234,23 @@ func Sanitize(ports []int) []int {
 	return dedupe
 }
 
+// UnavailablePorts returns the ports that cannot be bound on the local host,
+// such as those in use by other services.
+func UnavailablePorts(ports []uint32) []uint32 {
+	var unavailable []uint32
+	for _, port := range ports {
+		listener, err := net.Listen("tcp", ":"+strconv.Itoa(int(port)))
+		if err != nil {
+			unavailable = append(unavailable, port)
+			continue
+		}
+
+		listener.Close()
+	}
+
+	return unavailable
+}
+
 // FilterEnv selects only the specified variables from the environment and
 // returns those key/value pairs, in the key=value format expected by
 // os/exec.Cmd.Env.
@@ -3,6 +3,7 @@ package utils_test
 import (
 	"errors"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -170,3 +171,26 @@ func TestRemoveDuplicates(t *testing.T) {
 		}
 	})
 }
+
+func TestUnavailablePorts(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	inUse := uint32(listener.Addr().(*net.TCPAddr).Port)
+
+	free, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	available := uint32(free.Addr().(*net.TCPAddr).Port)
+	free.Close()
+
+	unavailable := utils.UnavailablePorts([]uint32{inUse, available})
+
+	expected := []uint32{inUse}
+	if !reflect.DeepEqual(unavailable, expected) {
+		t.Errorf("got %v want %v", unavailable, expected)
+	}
+}